@@ -35,8 +35,14 @@ const (
 	ignoreEnvFlag = "ignore-env"
 	// qaSkipFlag is the name of the flag that lets you skip all the question answers
 	qaSkipFlag = "qa-skip"
+	// qaStrictFlag is the name of the flag that makes the run fail if any question is not answered by a config or cache
+	qaStrictFlag = "qa-strict"
 	// qaPersistPasswords is the name of the flag that lets choose to persist passwords
 	qaPersistPasswords = "qa-persist-passwords"
+	// qaCacheMetadataFlag is the name of the flag that stores where and when each answer came from in the QA cache
+	qaCacheMetadataFlag = "qa-cache-metadata"
+	// qaSecretsFlag is the name of the flag that contains list of QA secrets files
+	qaSecretsFlag = "qa-secrets"
 	// configOutFlag is the name of the flag that will point the location to output the config file
 	configOutFlag = "config-out"
 	// qaCacheOutFlag is the name of the flag that will point the location to output the cache file
@@ -51,6 +57,28 @@ const (
 	overwriteFlag = "overwrite"
 	// maxIterationsFlag is the name of the flag that lets you set the maximum number of iterations to allow
 	maxIterationsFlag = "max-iterations"
+	// parallelismFlag is the name of the flag that lets you set the maximum number of transformers to run in parallel
+	parallelismFlag = "parallelism"
+	// detectCacheDirFlag is the name of the flag that lets you set the directory where the directory detection results are cached
+	detectCacheDirFlag = "detect-cache-dir"
+	// dryRunFlag is the name of the flag that lets you see the changes to the output directory without making them
+	dryRunFlag = "dry-run"
+	// resumeFlag is the name of the flag that lets you resume a transformation from the last checkpoint
+	resumeFlag = "resume"
+	// failOnTransformerErrorFlag is the name of the flag that makes the transform fail when any transformer fails
+	failOnTransformerErrorFlag = "fail-on-transformer-error"
+	// servicesFlag is the name of the flag that selects the services to transform using glob patterns
+	servicesFlag = "services"
+	// skipServicesFlag is the name of the flag that selects the services to skip using glob patterns
+	skipServicesFlag = "skip-services"
+	// eventsPortFlag is the name of the flag that lets you stream the progress events as Server-Sent Events on a port
+	eventsPortFlag = "events-port"
+	// eventsFileFlag is the name of the flag that lets you write the progress events to a file as newline delimited json
+	eventsFileFlag = "events-file"
+	// formatFlag is the name of the flag that selects the output format
+	formatFlag = "format"
+	// updateFlag is the name of the flag that overwrites the golden files
+	updateFlag = "update"
 	// customizationsFlag is the path to customizations directory
 	customizationsFlag       = "customizations"
 	qadisablecliFlag         = "qa-disable-cli"
@@ -62,6 +90,13 @@ const (
 	qaDisabledCategoriesFlag = "qa-disable"
 )
 
+type eventsflags struct {
+	// eventsPort is the port where the progress events are streamed as Server-Sent Events
+	eventsPort int
+	// eventsFile is the path of the file where the progress events are written as newline delimited json
+	eventsFile string
+}
+
 type qaflags struct {
 	// qadisablecli disables the CLI engine. To be used with HTTP REST engine
 	qadisablecli bool
@@ -77,10 +112,16 @@ type qaflags struct {
 	setconfigs []string
 	// qaskip lets you skip all the question answers
 	qaskip bool
+	// qaStrict uses the default answers like qaskip, but fails if any question is not answered by a config or cache
+	qaStrict bool
 	// preSets contains a list of preset configurations
 	preSets []string
 	// persistPasswords sets whether to persist the password or not
 	persistPasswords bool
+	// qaCacheMetadata stores where and when each answer came from in the QA cache
+	qaCacheMetadata bool
+	// qaSecrets contains a list of QA secrets files
+	qaSecrets []string
 	// qaEnabledCategories contains list of categories to be enabled
 	qaEnabledCategories []string
 	// qaDisabledCategories contains list of categories to be disabled
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io/fs"
 	"os"
 	"os/signal"
@@ -27,6 +29,7 @@ import (
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/common/download"
 	"github.com/konveyor/move2kube/common/vcs"
+	"github.com/konveyor/move2kube/events"
 	"github.com/konveyor/move2kube/lib"
 	"github.com/konveyor/move2kube/qaengine"
 	plantypes "github.com/konveyor/move2kube/types/plan"
@@ -36,6 +39,7 @@ import (
 )
 
 type planFlags struct {
+	eventsflags
 	maxVCSRepoCloneSize   int64
 	progressServerPort    int
 	planfile              string
@@ -45,15 +49,27 @@ type planFlags struct {
 	transformerSelector   string
 	disableLocalExecution bool
 	failOnEmptyPlan       bool
+	// qaStrict makes the plan fail if any question is not answered by a config
+	qaStrict bool
+	// parallelism is the maximum number of transformers that detect services in parallel
+	parallelism int
+	// detectCacheDir is the directory where the directory detection results are cached
+	detectCacheDir string
+	// updatePlanfile is the path of an existing plan to update with the newly detected services
+	updatePlanfile string
 	//Configs contains a list of config files
 	configs []string
 	//Configs contains a list of key-value configs
 	setconfigs []string
 	//PreSets contains a list of preset configurations
 	preSets []string
+	// qaSecrets contains a list of QA secrets files
+	qaSecrets []string
 }
 
 func planHandler(cmd *cobra.Command, flags planFlags) {
+	startEvents(flags.eventsflags)
+	defer events.Close()
 	ctx, cancel := context.WithCancel(cmd.Context())
 	logrus.AddHook(common.NewCleanupHook(cancel))
 	logrus.AddHook(common.NewCleanupHook(lib.Destroy))
@@ -72,10 +88,31 @@ func planHandler(cmd *cobra.Command, flags planFlags) {
 	planfile := flags.planfile
 	srcpath := flags.srcpath
 	name := flags.name
+	if flags.updatePlanfile != "" {
+		existingPlan := plantypes.Plan{}
+		if err := common.ReadMove2KubeYaml(flags.updatePlanfile, &existingPlan); err != nil {
+			logrus.Fatalf("Failed to read the plan file at path %s to update. Error: %q", flags.updatePlanfile, err)
+		}
+		if !cmd.Flags().Changed(sourceFlag) {
+			srcpath = existingPlan.Spec.SourceDir
+		}
+		if !cmd.Flags().Changed(nameFlag) {
+			name = existingPlan.Name
+		}
+		if !cmd.Flags().Changed(planFlag) {
+			planfile = flags.updatePlanfile
+		}
+		if !cmd.Flags().Changed(customizationsFlag) && existingPlan.Spec.CustomizationsDir != "" {
+			flags.customizationsPath = existingPlan.Spec.CustomizationsDir
+		}
+		if flags.updatePlanfile, err = filepath.Abs(flags.updatePlanfile); err != nil {
+			logrus.Fatalf("Failed to make the plan file path %q absolute. Error: %q", flags.updatePlanfile, err)
+		}
+	}
 	isRemotePath := vcs.IsRemotePath(srcpath)
 	// Check if the default customization folder exists in the working directory.
 	// If not, skip the customization option
-	if !cmd.Flags().Changed(customizationsFlag) {
+	if !cmd.Flags().Changed(customizationsFlag) && flags.customizationsPath == "" {
 		if _, err := os.Stat(common.DefaultCustomizationDir); err == nil {
 			flags.customizationsPath = common.DefaultCustomizationDir
 			// make all path(s) absolute
@@ -101,6 +138,13 @@ func planHandler(cmd *cobra.Command, flags planFlags) {
 			flags.configs[i] = c
 		}
 	}
+	for i, secretsFile := range flags.qaSecrets {
+		absSecretsFile, err := filepath.Abs(secretsFile)
+		if err != nil {
+			logrus.Fatalf("failed to make the QA secrets file path %s absolute. Error: %q", secretsFile, err)
+		}
+		flags.qaSecrets[i] = absSecretsFile
+	}
 
 	customizationsPath := flags.customizationsPath
 	// Global settings
@@ -137,17 +181,28 @@ func planHandler(cmd *cobra.Command, flags planFlags) {
 	} else if fi.IsDir() {
 		planfile = filepath.Join(planfile, common.DefaultPlanFile)
 	}
-	qaengine.StartEngine(true, 0, true)
+	if flags.qaStrict {
+		qaengine.StartStrictEngine()
+	} else {
+		qaengine.StartEngine(true, 0, true)
+	}
 	qaengine.SetupConfigFile("", flags.setconfigs, flags.configs, flags.preSets, false)
+	qaengine.SetupSecretStores(flags.qaSecrets...)
 	if flags.progressServerPort != 0 {
 		startPlanProgressServer(flags.progressServerPort)
 	}
-	p, err := lib.CreatePlan(ctx, srcpath, "", customizationsPath, flags.transformerSelector, name)
+	p, err := lib.CreatePlan(ctx, srcpath, "", customizationsPath, flags.transformerSelector, name, flags.parallelism, flags.detectCacheDir)
 	if err != nil {
 		logrus.Fatalf("failed to create the plan. Error: %q", err)
 	}
-	if err = plantypes.WritePlan(planfile, p); err != nil {
-		logrus.Fatalf("failed to write the plan to file at path %s . Error: %q", planfile, err)
+	detectedPlan := p
+	if flags.updatePlanfile != "" {
+		if p, err = lib.UpdatePlan(flags.updatePlanfile, detectedPlan); err != nil {
+			logrus.Fatalf("failed to update the plan. Error: %q", err)
+		}
+	}
+	if err = lib.WritePlan(planfile, p, detectedPlan); err != nil {
+		logrus.Fatalf("failed to write the plan. Error: %q", err)
 	}
 	logrus.Debugf("Plan : %+v", p)
 	logrus.Infof("Plan can be found at [%s].", planfile)
@@ -157,6 +212,72 @@ func planHandler(cmd *cobra.Command, flags planFlags) {
 		}
 		logrus.Warnf("Did not detect any services in the directory %s . Also we didn't find any default transformers to run.", srcpath)
 	}
+	checkUnansweredQuestions()
+}
+
+type planValidateFlags struct {
+	planfile           string
+	srcpath            string
+	customizationsPath string
+	format             string
+}
+
+func planValidateHandler(flags planValidateFlags) {
+	defer lib.Destroy()
+	if flags.format != textFormat && flags.format != jsonFormat {
+		logrus.Fatalf("The output format '%s' is not supported. Valid formats are '%s' and '%s'.", flags.format, textFormat, jsonFormat)
+	}
+	var err error
+	if flags.srcpath != "" && !vcs.IsRemotePath(flags.srcpath) {
+		if flags.srcpath, err = filepath.Abs(flags.srcpath); err != nil {
+			logrus.Fatalf("Failed to make the source directory path %q absolute. Error: %q", flags.srcpath, err)
+		}
+	}
+	if flags.customizationsPath != "" {
+		if flags.customizationsPath, err = filepath.Abs(flags.customizationsPath); err != nil {
+			logrus.Fatalf("Failed to make the customizations directory path %q absolute. Error: %q", flags.customizationsPath, err)
+		}
+	}
+	if fi, err := os.Stat(flags.planfile); err == nil && fi.IsDir() {
+		flags.planfile = filepath.Join(flags.planfile, common.DefaultPlanFile)
+	}
+	qaengine.StartEngine(true, 0, true)
+	report, err := lib.ValidatePlan(flags.planfile, flags.srcpath, flags.customizationsPath)
+	if err != nil {
+		logrus.Fatalf("Failed to validate the plan. Error: %q", err)
+	}
+	if flags.format == jsonFormat {
+		reportBytes, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			logrus.Fatalf("Failed to encode the validation report as json. Error: %q", err)
+		}
+		fmt.Println(string(reportBytes))
+	} else {
+		fmt.Print(report.String())
+	}
+	if report.HasErrors() {
+		lib.Destroy()
+		os.Exit(1)
+	}
+}
+
+func planDiffHandler(planPath1, planPath2, format string) {
+	if format != textFormat && format != jsonFormat {
+		logrus.Fatalf("The output format '%s' is not supported. Valid formats are '%s' and '%s'.", format, textFormat, jsonFormat)
+	}
+	diff, err := lib.DiffPlans(planPath1, planPath2)
+	if err != nil {
+		logrus.Fatalf("Failed to compare the plans. Error: %q", err)
+	}
+	if format == jsonFormat {
+		diffBytes, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			logrus.Fatalf("Failed to convert the differences to json. Error: %q", err)
+		}
+		fmt.Println(string(diffBytes))
+		return
+	}
+	fmt.Print(diff.String())
 }
 
 // GetPlanCommand returns a command to do the planning
@@ -169,6 +290,7 @@ func GetPlanCommand() *cobra.Command {
 	viper.AutomaticEnv()
 
 	flags := planFlags{}
+	planDiffFormat := textFormat
 	planCmd := &cobra.Command{
 		Use:   "plan",
 		Short: "Plan out a move",
@@ -184,12 +306,44 @@ func GetPlanCommand() *cobra.Command {
 	planCmd.Flags().StringVarP(&flags.transformerSelector, transformerSelectorFlag, "t", "", "Specify the transformer selector.")
 	planCmd.Flags().StringSliceVar(&flags.preSets, preSetFlag, []string{}, "Specify preset config to use.")
 	planCmd.Flags().StringArrayVar(&flags.setconfigs, setConfigFlag, []string{}, "Specify config key-value pairs.")
+	planCmd.Flags().StringSliceVar(&flags.qaSecrets, qaSecretsFlag, []string{}, "Specify QA secrets files. They map problem IDs to environment variables, files and encrypted answers.")
 	planCmd.Flags().IntVar(&flags.progressServerPort, planProgressPortFlag, 0, "Port for the plan progress server. If not provided, the server won't be started.")
 	planCmd.Flags().Int64Var(&flags.maxVCSRepoCloneSize, maxCloneSizeBytesFlag, -1, "Max size in bytes when cloning a git repo. Default -1 is infinite")
 	planCmd.Flags().BoolVar(&flags.disableLocalExecution, common.DisableLocalExecutionFlag, false, "Allow files to be executed locally.")
 	planCmd.Flags().BoolVar(&flags.failOnEmptyPlan, common.FailOnEmptyPlan, false, "If true, planning will exit with a failure exit code if no services are detected (and no default transformers are found).")
+	planCmd.Flags().BoolVar(&flags.qaStrict, qaStrictFlag, false, "Exit with a non-zero exit code if any question was not answered by a config. The default answers are used and all the unanswered questions are listed at the end.")
+
+	planCmd.Flags().IntVar(&flags.parallelism, parallelismFlag, 1, "The maximum number of transformers detecting services in parallel. Default is 1.")
+	planCmd.Flags().StringVar(&flags.detectCacheDir, detectCacheDirFlag, "", "Specify a directory to cache the services detected in each directory. Only the directories that changed are detected in again.")
+	planCmd.Flags().IntVar(&flags.eventsPort, eventsPortFlag, 0, "Port on which the progress events are streamed as Server-Sent Events at /events. If not provided, the events are not streamed.")
+	planCmd.Flags().StringVar(&flags.eventsFile, eventsFileFlag, "", "Path of a file to write the progress events to as newline delimited json.")
+	planCmd.Flags().StringVar(&flags.updatePlanfile, updateFlag, "", "Specify an existing plan file to update. Services are detected again and merged into the plan while preserving the changes made to it. Every plan run stores the detected plan next to the plan with a .base suffix for the next update.")
 
 	must(planCmd.Flags().MarkHidden(planProgressPortFlag))
 
+	planDiffCmd := &cobra.Command{
+		Use:   "diff <plan file> <plan file>",
+		Short: "Show the differences between two plans",
+		Long:  "Show the services, artifacts and transformers that were added, removed or changed between two plan files",
+		Args:  cobra.ExactArgs(2),
+		Run:   func(cmd *cobra.Command, args []string) { planDiffHandler(args[0], args[1], planDiffFormat) },
+	}
+	planDiffCmd.Flags().StringVar(&planDiffFormat, formatFlag, textFormat, "Specify the output format. Valid values are "+textFormat+" and "+jsonFormat+".")
+	planCmd.AddCommand(planDiffCmd)
+
+	validateFlags := planValidateFlags{}
+	planValidateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check a plan against the source and customizations",
+		Long:  "Check that the paths, transformers, transformer selector and service names in a plan are valid for the current source and customizations",
+		Args:  cobra.NoArgs,
+		Run:   func(*cobra.Command, []string) { planValidateHandler(validateFlags) },
+	}
+	planValidateCmd.Flags().StringVarP(&validateFlags.planfile, planFlag, "p", common.DefaultPlanFile, "Specify the plan file to validate.")
+	planValidateCmd.Flags().StringVarP(&validateFlags.srcpath, sourceFlag, "s", "", "Specify the source directory to validate against. By default the source directory in the plan is used.")
+	planValidateCmd.Flags().StringVarP(&validateFlags.customizationsPath, customizationsFlag, "c", "", "Specify the customizations directory to validate against. By default the customizations directory in the plan is used.")
+	planValidateCmd.Flags().StringVar(&validateFlags.format, formatFlag, textFormat, "Specify the output format. Valid values are "+textFormat+" and "+jsonFormat+".")
+	planCmd.AddCommand(planValidateCmd)
+
 	return planCmd
 }
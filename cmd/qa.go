@@ -0,0 +1,167 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/lib"
+	"github.com/konveyor/move2kube/qaengine"
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// yamlFormat prints yaml output
+	yamlFormat = "yaml"
+	// markdownFormat prints a markdown table
+	markdownFormat = "markdown"
+	// keyFileFlag is the name of the flag that contains the path to the key file used to encrypt the answers
+	keyFileFlag = "key-file"
+)
+
+type qaCatalogFlags struct {
+	// customizationsPath contains the path to the customizations directory
+	customizationsPath  string
+	transformerSelector string
+	// format is the output format
+	format string
+}
+
+func qaCatalogHandler(cmd *cobra.Command, flags qaCatalogFlags) {
+	defer lib.Destroy()
+	if flags.format != yamlFormat && flags.format != jsonFormat && flags.format != markdownFormat {
+		logrus.Fatalf("The output format '%s' is not supported. Valid formats are '%s', '%s' and '%s'.", flags.format, yamlFormat, jsonFormat, markdownFormat)
+	}
+	if !cmd.Flags().Changed(customizationsFlag) {
+		if _, err := os.Stat(common.DefaultCustomizationDir); err == nil {
+			flags.customizationsPath = common.DefaultCustomizationDir
+		}
+	}
+	if flags.customizationsPath != "" {
+		var err error
+		if flags.customizationsPath, err = filepath.Abs(flags.customizationsPath); err != nil {
+			logrus.Fatalf("Failed to make the customizations directory path %q absolute. Error: %q", flags.customizationsPath, err)
+		}
+	}
+	qaengine.StartEngine(true, 0, true)
+	catalog, err := lib.GetQACatalog(flags.customizationsPath, flags.transformerSelector)
+	if err != nil {
+		logrus.Fatalf("Failed to get the QA catalog. Error: %q", err)
+	}
+	// the QA mappings can be overridden using the customizations, so load them after the customizations are copied
+	loadQACategories()
+	catalog.AddCategories()
+	switch flags.format {
+	case jsonFormat:
+		catalogBytes, err := json.MarshalIndent(catalog, "", "    ")
+		if err != nil {
+			logrus.Fatalf("Failed to encode the QA catalog as json. Error: %q", err)
+		}
+		fmt.Println(string(catalogBytes))
+	case markdownFormat:
+		fmt.Print(catalog.Markdown())
+	default:
+		catalogBytes, err := yaml.Marshal(catalog)
+		if err != nil {
+			logrus.Fatalf("Failed to encode the QA catalog as yaml. Error: %q", err)
+		}
+		fmt.Print(string(catalogBytes))
+	}
+}
+
+type qaEncryptFlags struct {
+	// keyFile is the path to the key file. A new key is generated if the file does not exist.
+	keyFile string
+}
+
+func qaEncryptHandler(flags qaEncryptFlags) {
+	key, err := qatypes.ReadSecretKeyFile(flags.keyFile)
+	if err != nil {
+		if _, statErr := os.Stat(flags.keyFile); !os.IsNotExist(statErr) {
+			logrus.Fatalf("Failed to read the key file. Error: %q", err)
+		}
+		logrus.Infof("The key file %s does not exist. Generating a new key.", flags.keyFile)
+		if key, err = qatypes.GenerateSecretKeyFile(flags.keyFile); err != nil {
+			logrus.Fatalf("Failed to generate the key file. Error: %q", err)
+		}
+	}
+	answerBytes, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		logrus.Fatalf("Failed to read the answer from the standard input. Error: %q", err)
+	}
+	encrypted, err := qatypes.EncryptSecret(key, strings.TrimRight(string(answerBytes), "\r\n"))
+	if err != nil {
+		logrus.Fatalf("Failed to encrypt the answer. Error: %q", err)
+	}
+	fmt.Println(encrypted)
+}
+
+// GetQACommand returns a command to inspect the questions asked by move2kube
+func GetQACommand() *cobra.Command {
+	must := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+	viper.AutomaticEnv()
+
+	qaCmd := &cobra.Command{
+		Use:   "qa",
+		Short: "Inspect the questions asked during planning and transformation",
+		Long:  "Inspect the questions that move2kube and the transformers can ask during planning and transformation",
+	}
+
+	catalogFlags := qaCatalogFlags{}
+	catalogCmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "List the questions that can be asked by the active transformers",
+		Long: `List the problem IDs of the questions that can be asked by move2kube and the transformers that would be active for the given customizations and transformer selector.
+	Dynamic parts of the problem IDs like service names are shown as '*'. Each question has its type, description, default, options and QA categories.
+	Use the problem IDs as keys in the config file to answer the questions beforehand. Questions asked by starlark transformers and the parameterizer are not listed.`,
+		Args: cobra.NoArgs,
+		Run:  func(cmd *cobra.Command, _ []string) { qaCatalogHandler(cmd, catalogFlags) },
+	}
+	catalogCmd.Flags().StringVarP(&catalogFlags.customizationsPath, customizationsFlag, "c", "", "Specify directory or a git url (see https://move2kube.konveyor.io/concepts/git-support) where customizations are stored. By default we look for "+common.DefaultCustomizationDir)
+	catalogCmd.Flags().StringVarP(&catalogFlags.transformerSelector, transformerSelectorFlag, "t", "", "Specify the transformer selector.")
+	catalogCmd.Flags().StringVar(&catalogFlags.format, formatFlag, yamlFormat, "The output format. Valid values are '"+yamlFormat+"', '"+jsonFormat+"' and '"+markdownFormat+"'.")
+	qaCmd.AddCommand(catalogCmd)
+
+	encryptFlags := qaEncryptFlags{}
+	encryptCmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt an answer for use in a QA secrets file",
+		Long: `Encrypt the answer read from the standard input using the key in the key file. A new key file is created if it does not exist.
+	Put the output in the 'encrypted' field of a secret in a QA secrets file (kind: ` + string(qatypes.QASecretsKind) + `) along with the key file and pass the file using --` + qaSecretsFlag + `.`,
+		Args: cobra.NoArgs,
+		Run:  func(_ *cobra.Command, _ []string) { qaEncryptHandler(encryptFlags) },
+	}
+	encryptCmd.Flags().StringVar(&encryptFlags.keyFile, keyFileFlag, "", "Specify the key file.")
+	must(encryptCmd.MarkFlagRequired(keyFileFlag))
+	qaCmd.AddCommand(encryptCmd)
+
+	return qaCmd
+}
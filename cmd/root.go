@@ -67,5 +67,7 @@ func GetRootCmd() *cobra.Command {
 	rootCmd.AddCommand(GetTransformCommand())
 	rootCmd.AddCommand(GetGenerateDocsCommand())
 	rootCmd.AddCommand(GetGraphCommand())
+	rootCmd.AddCommand(GetTransformersCommand())
+	rootCmd.AddCommand(GetQACommand())
 	return rootCmd
 }
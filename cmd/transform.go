@@ -23,13 +23,16 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime/pprof"
+	"strings"
 	"time"
 
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/common/download"
 	"github.com/konveyor/move2kube/common/vcs"
+	"github.com/konveyor/move2kube/events"
 	"github.com/konveyor/move2kube/lib"
 	"github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -37,6 +40,7 @@ import (
 
 type transformFlags struct {
 	qaflags
+	eventsflags
 	// maxVCSRepoCloneSize is the maximum size in bytes for cloning repos
 	maxVCSRepoCloneSize int64
 	// ignoreEnv tells us whether to use data collected from the local machine
@@ -61,6 +65,20 @@ type transformFlags struct {
 	overwrite bool
 	// maxIterations is the maximum number of iterations to allow before aborting with an error
 	maxIterations int
+	// parallelism is the maximum number of transformers to run in parallel within an iteration
+	parallelism int
+	// detectCacheDir is the directory where the directory detection results are cached while planning
+	detectCacheDir string
+	// dryRun prints the changes that would be made to the output directory without making them
+	dryRun bool
+	// resume continues the transformation from the last checkpoint
+	resume bool
+	// failOnTransformerError returns a non-zero exit code when any transformer fails
+	failOnTransformerError bool
+	// services contains the glob patterns of the names of the services to transform
+	services []string
+	// skipServices contains the glob patterns of the names of the services to skip
+	skipServices []string
 	// CustomizationsPaths contains the path to the customizations directory
 	customizationsPath  string
 	transformerSelector string
@@ -127,6 +145,8 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 		}
 	}
 	vcs.SetMaxRepoCloneSize(flags.maxVCSRepoCloneSize)
+	startEvents(flags.eventsflags)
+	defer events.Close()
 
 	ctx, cancel := context.WithCancel(cmd.Context())
 	logrus.AddHook(common.NewCleanupHook(cancel))
@@ -184,6 +204,13 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 			flags.configs[i] = c
 		}
 	}
+	for i, secretsFile := range flags.qaSecrets {
+		absSecretsFile, err := filepath.Abs(secretsFile)
+		if err != nil {
+			logrus.Fatalf("failed to make the QA secrets file path %s absolute. Error: %q", secretsFile, err)
+		}
+		flags.qaSecrets[i] = absSecretsFile
+	}
 
 	// Global settings
 	common.IgnoreEnvironment = flags.ignoreEnv
@@ -191,11 +218,26 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 	// Parameter cleaning and curate plan
 	transformationPlan := plan.Plan{}
 	preExistingPlan := false
+	if flags.resume {
+		if flags.dryRun {
+			logrus.Fatalf("The --%s and --%s flags cannot be used together.", resumeFlag, dryRunFlag)
+		}
+		if isRemoteOutPath {
+			logrus.Fatalf("The --%s flag cannot be used with a remote output path.", resumeFlag)
+		}
+		if cmd.Flags().Changed(planFlag) {
+			logrus.Warnf("Resuming the transformation using the plan stored in the checkpoint. Ignoring the plan file at path %s", flags.planfile)
+		}
+		flags.planfile = filepath.Join(lib.GetCheckpointDir(getResumeOutputPath(flags.outpath, flags.name, cmd.Flags().Changed(nameFlag))), common.DefaultPlanFile)
+	}
 	fi, err := os.Stat(flags.planfile)
 	if err == nil && fi.IsDir() {
 		flags.planfile = filepath.Join(flags.planfile, common.DefaultPlanFile)
 		_, err = os.Stat(flags.planfile)
 	}
+	if err != nil && flags.resume {
+		logrus.Fatalf("Failed to find a checkpoint to resume from at path %s Error: %q", flags.planfile, err)
+	}
 	if err != nil {
 		logrus.Infof("No plan file found.")
 		if cmd.Flags().Changed(planFlag) {
@@ -205,15 +247,19 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 		// Global settings
 		if !isRemoteOutPath {
 			flags.outpath = filepath.Join(flags.outpath, flags.name)
-			checkOutputPath(flags.outpath, flags.overwrite)
+			if !flags.dryRun {
+				checkOutputPath(flags.outpath, flags.overwrite)
+			}
 			if flags.srcpath != "" && !isRemotePath {
 				checkSourcePath(flags.srcpath)
 				if flags.srcpath == flags.outpath || common.IsParent(flags.outpath, flags.srcpath) || common.IsParent(flags.srcpath, flags.outpath) {
 					logrus.Fatalf("The source path %s and output path %s overlap.", flags.srcpath, flags.outpath)
 				}
 			}
-			if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
-				logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+			if !flags.dryRun {
+				if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
+					logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+				}
 			}
 		}
 		if flags.customizationsPath != "" {
@@ -222,8 +268,11 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 			}
 		}
 		startQA(flags.qaflags)
+		if !flags.dryRun && !isRemoteOutPath {
+			startCheckpointQA(flags.qaflags, lib.GetCheckpointDir(flags.outpath))
+		}
 		logrus.Debugf("Creating a new plan.")
-		transformationPlan, err = lib.CreatePlan(ctx, flags.srcpath, flags.outpath, flags.customizationsPath, flags.transformerSelector, flags.name)
+		transformationPlan, err = lib.CreatePlan(ctx, flags.srcpath, flags.outpath, flags.customizationsPath, flags.transformerSelector, flags.name, flags.parallelism, flags.detectCacheDir)
 		if err != nil {
 			logrus.Fatalf("failed to create the plan. Error: %q", err)
 		}
@@ -265,27 +314,83 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 		}
 		if !isRemoteOutPath {
 			flags.outpath = filepath.Join(flags.outpath, transformationPlan.Name)
-			checkOutputPath(flags.outpath, flags.overwrite)
+			if !flags.dryRun && !flags.resume {
+				checkOutputPath(flags.outpath, flags.overwrite)
+			}
 			if transformationPlan.Spec.SourceDir != "" && (transformationPlan.Spec.SourceDir == flags.outpath || common.IsParent(flags.outpath, transformationPlan.Spec.SourceDir) || common.IsParent(transformationPlan.Spec.SourceDir, flags.outpath)) {
 				logrus.Fatalf("The source path %s and output path %s overlap.", transformationPlan.Spec.SourceDir, flags.outpath)
 			}
-			if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
-				logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+			if !flags.dryRun {
+				if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
+					logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+				}
+			}
+		}
+		if flags.resume {
+			resumeQA(flags.qaflags, lib.GetCheckpointDir(flags.outpath))
+		} else {
+			startQA(flags.qaflags)
+			if !flags.dryRun && !isRemoteOutPath {
+				startCheckpointQA(flags.qaflags, lib.GetCheckpointDir(flags.outpath))
 			}
 		}
-		startQA(flags.qaflags)
 	}
-	if err := lib.Transform(
+	dryRunSummary, err := lib.Transform(
 		ctx,
 		transformationPlan,
 		preExistingPlan,
 		flags.outpath,
 		flags.transformerSelector,
 		flags.maxIterations,
-	); err != nil {
+		flags.parallelism,
+		flags.dryRun,
+		flags.resume,
+		flags.failOnTransformerError,
+		flags.services,
+		flags.skipServices,
+	)
+	if err != nil {
 		logrus.Fatalf("failed to transform. Error: %q", err)
 	}
+	if flags.dryRun {
+		logrus.Infof("Dry run complete. The output directory [%s] was not modified. The following changes would be made:", flags.outpath)
+		fmt.Print(dryRunSummary.String())
+		stopQA()
+		checkUnansweredQuestions()
+		return
+	}
+	if len(flags.services) > 0 || len(flags.skipServices) > 0 {
+		logrus.Warnf("Only the services matching the service filters were transformed. The selected and skipped services are listed in the %s file.", transformertypes.ReportTextFileName)
+	}
 	logrus.Infof("Transformed target artifacts can be found at [%s].", flags.outpath)
+	stopQA()
+	checkUnansweredQuestions()
+}
+
+// getResumeOutputPath returns the output path of the project whose transformation is resumed.
+// If the project name was not given and the output directory has the checkpoint of only one project, that project is resumed.
+func getResumeOutputPath(outDir, name string, nameChanged bool) string {
+	if nameChanged {
+		return filepath.Join(outDir, name)
+	}
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		logrus.Fatalf("Failed to read the output directory %s to find a checkpoint to resume from. Error: %q", outDir, err)
+	}
+	checkpointSuffix := "." + common.CheckpointDir
+	projectNames := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasSuffix(entry.Name(), checkpointSuffix) {
+			projectNames = append(projectNames, strings.TrimSuffix(entry.Name(), checkpointSuffix))
+		}
+	}
+	if len(projectNames) > 1 {
+		logrus.Fatalf("The output directory %s has the checkpoints of the projects %+v . Use --%s to choose the project to resume.", outDir, projectNames, nameFlag)
+	}
+	if len(projectNames) == 1 {
+		return filepath.Join(outDir, projectNames[0])
+	}
+	return filepath.Join(outDir, name)
 }
 
 // GetTransformCommand returns a command to do the transformation
@@ -320,10 +425,13 @@ func GetTransformCommand() *cobra.Command {
 	transformCmd.Flags().StringSliceVarP(&flags.configs, configFlag, "f", []string{}, "Specify config file locations. By default we look for "+common.DefaultConfigFilePath)
 	transformCmd.Flags().StringSliceVar(&flags.preSets, preSetFlag, []string{}, "Specify preset config to use.")
 	transformCmd.Flags().BoolVar(&flags.persistPasswords, qaPersistPasswords, false, "Store passwords in the config and cache. By default passwords are not persisted.")
+	transformCmd.Flags().BoolVar(&flags.qaCacheMetadata, qaCacheMetadataFlag, false, "Store where and when each answer came from in the QA cache. The same information is always written to the "+common.QAAuditLogFile+" file next to the QA cache.")
 	transformCmd.Flags().StringArrayVar(&flags.setconfigs, setConfigFlag, []string{}, "Specify config key-value pairs.")
+	transformCmd.Flags().StringSliceVar(&flags.qaSecrets, qaSecretsFlag, []string{}, "Specify QA secrets files. They map problem IDs to environment variables, files and encrypted answers. The answers from them are never persisted.")
 	transformCmd.Flags().StringVarP(&flags.customizationsPath, customizationsFlag, "c", "", "Specify directory or a git url (see https://move2kube.konveyor.io/concepts/git-support) where customizations are stored. By default we look for "+common.DefaultCustomizationDir)
 	transformCmd.Flags().StringVarP(&flags.transformerSelector, transformerSelectorFlag, "t", "", "Specify the transformer selector.")
 	transformCmd.Flags().BoolVar(&flags.qaskip, qaSkipFlag, false, "Enable/disable the default answers to questions posed in QA Cli sub-system. If disabled, you will have to answer the questions posed by QA during interaction.")
+	transformCmd.Flags().BoolVar(&flags.qaStrict, qaStrictFlag, false, "Use the default answers like --"+qaSkipFlag+", but exit with a non-zero exit code if any question was not answered by a config or cache. All the unanswered questions are listed at the end.")
 	transformCmd.Flags().Int64Var(&flags.maxVCSRepoCloneSize, maxCloneSizeBytesFlag, -1, "Max size in bytes when cloning a git repo. Default -1 is infinite")
 
 	// QA options
@@ -334,6 +442,15 @@ func GetTransformCommand() *cobra.Command {
 	transformCmd.Flags().BoolVar(&flags.ignoreEnv, ignoreEnvFlag, false, "Ignore data from local machine.")
 	transformCmd.Flags().BoolVar(&flags.disableLocalExecution, common.DisableLocalExecutionFlag, false, "Allow files to be executed locally.")
 	transformCmd.Flags().IntVar(&flags.maxIterations, maxIterationsFlag, -1, "The maximum number of iterations to allow. Negative value means infinite. Default is -1.")
+	transformCmd.Flags().IntVar(&flags.parallelism, parallelismFlag, 1, "The maximum number of transformers with disjoint inputs to run in parallel within an iteration, and of transformers detecting services in parallel when there is no plan. Default is 1.")
+	transformCmd.Flags().StringVar(&flags.detectCacheDir, detectCacheDirFlag, "", "Specify a directory to cache the services detected in each directory when there is no plan. Only the directories that changed are detected in again.")
+	transformCmd.Flags().BoolVar(&flags.dryRun, dryRunFlag, false, "Print the files that would be created, overwritten or deleted in the output directory without modifying it.")
+	transformCmd.Flags().BoolVar(&flags.failOnTransformerError, failOnTransformerErrorFlag, false, "Exit with a non-zero exit code if any transformer failed. The failures are listed in the "+transformertypes.ReportTextFileName+" file.")
+	transformCmd.Flags().StringSliceVar(&flags.services, servicesFlag, []string{}, "Only transform the services in the plan whose names match one of these glob patterns. The transformers that are invoked by default still run.")
+	transformCmd.Flags().StringSliceVar(&flags.skipServices, skipServicesFlag, []string{}, "Skip the services in the plan whose names match one of these glob patterns.")
+	transformCmd.Flags().IntVar(&flags.eventsPort, eventsPortFlag, 0, "Port on which the progress events are streamed as Server-Sent Events at /events. If not provided, the events are not streamed.")
+	transformCmd.Flags().StringVar(&flags.eventsFile, eventsFileFlag, "", "Path of a file to write the progress events to as newline delimited json.")
+	transformCmd.Flags().BoolVar(&flags.resume, resumeFlag, false, "Resume the transformation from the last completed iteration using the checkpoint stored next to the output directory of the project, in the <output directory>/<project name>."+common.CheckpointDir+" directory. Use --"+nameFlag+" to choose the project if there are checkpoints of several projects.")
 
 	// Hidden options
 	transformCmd.Flags().BoolVar(&flags.qadisablecli, qadisablecliFlag, false, "Enable/disable the QA Cli sub-system. Without this system, you will have to use the REST API to interact.")
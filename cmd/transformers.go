@@ -0,0 +1,240 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/lib"
+	"github.com/konveyor/move2kube/qaengine"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// textFormat prints human readable output
+	textFormat = "text"
+	// jsonFormat prints json output
+	jsonFormat = "json"
+)
+
+type transformersFlags struct {
+	// customizationsPath contains the path to the customizations directory
+	customizationsPath  string
+	transformerSelector string
+	// format is the output format
+	format string
+	// update overwrites the golden files of the test cases
+	update bool
+}
+
+// setupTransformersCommand copies the customizations and starts a QA engine that uses the default answers
+func setupTransformersCommand(cmd *cobra.Command, flags *transformersFlags) {
+	if flags.format != textFormat && flags.format != jsonFormat {
+		logrus.Fatalf("The output format '%s' is not supported. Valid formats are '%s' and '%s'.", flags.format, textFormat, jsonFormat)
+	}
+	if !cmd.Flags().Changed(customizationsFlag) {
+		if _, err := os.Stat(common.DefaultCustomizationDir); err == nil {
+			flags.customizationsPath = common.DefaultCustomizationDir
+		}
+	}
+	if flags.customizationsPath != "" {
+		var err error
+		if flags.customizationsPath, err = filepath.Abs(flags.customizationsPath); err != nil {
+			logrus.Fatalf("Failed to make the customizations directory path %q absolute. Error: %q", flags.customizationsPath, err)
+		}
+	}
+	qaengine.StartEngine(true, 0, true)
+}
+
+func transformersValidateHandler(cmd *cobra.Command, flags transformersFlags) {
+	defer lib.Destroy()
+	setupTransformersCommand(cmd, &flags)
+	report, err := lib.ValidateTransformers(flags.customizationsPath, flags.transformerSelector)
+	if err != nil {
+		logrus.Fatalf("Failed to validate the transformers. Error: %q", err)
+	}
+	if flags.format == jsonFormat {
+		reportBytes, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			logrus.Fatalf("Failed to encode the validation report as json. Error: %q", err)
+		}
+		fmt.Println(string(reportBytes))
+	} else {
+		fmt.Print(report.String())
+	}
+	if report.HasErrors() {
+		lib.Destroy()
+		os.Exit(1)
+	}
+}
+
+func transformersListHandler(cmd *cobra.Command, flags transformersFlags) {
+	defer lib.Destroy()
+	setupTransformersCommand(cmd, &flags)
+	summaries, err := lib.ListTransformers(flags.customizationsPath, flags.transformerSelector)
+	if err != nil {
+		logrus.Fatalf("Failed to list the transformers. Error: %q", err)
+	}
+	if flags.format == jsonFormat {
+		summariesBytes, err := json.MarshalIndent(summaries, "", "    ")
+		if err != nil {
+			logrus.Fatalf("Failed to encode the list of transformers as json. Error: %q", err)
+		}
+		fmt.Println(string(summariesBytes))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCLASS\tSORT ORDER\tISOLATED\tCONTAINER BASED\tLABELS")
+	for _, summary := range summaries {
+		labelStrs := []string{}
+		for k, v := range summary.Labels {
+			labelStrs = append(labelStrs, k+"="+v)
+		}
+		sort.Strings(labelStrs)
+		fmt.Fprintf(w, "%s\t%s\t%d\t%t\t%t\t%s\n", summary.Name, summary.Class, summary.SortOrder, summary.Isolated, summary.ContainerBased, strings.Join(labelStrs, ","))
+	}
+	w.Flush()
+}
+
+func transformersDescribeHandler(cmd *cobra.Command, name string, flags transformersFlags) {
+	defer lib.Destroy()
+	setupTransformersCommand(cmd, &flags)
+	description, err := lib.DescribeTransformer(flags.customizationsPath, flags.transformerSelector, name)
+	if err != nil {
+		logrus.Fatalf("Failed to describe the transformer '%s' . Error: %q", name, err)
+	}
+	if flags.format == jsonFormat {
+		descriptionBytes, err := json.MarshalIndent(description, "", "    ")
+		if err != nil {
+			logrus.Fatalf("Failed to encode the transformer description as json. Error: %q", err)
+		}
+		fmt.Println(string(descriptionBytes))
+		return
+	}
+	descriptionBytes, err := yaml.Marshal(description)
+	if err != nil {
+		logrus.Fatalf("Failed to encode the transformer description as yaml. Error: %q", err)
+	}
+	fmt.Print(string(descriptionBytes))
+}
+
+func transformersTestHandler(cmd *cobra.Command, name string, testCaseDirs []string, flags transformersFlags) {
+	defer lib.Destroy()
+	setupTransformersCommand(cmd, &flags)
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	report, err := lib.TestTransformer(ctx, flags.customizationsPath, name, testCaseDirs, flags.update)
+	if err != nil {
+		logrus.Fatalf("Failed to test the transformer '%s' . Error: %q", name, err)
+	}
+	if flags.format == jsonFormat {
+		reportBytes, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			logrus.Fatalf("Failed to encode the test report as json. Error: %q", err)
+		}
+		fmt.Println(string(reportBytes))
+	} else {
+		fmt.Print(report.String())
+	}
+	if report.Failed() {
+		lib.Destroy()
+		os.Exit(1)
+	}
+}
+
+func addTransformersFlags(command *cobra.Command, flags *transformersFlags) {
+	command.Flags().StringVarP(&flags.customizationsPath, customizationsFlag, "c", "", "Specify directory or a git url (see https://move2kube.konveyor.io/concepts/git-support) where customizations are stored. By default we look for "+common.DefaultCustomizationDir)
+	command.Flags().StringVarP(&flags.transformerSelector, transformerSelectorFlag, "t", "", "Specify the transformer selector.")
+	command.Flags().StringVar(&flags.format, formatFlag, textFormat, "The output format. Valid values are '"+textFormat+"' and '"+jsonFormat+"'.")
+}
+
+// GetTransformersCommand returns a command to inspect the transformers
+func GetTransformersCommand() *cobra.Command {
+	viper.AutomaticEnv()
+
+	transformersCmd := &cobra.Command{
+		Use:     "transformers",
+		Aliases: []string{"transformer"},
+		Short:   "Inspect and test the built-in and custom transformers",
+		Long:    "Inspect the built-in transformers and the transformers in the customizations directory without running them, or test a single transformer against golden files",
+	}
+
+	validateFlags := transformersFlags{}
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Statically check the transformers for problems",
+		Long: `Load the built-in and custom transformers and check the graph formed by the artifacts they consume and produce and by their dependency and override selectors.
+	Reports transformers that can never run, artifact types that are never produced or never consumed, potential cycles and selector conflicts.
+	Nothing is detected or transformed. Exits with a non-zero exit code if any errors are found.`,
+		Args: cobra.NoArgs,
+		Run:  func(cmd *cobra.Command, _ []string) { transformersValidateHandler(cmd, validateFlags) },
+	}
+	addTransformersFlags(validateCmd, &validateFlags)
+	transformersCmd.AddCommand(validateCmd)
+
+	listFlags := transformersFlags{}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the transformers that would be active",
+		Long:  "List the transformers that would be active for the given customizations and transformer selector, in the order they run.",
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, _ []string) { transformersListHandler(cmd, listFlags) },
+	}
+	addTransformersFlags(listCmd, &listFlags)
+	transformersCmd.AddCommand(listCmd)
+
+	describeFlags := transformersFlags{}
+	describeCmd := &cobra.Command{
+		Use:   "describe <name>",
+		Short: "Show the resolved configuration of a transformer",
+		Long:  "Show the resolved configuration of an active transformer including its spec, config, templates directory and the transformers it overrides.",
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { transformersDescribeHandler(cmd, args[0], describeFlags) },
+	}
+	addTransformersFlags(describeCmd, &describeFlags)
+	transformersCmd.AddCommand(describeCmd)
+
+	testFlags := transformersFlags{}
+	testCmd := &cobra.Command{
+		Use:   "test <name> <test case directory>...",
+		Short: "Test a transformer against golden files",
+		Long: `Run a single transformer against the input artifacts and the source directory of each test case and compare the path mappings and artifacts it creates with the golden files.
+	A test case directory contains the input artifacts in '` + transformertypes.TransformerTestInputArtifactsFileName + `', an optional source directory '` + transformertypes.TransformerTestSourceDirName + `' and the golden files in '` + transformertypes.TransformerTestGoldenDirName + `'.
+	A directory without '` + transformertypes.TransformerTestInputArtifactsFileName + `' is treated as a suite of test cases. Use --update to create or overwrite the golden files.
+	Paths in the input artifacts and the golden files use $SOURCE for the source directory and $CONTEXT for the directory containing the transformer yaml.`,
+		Args: cobra.MinimumNArgs(2),
+		Run:  func(cmd *cobra.Command, args []string) { transformersTestHandler(cmd, args[0], args[1:], testFlags) },
+	}
+	testCmd.Flags().StringVarP(&testFlags.customizationsPath, customizationsFlag, "c", "", "Specify directory or a git url (see https://move2kube.konveyor.io/concepts/git-support) where customizations are stored. By default we look for "+common.DefaultCustomizationDir)
+	testCmd.Flags().StringVar(&testFlags.format, formatFlag, textFormat, "The output format. Valid values are '"+textFormat+"' and '"+jsonFormat+"'.")
+	testCmd.Flags().BoolVar(&testFlags.update, updateFlag, false, "Overwrite the golden files with the output of the transformer.")
+	transformersCmd.AddCommand(testCmd)
+
+	return transformersCmd
+}
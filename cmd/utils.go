@@ -28,6 +28,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/konveyor/move2kube/assets"
 	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/events"
 	"github.com/konveyor/move2kube/qaengine"
 	qaenginetypes "github.com/konveyor/move2kube/types/qaengine"
 	"github.com/sirupsen/logrus"
@@ -35,6 +36,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// previousQACacheFilePrefix is prepended to the name of the copy of the QA cache in the checkpoint of the transformation being resumed
+const previousQACacheFilePrefix = "previous-"
+
 // checkSourcePath checks if the source path is an existing directory.
 func checkSourcePath(srcpath string) {
 	fi, err := os.Stat(srcpath)
@@ -125,12 +129,8 @@ func getCustomMappingFilePath() (qaenginetypes.QAMappings, error) {
 	return qaMapping, nil
 }
 
-func initDisabledCategories(flags qaflags) {
-	// qa-disable and qa=enable are mutually exclusive
-	if len(flags.qaEnabledCategories) > 0 && len(flags.qaDisabledCategories) > 0 {
-		logrus.Fatalf("--qa-enable and --qa-disable cannot be used together.\n")
-	}
-	// Read the QA categories from the QA mapping file
+// loadQACategories reads the QA categories from the QA mapping file into the QA category map
+func loadQACategories() qaenginetypes.QAMappings {
 	qaMapping, err := getCustomMappingFilePath()
 	if err != nil {
 		logrus.Fatalf("failed to read the QAMappings file. Error: %q", err)
@@ -140,6 +140,15 @@ func initDisabledCategories(flags qaflags) {
 	}
 	common.QACategoryMap["default"] = []string{}
 	common.QACategoryMap["external"] = []string{}
+	return qaMapping
+}
+
+func initDisabledCategories(flags qaflags) {
+	// qa-disable and qa=enable are mutually exclusive
+	if len(flags.qaEnabledCategories) > 0 && len(flags.qaDisabledCategories) > 0 {
+		logrus.Fatalf("--qa-enable and --qa-disable cannot be used together.\n")
+	}
+	qaMapping := loadQACategories()
 	// if --qa-enable is passed, all categories are disabled by default. Otherwise, only categories passed to --qa-disable
 	// are disabled
 	for _, category := range qaMapping.Spec.Categories {
@@ -161,9 +170,32 @@ func initDisabledCategories(flags qaflags) {
 	}
 }
 
+// checkUnansweredQuestions exits with an error if the strict QA engine had to use the default answers for any of the questions
+func checkUnansweredQuestions() {
+	unanswered := qaengine.GetUnansweredProblems()
+	if len(unanswered) == 0 {
+		return
+	}
+	for _, problem := range unanswered {
+		logrus.Errorf("The question '%s' was not answered by any config or cache. Used the default answer: %+v . Question: %s", problem.ID, problem.Default, problem.Desc)
+	}
+	logrus.Fatalf("%d questions were not answered by any config or cache. Add the answers to a config file and rerun.", len(unanswered))
+}
+
+// stopQA stops the QA engine and exits with an error if any of the answers could not be written to the audit log
+func stopQA() {
+	if err := qaengine.StopEngine(); err != nil {
+		logrus.Fatalf("Failed to write the QA audit log. Error: %q", err)
+	}
+}
+
 func startQA(flags qaflags) {
 	initDisabledCategories(flags)
-	qaengine.StartEngine(flags.qaskip, flags.qaport, flags.qadisablecli)
+	if flags.qaStrict {
+		qaengine.StartStrictEngine()
+	} else {
+		qaengine.StartEngine(flags.qaskip, flags.qaport, flags.qadisablecli)
+	}
 	if flags.configOut == "" {
 		qaengine.SetupConfigFile("", flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
 	} else {
@@ -183,28 +215,73 @@ func startQA(flags qaflags) {
 			qaengine.SetupConfigFile(filepath.Join(flags.configOut, common.ConfigFile), flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
 		}
 	}
-	if flags.qaCacheOut != "" {
-		if flags.qaCacheOut == "." {
-			qaengine.SetupWriteCacheFile(common.QACacheFile, flags.persistPasswords)
-		} else if fi, err := os.Stat(flags.qaCacheOut); err == nil {
-			if fi.IsDir() {
-				qaengine.SetupWriteCacheFile(filepath.Join(flags.qaCacheOut, common.QACacheFile), flags.persistPasswords)
-			} else {
-				qaengine.SetupWriteCacheFile(flags.qaCacheOut, flags.persistPasswords)
-			}
-		} else if strings.Contains(filepath.Base(flags.qaCacheOut), ".") {
-			os.MkdirAll(filepath.Dir(flags.qaCacheOut), common.DefaultDirectoryPermission)
-			qaengine.SetupWriteCacheFile(flags.qaCacheOut, flags.persistPasswords)
-		} else {
-			os.MkdirAll(flags.qaCacheOut, common.DefaultDirectoryPermission)
-			qaengine.SetupWriteCacheFile(filepath.Join(flags.qaCacheOut, common.QACacheFile), flags.persistPasswords)
+	if qaCacheFilePath := getQACacheFilePath(flags.qaCacheOut); qaCacheFilePath != "" {
+		os.MkdirAll(filepath.Dir(qaCacheFilePath), common.DefaultDirectoryPermission)
+		qaengine.SetupWriteCacheFile(qaCacheFilePath, flags.persistPasswords, flags.qaCacheMetadata)
+		if err := qaengine.SetupAuditLog(filepath.Join(filepath.Dir(qaCacheFilePath), common.QAAuditLogFile)); err != nil {
+			logrus.Warnf("The answers will not be audited. Error: %q", err)
 		}
 	}
+	qaengine.SetupSecretStores(flags.qaSecrets...)
 	if err := qaengine.WriteStoresToDisk(); err != nil {
 		logrus.Warnf("Failed to write the stores to disk. Error: %q", err)
 	}
 }
 
+// startCheckpointQA writes the answers to a QA cache in the checkpoint directory, so that a resumed transformation
+// reuses the answers of the transformation of the same output directory
+func startCheckpointQA(flags qaflags, checkpointDir string) {
+	if err := os.MkdirAll(checkpointDir, common.DefaultDirectoryPermission); err != nil {
+		logrus.Warnf("Failed to create the checkpoint directory at path %s . The questions will be asked again when resuming. Error: %q", checkpointDir, err)
+		return
+	}
+	qaCacheFilePath := filepath.Join(checkpointDir, common.QACacheFile)
+	if err := qaengine.AddWriteCacheFile(qaCacheFilePath, flags.persistPasswords); err != nil {
+		logrus.Warnf("Failed to write the QA cache at path %s . The questions will be asked again when resuming. Error: %q", qaCacheFilePath, err)
+	}
+}
+
+// resumeQA starts the QA engine and reuses the answers from the QA cache in the checkpoint of the transformation being resumed
+func resumeQA(flags qaflags, checkpointDir string) {
+	qaCacheFilePath := filepath.Join(checkpointDir, common.QACacheFile)
+	previousQACacheFilePath := ""
+	if cacheBytes, err := os.ReadFile(qaCacheFilePath); err != nil {
+		logrus.Warnf("Failed to read the QA cache at path %s . The questions will be asked again. Error: %q", qaCacheFilePath, err)
+	} else {
+		// the cache in the checkpoint is overwritten when the QA engine starts, so keep a copy of the previous answers
+		previousQACacheFilePath = filepath.Join(checkpointDir, previousQACacheFilePrefix+common.QACacheFile)
+		if err := os.WriteFile(previousQACacheFilePath, cacheBytes, common.DefaultFilePermission); err != nil {
+			logrus.Warnf("Failed to copy the QA cache to path %s . The questions will be asked again. Error: %q", previousQACacheFilePath, err)
+			previousQACacheFilePath = ""
+		}
+	}
+	startQA(flags)
+	startCheckpointQA(flags, checkpointDir)
+	if previousQACacheFilePath != "" {
+		qaengine.AddCaches(previousQACacheFilePath)
+	}
+}
+
+// getQACacheFilePath returns the path of the QA cache file given the value of the qa cache out flag
+func getQACacheFilePath(qaCacheOut string) string {
+	if qaCacheOut == "" {
+		return ""
+	}
+	if qaCacheOut == "." {
+		return common.QACacheFile
+	}
+	if fi, err := os.Stat(qaCacheOut); err == nil {
+		if fi.IsDir() {
+			return filepath.Join(qaCacheOut, common.QACacheFile)
+		}
+		return qaCacheOut
+	}
+	if strings.Contains(filepath.Base(qaCacheOut), ".") {
+		return qaCacheOut
+	}
+	return filepath.Join(qaCacheOut, common.QACacheFile)
+}
+
 func startPlanProgressServer(port int) {
 	logrus.Trace("startPlanProgressServer start")
 	var server http.Server
@@ -224,3 +301,27 @@ func startPlanProgressServer(port int) {
 	}()
 	logrus.Trace("startPlanProgressServer end")
 }
+
+// startEvents streams the progress events to the events file and the events server if they were requested.
+// The events are closed when a fatal error is logged.
+func startEvents(flags eventsflags) {
+	if flags.eventsPort == 0 && flags.eventsFile == "" {
+		return
+	}
+	if flags.eventsFile != "" {
+		sink, err := events.NewFileSink(flags.eventsFile)
+		if err != nil {
+			logrus.Fatalf("failed to write the events to a file. Error: %q", err)
+		}
+		events.AddSink(sink)
+	}
+	if flags.eventsPort != 0 {
+		server, err := events.NewSSEServer(flags.eventsPort)
+		if err != nil {
+			logrus.Fatalf("failed to start the events server. Error: %q", err)
+		}
+		events.AddSink(server)
+	}
+	logrus.AddHook(&events.LogHook{})
+	logrus.AddHook(common.NewCleanupHook(events.Close))
+}
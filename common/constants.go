@@ -44,8 +44,12 @@ const (
 	DefaultFilePermission os.FileMode = 0644
 	// QACacheFile defines the location of the QA cache file
 	QACacheFile = types.AppNameShort + "qacache.yaml"
+	// QAAuditLogFile defines the name of the file next to the QA cache file where every answer is logged as newline delimited json
+	QAAuditLogFile = types.AppNameShort + "qaaudit.jsonl"
 	// ConfigFile defines the location of the config file
 	ConfigFile = types.AppNameShort + "config.yaml"
+	// CheckpointDir defines the location of the directory where the transformation checkpoint is stored
+	CheckpointDir = types.AppNameShort + "checkpoint"
 	// IgnoreFilename is the name of the file containing the ignore rules and exceptions
 	IgnoreFilename = "." + types.AppNameShort + "ignore"
 	// WindowsAnnotation tag is used tag a service to run on windows nodes
@@ -134,10 +138,27 @@ const (
 	ConfigRepoLoadPrivKey = ConfigRepoKeysKey + d + "load"
 	//ConfigRepoKeyPathsKey represents paths of keyfiles
 	ConfigRepoKeyPathsKey = ConfigRepoKeysKey + d + "paths"
+	//ConfigRepoPrivKeyPasswordKey represents the password of a private key. The arg is the key name.
+	ConfigRepoPrivKeyPasswordKey = ConfigRepoPrivKey + d + "%s" + d + "password"
+	//ConfigRepoKeyDataForDomainKey represents the private key data for a git domain. The arg is the domain.
+	ConfigRepoKeyDataForDomainKey = ConfigRepoKeysKey + d + "%s" + d + "keyData"
+	//ConfigRepoKeyForDomainKey represents the private key selected for a git domain. The arg is the domain.
+	ConfigRepoKeyForDomainKey = ConfigRepoKeysKey + d + "%s" + d + "key"
+	//ConfigRepoPubKeyForDomainKey represents the public key of a git domain. The arg is the domain.
+	ConfigRepoPubKeyForDomainKey = ConfigRepoLoadPubDomainsKey + d + "%s" + d + "pubkey"
 	//ConfigTransformerTypesKey represents Transformers type Key
 	ConfigTransformerTypesKey = ConfigTransformersKey + d + "types"
+	//ConfigServiceNamingKey represents the service naming Key
+	ConfigServiceNamingKey = BaseKey + d + "planner" + d + "servicenaming"
+	//ConfigServiceNamingStrategyKey represents the service naming strategy Key
+	ConfigServiceNamingStrategyKey = ConfigServiceNamingKey + d + "strategy"
+	//ConfigServiceNamingRewritesKey represents the service name rewrites Key
+	ConfigServiceNamingRewritesKey = ConfigServiceNamingKey + d + "rewrites"
 	//VolQaPrefixKey represents the storage QA
 	VolQaPrefixKey = BaseKey + d + "storage.type"
+	//ConfigStorageTypeForServiceKey represents the storage type of a volume. The arg is the service name.
+	//The empty segment before options is kept for compatibility with the existing configs.
+	ConfigStorageTypeForServiceKey = VolQaPrefixKey + d + "%s" + d + d + "options"
 	//IngressKey represents ingress keyword
 	IngressKey = "ingress"
 	// ConfigIngressClassNameKeySuffix represents the ingress class name
@@ -158,6 +179,14 @@ const (
 	ConfigRouteTLSCertificateKey = RouteKey + d + TLSKey + d + "certificate"
 	//ConfigTargetClusterTypeKey represents target cluster type key
 	ConfigTargetClusterTypeKey = ConfigTargetKey + d + "clustertype"
+	//ConfigTargetClusterTypeForLabelKey represents the cluster type of a target. The arg is the cluster QA label.
+	ConfigTargetClusterTypeForLabelKey = ConfigTargetKey + d + "%s" + d + "clustertype"
+	//ConfigTargetIngressHostKey represents the ingress host of a target. The arg is the cluster QA label.
+	ConfigTargetIngressHostKey = ConfigTargetKey + d + "%s" + d + ConfigIngressHostKeySuffix
+	//ConfigTargetIngressClassNameKey represents the ingress class name of a target. The arg is the cluster QA label.
+	ConfigTargetIngressClassNameKey = ConfigTargetKey + d + "%s" + d + ConfigIngressClassNameKeySuffix
+	//ConfigTargetIngressTLSKey represents the ingress tls secret of a target. The arg is the cluster QA label.
+	ConfigTargetIngressTLSKey = ConfigTargetKey + d + "%s" + d + ConfigIngressTLSKeySuffix
 	//ConfigImageRegistryKey represents image registry Key
 	ConfigImageRegistryKey = ConfigTargetKey + d + "imageregistry"
 	// ConfigCICDKey is for CICD related questions
@@ -204,6 +233,36 @@ const (
 	ConfigServicesDotNetChildProjectsNamesKey = ConfigServicesKey + d + "%s" + d + "childProjects" + d + Special + d + "enable"
 	// ConfigServicesChildModulesSpringProfilesKey is the list of spring profiles for this child module. 1st arg is service name and 2nd is child module name.
 	ConfigServicesChildModulesSpringProfilesKey = ConfigServicesKey + d + "%s" + d + "childModules" + d + "%s" + d + "springBootProfiles"
+	// ConfigServicesChildModuleQASubKey is the QA sub key of a child module. 1st arg is service name and 2nd is child module name.
+	ConfigServicesChildModuleQASubKey = "%s" + d + "childModules" + d + "%s"
+	// ConfigServicesChildProjectQASubKey is the QA sub key of a dot net child project. 1st arg is service name and 2nd is child project name.
+	ConfigServicesChildProjectQASubKey = "%s" + d + "childProjects" + d + "%s"
+	// ConfigServicesDeploymentTypeKey is the type of deployment of a service. The arg is the service name.
+	ConfigServicesDeploymentTypeKey = ConfigServicesKey + d + "%s" + d + ConfigDeploymentTypeKey
+	// ConfigServicesArgoRolloutTypeKey is the type of Argo rollout generated for the services
+	ConfigServicesArgoRolloutTypeKey = ConfigServicesKey + d + ConfigDeploymentTypeKey + d + ConfigArgoRolloutTypeKey
+	// ConfigServicesPortsKey is the list of ports exposed by a service. The arg is the QA sub key of the service.
+	ConfigServicesPortsKey = ConfigServicesKey + d + "%s" + d + ConfigPortsForServiceKeySegment
+	// ConfigServicesPortKey is the port exposed by a service. The arg is the QA sub key of the service.
+	ConfigServicesPortKey = ConfigServicesKey + d + "%s" + d + ConfigPortForServiceKeySegment
+	// ConfigServicesServiceTypeKey is the kind of service/ingress created for a port. 1st arg is service name and 2nd is the port.
+	ConfigServicesServiceTypeKey = ConfigServicesKey + d + "%s" + d + "%s" + d + "servicetype"
+	// ConfigServicesURLPathKey is the ingress path of a port. 1st arg is service name and 2nd is the port.
+	ConfigServicesURLPathKey = ConfigServicesKey + d + "%s" + d + "%s" + d + "urlpath"
+	// ConfigServicesContainerizationOptionKey is the transformer used to containerize a service. The arg is the service name.
+	ConfigServicesContainerizationOptionKey = ConfigServicesKey + d + "%s" + d + ConfigContainerizationOptionServiceKeySegment
+	// ConfigServicesDockerfileTypeKey is the type of Dockerfiles generated for a service. The arg is the service name.
+	ConfigServicesDockerfileTypeKey = ConfigServicesKey + d + "%s" + d + "dockerfileType"
+	// ConfigServicesMavenProfilesKey is the list of maven profiles of a service. The arg is the service name.
+	ConfigServicesMavenProfilesKey = ConfigServicesKey + d + "%s" + d + "mavenProfiles"
+	// ConfigServicesPublishProfileKey is the publish profile of a dot net child project. The arg is the QA sub key of the child project.
+	ConfigServicesPublishProfileKey = ConfigServicesKey + d + "%s" + d + ConfigPublishProfileForServiceKeySegment
+	// ConfigServicesApacheConfFileKey is the apache config file of a service. The arg is the service name.
+	ConfigServicesApacheConfFileKey = ConfigServicesKey + d + "%s" + d + ConfigApacheConfFileForServiceKeySegment
+	// ConfigServicesMainPythonFileKey is the main python file of a service. The arg is the service name.
+	ConfigServicesMainPythonFileKey = ConfigServicesKey + d + "%s" + d + ConfigMainPythonFileForServiceKeySegment
+	// ConfigServicesStartingPythonFileKey is the starting python file of a service. The arg is the service name.
+	ConfigServicesStartingPythonFileKey = ConfigServicesKey + d + "%s" + d + ConfigStartingPythonFileForServiceKeySegment
 	// ConfigTransformersKubernetesArgoCDNamespaceKey represents namespace key for argocd transformer
 	ConfigTransformersKubernetesArgoCDNamespaceKey = ConfigTransformersKey + d + "kubernetes" + d + "argocd" + d + "namespace"
 	// ConfigArgoRolloutTypeKey represents the type of Rollout that should be generated.
@@ -212,6 +271,14 @@ const (
 	VCSKey = BaseKey + d + "vcs"
 	//GitKey represents git qa key
 	GitKey = VCSKey + d + "git"
+	//ConfigGitAuthorNameKey represents the git author name key
+	ConfigGitAuthorNameKey = GitKey + d + "name"
+	//ConfigGitAuthorEmailKey represents the git author email key
+	ConfigGitAuthorEmailKey = GitKey + d + "email"
+	//ConfigGitUserNameKey represents the git username key
+	ConfigGitUserNameKey = GitKey + d + "username"
+	//ConfigGitPasswordKey represents the git password key
+	ConfigGitPasswordKey = GitKey + d + "pass"
 )
 
 const (
@@ -0,0 +1,150 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package common
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	yamlMarshalerType = reflect.TypeOf((*yaml.Marshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// GetJSONSchemaForType generates a JSON schema (draft 4) for the values that GetObjFromInterface can decode into the type.
+// Properties are named after the yaml tags. Null values are allowed since the decoder ignores them.
+// Unknown properties are not allowed so that misspelled keys are caught, unless the struct has an inline map.
+// Types with custom marshalling and recursive types are not constrained.
+func GetJSONSchemaForType(t reflect.Type) map[string]interface{} {
+	return getJSONSchemaForType(t, map[reflect.Type]bool{})
+}
+
+func getJSONSchemaForType(t reflect.Type, inProgress map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if inProgress[t] || hasCustomMarshaller(t) {
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return nullableJSONSchema("boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nullableJSONSchema("integer")
+	case reflect.Float32, reflect.Float64:
+		return nullableJSONSchema("number")
+	case reflect.String:
+		return nullableJSONSchema("string")
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices can be encoded in many ways
+			return map[string]interface{}{}
+		}
+		schema := nullableJSONSchema("array")
+		schema["items"] = getJSONSchemaForType(t.Elem(), inProgress)
+		return schema
+	case reflect.Map:
+		schema := nullableJSONSchema("object")
+		if t.Key().Kind() == reflect.String {
+			schema["additionalProperties"] = getJSONSchemaForType(t.Elem(), inProgress)
+		}
+		return schema
+	case reflect.Struct:
+		inProgress[t] = true
+		defer delete(inProgress, t)
+		properties := map[string]interface{}{}
+		var additionalProperties interface{} = false
+		if inlineMapSchema := addJSONSchemaProperties(t, properties, inProgress); inlineMapSchema != nil {
+			additionalProperties = inlineMapSchema
+		}
+		schema := nullableJSONSchema("object")
+		if len(properties) > 0 {
+			schema["properties"] = properties
+		}
+		schema["additionalProperties"] = additionalProperties
+		return schema
+	}
+	return map[string]interface{}{}
+}
+
+// addJSONSchemaProperties adds the properties for the fields of the struct. Embedded structs and structs tagged
+// as inline are squashed like GetObjFromInterface does. If the struct has an inline map, the schema for its values is returned.
+func addJSONSchemaProperties(t reflect.Type, properties map[string]interface{}, inProgress map[reflect.Type]bool) map[string]interface{} {
+	var inlineMapSchema map[string]interface{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		inline := field.Anonymous || isInlineField(field)
+		if inline && field.Type.Kind() == reflect.Struct && !hasCustomMarshaller(field.Type) {
+			if schema := addJSONSchemaProperties(field.Type, properties, inProgress); schema != nil {
+				inlineMapSchema = schema
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if inline && field.Type.Kind() == reflect.Map && field.Type.Key().Kind() == reflect.String {
+			inlineMapSchema = getJSONSchemaForType(field.Type.Elem(), inProgress)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		properties[name] = getJSONSchemaForType(field.Type, inProgress)
+	}
+	return inlineMapSchema
+}
+
+// isInlineField returns true if the yaml or json tag of the field has the inline option
+func isInlineField(field reflect.StructField) bool {
+	for _, tagName := range []string{"yaml", "json"} {
+		tagParts := strings.Split(field.Tag.Get(tagName), ",")
+		for _, option := range tagParts[1:] {
+			if option == "inline" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasCustomMarshaller(t reflect.Type) bool {
+	for _, marshalerType := range []reflect.Type{yamlMarshalerType, jsonMarshalerType, textMarshalerType} {
+		if t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+			return true
+		}
+	}
+	return false
+}
+
+func nullableJSONSchema(jsonType string) map[string]interface{} {
+	return map[string]interface{}{"type": []interface{}{jsonType, "null"}}
+}
@@ -0,0 +1,77 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package common_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type jsonSchemaTestMeta struct {
+	Kind string `yaml:"kind" json:"kind"`
+}
+
+type jsonSchemaTestSpec struct {
+	Replicas int `yaml:"replicas"`
+}
+
+type jsonSchemaTestObject struct {
+	jsonSchemaTestMeta
+	Spec   jsonSchemaTestSpec `json:",inline"`
+	Name   string             `yaml:"name"`
+	Labels map[string]string  `yaml:",inline"`
+}
+
+type jsonSchemaTestStrict struct {
+	jsonSchemaTestMeta
+	Spec jsonSchemaTestSpec `yaml:",inline"`
+	Name string             `yaml:"name"`
+}
+
+func TestGetJSONSchemaForType(t *testing.T) {
+	testcases := []struct {
+		name  string
+		obj   interface{}
+		doc   map[string]interface{}
+		valid bool
+	}{
+		{name: "known properties", obj: jsonSchemaTestStrict{}, doc: map[string]interface{}{"kind": "a", "replicas": 2, "name": "b"}, valid: true},
+		{name: "misspelled property", obj: jsonSchemaTestStrict{}, doc: map[string]interface{}{"nmae": "b"}, valid: false},
+		{name: "inline struct properties are squashed", obj: jsonSchemaTestStrict{}, doc: map[string]interface{}{"spec": map[string]interface{}{}}, valid: false},
+		{name: "inline map allows other properties", obj: jsonSchemaTestObject{}, doc: map[string]interface{}{"kind": "a", "replicas": 2, "app": "web"}, valid: true},
+		{name: "inline map values are checked", obj: jsonSchemaTestObject{}, doc: map[string]interface{}{"app": 1}, valid: false},
+		{name: "null values are allowed", obj: jsonSchemaTestStrict{}, doc: map[string]interface{}{"name": nil}, valid: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(common.GetJSONSchemaForType(reflect.TypeOf(tc.obj))))
+			if err != nil {
+				t.Fatalf("failed to compile the schema. Error: %q", err)
+			}
+			result, err := schema.Validate(gojsonschema.NewGoLoader(tc.doc))
+			if err != nil {
+				t.Fatalf("failed to validate the document. Error: %q", err)
+			}
+			if result.Valid() != tc.valid {
+				t.Fatalf("expected the document %+v to be valid: %t . Errors: %v", tc.doc, tc.valid, result.Errors())
+			}
+		})
+	}
+}
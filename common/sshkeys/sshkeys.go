@@ -186,7 +186,7 @@ func loadSSHPrivateKeyFromBytes(keyBytes []byte, keyName string) (string, error)
 		if _, ok := err.(*ssh.PassphraseMissingError); !ok {
 			return "", fmt.Errorf("failed to parse as a SSH private key. Error %w", err)
 		}
-		qaKey := common.JoinQASubKeys(common.ConfigRepoPrivKey, `"`+keyName+`"`, "password")
+		qaKey := fmt.Sprintf(common.ConfigRepoPrivKeyPasswordKey, `"`+keyName+`"`)
 		desc := fmt.Sprintf("Enter the password to decrypt the SSH private key '%s' : ", keyName)
 		hints := []string{"Password:"}
 		password := qaengine.FetchPasswordAnswer(qaKey, desc, hints, nil)
@@ -223,7 +223,7 @@ func GetSSHKey(domain string) (string, bool) {
 	}
 
 	if len(privateKeysToConsider) == 1 && privateKeysToConsider[0] == shoudlAskUserForSSHKey {
-		qaKey := common.JoinQASubKeys(common.ConfigRepoKeysKey, `"`+domain+`"`, "keyData")
+		qaKey := fmt.Sprintf(common.ConfigRepoKeyDataForDomainKey, `"`+domain+`"`)
 		validatedKey := ""
 		key := qaengine.FetchStringAnswer(
 			qaKey,
@@ -252,7 +252,7 @@ func GetSSHKey(domain string) (string, bool) {
 	filenames := privateKeysToConsider
 	noAnswer := "none of the above"
 	filenames = append(filenames, noAnswer)
-	qaKey := common.JoinQASubKeys(common.ConfigRepoKeysKey, `"`+domain+`"`, "key")
+	qaKey := fmt.Sprintf(common.ConfigRepoKeyForDomainKey, `"`+domain+`"`)
 	desc := fmt.Sprintf("Select the key to use for the git domain '%s' :", domain)
 	hints := []string{fmt.Sprintf("If none of the keys are correct, select '%s'", noAnswer)}
 	filename := qaengine.FetchSelectAnswer(qaKey, desc, hints, noAnswer, filenames, nil)
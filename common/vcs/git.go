@@ -154,8 +154,8 @@ func pushGitVCS(remotePath, folderName string, maxSize int64) error {
 		return &FailedVCSPush{VCSPath: gitFSPath, Err: fmt.Errorf("failed to add files to staging. Error %+v", err)}
 	}
 
-	authorName := qaengine.FetchStringAnswer(common.JoinQASubKeys(common.GitKey, "name"), "Enter git author name : ", []string{}, "", nil)
-	authorEmail := qaengine.FetchStringAnswer(common.JoinQASubKeys(common.GitKey, "email"), "Enter git author email : ", []string{}, "", nil)
+	authorName := qaengine.FetchStringAnswer(common.ConfigGitAuthorNameKey, "Enter git author name : ", []string{}, "", nil)
+	authorEmail := qaengine.FetchStringAnswer(common.ConfigGitAuthorEmailKey, "Enter git author email : ", []string{}, "", nil)
 	commit, err := worktree.Commit("add move2kube generated output artifacts", &git.CommitOptions{
 		Author: &object.Signature{
 			Name:  authorName,
@@ -172,8 +172,8 @@ func pushGitVCS(remotePath, folderName string, maxSize int64) error {
 		return &FailedVCSPush{VCSPath: gitFSPath, Err: fmt.Errorf("failed to get head. Error : %+v", err)}
 	}
 	if isHTTPS {
-		username := qaengine.FetchStringAnswer(common.JoinQASubKeys(common.GitKey, "username"), "Enter git username : ", []string{}, "", nil)
-		password := qaengine.FetchPasswordAnswer(common.JoinQASubKeys(common.GitKey, "pass"), "Enter git password : ", []string{}, nil)
+		username := qaengine.FetchStringAnswer(common.ConfigGitUserNameKey, "Enter git username : ", []string{}, "", nil)
+		password := qaengine.FetchPasswordAnswer(common.ConfigGitPasswordKey, "Enter git password : ", []string{}, nil)
 		err = repo.Push(&git.PushOptions{
 			RemoteName: "origin",
 			RefSpecs: []config.RefSpec{
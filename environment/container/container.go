@@ -17,6 +17,7 @@
 package container
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -38,8 +39,8 @@ var (
 
 // ContainerEngine defines interface to manage containers
 type ContainerEngine interface {
-	// RunCmdInContainer runs a container
-	RunCmdInContainer(image string, cmd environmenttypes.Command, workingdir string, env []string) (stdout, stderr string, exitcode int, err error)
+	// RunCmdInContainer runs a command in a container. The container is killed when the context is done.
+	RunCmdInContainer(ctx context.Context, image string, cmd environmenttypes.Command, workingdir string, env []string) (stdout, stderr string, exitcode int, err error)
 	// InspectImage gets Inspect output for a container
 	InspectImage(image string) (dockertypes.ImageInspect, error)
 	// TODO: Change paths from map to array
@@ -50,6 +51,8 @@ type ContainerEngine interface {
 	RemoveImage(image string) (err error)
 	CreateContainer(container environmenttypes.Container) (containerid string, err error)
 	StopAndRemoveContainer(containerID string) (err error)
+	// GetContainerIP returns the IP address of a running container
+	GetContainerIP(containerID string) (ip string, err error)
 	// RunContainer runs a container from an image
 	RunContainer(image string, cmd environmenttypes.Command, volsrc string, voldest string) (output string, containerStarted bool, err error)
 	Stat(containerID, name string) (fs.FileInfo, error)
@@ -104,7 +104,7 @@ func (e *dockerEngine) pullImage(image string) error {
 }
 
 // RunCmdInContainer executes a container
-func (e *dockerEngine) RunCmdInContainer(containerID string, cmd environmenttypes.Command, workingdir string, env []string) (stdout, stderr string, exitCode int, err error) {
+func (e *dockerEngine) RunCmdInContainer(ctx context.Context, containerID string, cmd environmenttypes.Command, workingdir string, env []string) (stdout, stderr string, exitCode int, err error) {
 	execConfig := types.ExecConfig{
 		AttachStdout: true,
 		AttachStderr: true,
@@ -112,15 +112,30 @@ func (e *dockerEngine) RunCmdInContainer(containerID string, cmd environmenttype
 		WorkingDir:   workingdir,
 		Env:          env,
 	}
-	cresp, err := e.cli.ContainerExecCreate(e.ctx, containerID, execConfig)
+	cresp, err := e.cli.ContainerExecCreate(ctx, containerID, execConfig)
 	if err != nil {
 		return "", "", -1, fmt.Errorf("failed to execute a process in the container. Error: %w", err)
 	}
-	aresp, err := e.cli.ContainerExecAttach(e.ctx, cresp.ID, types.ExecStartCheck{})
+	aresp, err := e.cli.ContainerExecAttach(ctx, cresp.ID, types.ExecStartCheck{})
 	if err != nil {
 		return "", "", -1, fmt.Errorf("failed to execute a process in the container and attach to it. Error: %w", err)
 	}
 	defer aresp.Close()
+	// kill the process and close the connection when the context is done so that reading the output does not block forever.
+	// The docker API can not kill a single exec'd process, so the whole container is killed. The environment
+	// replaces the killed container when it is reset before the next command.
+	attachDone := make(chan struct{})
+	defer close(attachDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := e.cli.ContainerKill(context.Background(), containerID, "KILL"); err != nil {
+				logrus.Errorf("failed to kill the container with ID '%s' after the command %v was cancelled. Error: %q", containerID, cmd, err)
+			}
+			aresp.Close()
+		case <-attachDone:
+		}
+	}()
 
 	var outBuf, errBuf bytes.Buffer
 	outputDone := make(chan error)
@@ -149,13 +164,13 @@ func (e *dockerEngine) RunCmdInContainer(containerID string, cmd environmenttype
 		}
 		break
 
-	case <-e.ctx.Done():
-		return "", "", 0, e.ctx.Err()
+	case <-ctx.Done():
+		return "", "", 0, ctx.Err()
 	}
 
 	stdoutbytes := outBuf.Bytes()
 	stderrbytes := errBuf.Bytes()
-	res, err := e.cli.ContainerExecInspect(e.ctx, cresp.ID)
+	res, err := e.cli.ContainerExecInspect(ctx, cresp.ID)
 	if err != nil {
 		return
 	}
@@ -205,6 +220,26 @@ func (e *dockerEngine) StopAndRemoveContainer(containerID string) error {
 	return nil
 }
 
+// GetContainerIP returns the IP address of a running container
+func (e *dockerEngine) GetContainerIP(containerID string) (string, error) {
+	inspectOutput, err := e.cli.ContainerInspect(e.ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect the container with ID '%s' . Error: %w", containerID, err)
+	}
+	if inspectOutput.NetworkSettings == nil {
+		return "", fmt.Errorf("the container with ID '%s' is not connected to any network", containerID)
+	}
+	if inspectOutput.NetworkSettings.IPAddress != "" {
+		return inspectOutput.NetworkSettings.IPAddress, nil
+	}
+	for _, network := range inspectOutput.NetworkSettings.Networks {
+		if network != nil && network.IPAddress != "" {
+			return network.IPAddress, nil
+		}
+	}
+	return "", fmt.Errorf("the container with ID '%s' does not have an IP address", containerID)
+}
+
 // CopyDirsIntoImage copies some directories into a container
 func (e *dockerEngine) CopyDirsIntoImage(image, newImageName string, paths map[string]string) (err error) {
 	logrus.Trace("CopyDirsIntoImage start")
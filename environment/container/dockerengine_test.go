@@ -17,6 +17,7 @@
 package container
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -137,7 +138,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 			t.Fatalf("failed to create container with base image '%s' . Error: %q", image, err)
 		}
 		cmd := environmenttypes.Command{"pwd"}
-		stdout, stderr, exitCode, err := provider.RunCmdInContainer(containerID, cmd, testStdout, []string{})
+		stdout, stderr, exitCode, err := provider.RunCmdInContainer(context.Background(), containerID, cmd, testStdout, []string{})
 		if err != nil {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -238,7 +239,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 		t.Logf("created container with container id - %s", containerID)
 
 		cmd := environmenttypes.Command{"stat", "-c", "'%A %u %g'", "dir1/test1.txt"}
-		stdout, stderr, exitCode, err := provider.RunCmdInContainer(containerID, cmd, "/", []string{})
+		stdout, stderr, exitCode, err := provider.RunCmdInContainer(context.Background(), containerID, cmd, "/", []string{})
 		if err != nil || stderr != "" || exitCode != 0 {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -247,7 +248,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 		textfile1Stat := strings.Split(outfromcont, " ")
 
 		cmd = environmenttypes.Command{"stat", "-c", "'%A %u %g'", "dir2/test2.txt"}
-		stdout, stderr, exitCode, err = provider.RunCmdInContainer(containerID, cmd, "/", []string{})
+		stdout, stderr, exitCode, err = provider.RunCmdInContainer(context.Background(), containerID, cmd, "/", []string{})
 		if err != nil || stderr != "" || exitCode != 0 {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -303,7 +304,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 
 		cmd := environmenttypes.Command{"stat", "-c", "'%A %u %g'", "dir1/test1.txt"}
 
-		stdout, stderr, exitCode, err := provider.RunCmdInContainer(containerID, cmd, "/", []string{})
+		stdout, stderr, exitCode, err := provider.RunCmdInContainer(context.Background(), containerID, cmd, "/", []string{})
 		if err != nil || stderr != "" || exitCode != 0 {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -313,7 +314,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 		textfile1Stat := strings.Split(outfromcont, " ")
 
 		cmd = environmenttypes.Command{"stat", "-c", "'%A %u %g'", "dir2/test2.txt"}
-		stdout, stderr, exitCode, err = provider.RunCmdInContainer(containerID, cmd, "/", []string{})
+		stdout, stderr, exitCode, err = provider.RunCmdInContainer(context.Background(), containerID, cmd, "/", []string{})
 		if err != nil || stderr != "" || exitCode != 0 {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -390,7 +391,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 
 		cmd := environmenttypes.Command{"stat", "-c", "'%A %u %g'", "dir1/test1.txt"}
 
-		stdout, stderr, exitCode, err := provider.RunCmdInContainer(containerID, cmd, "/", []string{})
+		stdout, stderr, exitCode, err := provider.RunCmdInContainer(context.Background(), containerID, cmd, "/", []string{})
 		if err != nil || stderr != "" || exitCode != 0 {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -400,7 +401,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 		textfile1Stat := strings.Split(outfromcont, " ")
 
 		cmd = environmenttypes.Command{"stat", "-c", "'%A %u %g'", "dir2/test2.txt"}
-		stdout, stderr, exitCode, err = provider.RunCmdInContainer(containerID, cmd, "/", []string{})
+		stdout, stderr, exitCode, err = provider.RunCmdInContainer(context.Background(), containerID, cmd, "/", []string{})
 		if err != nil || stderr != "" || exitCode != 0 {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -439,7 +440,7 @@ func TestIsBuilderAvailable(t *testing.T) {
 		t.Logf("created container with container id - %s", containerID)
 
 		cmd := environmenttypes.Command{"touch", "test.txt"}
-		stdout, stderr, exitCode, err := provider.RunCmdInContainer(containerID, cmd, "/", []string{})
+		stdout, stderr, exitCode, err := provider.RunCmdInContainer(context.Background(), containerID, cmd, "/", []string{})
 		if err != nil || stderr != "" || exitCode != 0 {
 			t.Fatalf("failed to run the command in the container ID %s . Error: %q", containerID, err)
 		}
@@ -18,6 +18,7 @@ package environment
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/fs"
 	"net"
@@ -79,11 +80,13 @@ type EnvironmentInstance interface {
 	Stat(name string) (fs.FileInfo, error)
 	Download(envpath string) (outpath string, err error)
 	Upload(outpath string) (envpath string, err error)
-	Exec(cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error)
+	Exec(ctx context.Context, cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error)
 	Destroy() error
 
 	GetSource() string
 	GetContext() string
+	// GetHost returns the address at which the servers started in the environment can be reached
+	GetHost() (string, error)
 }
 
 // NewEnvironment creates a new environment
@@ -159,15 +162,23 @@ func (e *Environment) Reset() error {
 		return nil
 	}
 	e.CurrEnvOutputBasePath = ""
+	if e.KeepAlive {
+		return nil
+	}
 	return e.Env.Reset()
 }
 
 // Exec executes an executable within the environment
 func (e *Environment) Exec(cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
+	return e.ExecWithContext(context.Background(), cmd, envList)
+}
+
+// ExecWithContext executes an executable within the environment and kills it when the context is done
+func (e *Environment) ExecWithContext(ctx context.Context, cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
 	if !e.active {
 		return "", "", 0, ErrEnvironmentNotActive
 	}
-	return e.Env.Exec(cmd, envList)
+	return e.Env.Exec(ctx, cmd, envList)
 }
 
 // Destroy destroys all artifacts specific to the environment
@@ -498,6 +509,11 @@ func (e *Environment) GetEnvironmentOutput() string {
 	return e.CurrEnvOutputBasePath
 }
 
+// GetEnvironmentHost returns the address at which the servers started in the environment can be reached
+func (e *Environment) GetEnvironmentHost() (string, error) {
+	return e.Env.GetHost()
+}
+
 // GetProjectName returns the project name
 func (e *Environment) GetProjectName() string {
 	return e.ProjectName
@@ -18,6 +18,7 @@ package environment
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -91,14 +92,14 @@ func (e *Local) Stat(name string) (fs.FileInfo, error) {
 }
 
 // Exec executes an executable within the environment
-func (e *Local) Exec(cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
+func (e *Local) Exec(ctx context.Context, cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
 	if common.DisableLocalExecution {
 		return "", "", 0, fmt.Errorf("local execution prevented by %s flag", common.DisableLocalExecutionFlag)
 	}
 	var outb, errb bytes.Buffer
 	var execcmd *exec.Cmd
 	if len(cmd) > 0 {
-		execcmd = exec.Command(cmd[0], cmd[1:]...)
+		execcmd = exec.CommandContext(ctx, cmd[0], cmd[1:]...)
 	} else {
 		return "", "", 0, fmt.Errorf("no command found to execute")
 	}
@@ -109,6 +110,9 @@ func (e *Local) Exec(cmd environmenttypes.Command, envList []string) (stdout str
 	execcmd.Env = append(execcmd.Env, envList...)
 	if err := execcmd.Run(); err != nil {
 		var ee *exec.ExitError
+		if ctx.Err() != nil {
+			return outb.String(), errb.String(), -1, fmt.Errorf("the command was killed. Error: %w", ctx.Err())
+		}
 		var pe *os.PathError
 		if errors.As(err, &ee) {
 			exitcode = ee.ExitCode()
@@ -184,6 +188,11 @@ func (e *Local) GetSource() string {
 	return e.WorkspaceSource
 }
 
+// GetHost returns the loopback address, since the processes run on the local machine
+func (e *Local) GetHost() (string, error) {
+	return "127.0.0.1", nil
+}
+
 func (e *Local) getEnv() []string {
 	environ := os.Environ()
 	if e.GRPCQAReceiver != nil {
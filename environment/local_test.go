@@ -0,0 +1,56 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+)
+
+func TestLocalExec(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("the sleep command is not available")
+	}
+	local, err := NewLocal(EnvInfo{Context: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatalf("failed to create the local environment. Error: %q", err)
+	}
+	t.Run("command finishes before the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, _, exitcode, err := local.Exec(ctx, environmenttypes.Command{"sleep", "0"}, nil); err != nil || exitcode != 0 {
+			t.Fatalf("expected the command to succeed. Exit code: %d Error: %q", exitcode, err)
+		}
+	})
+	t.Run("command is killed after the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, _, _, err := local.Exec(ctx, environmenttypes.Command{"sleep", "10"}, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a deadline exceeded error. Actual: %q", err)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("expected the command to be killed. It ran for %s", time.Since(start))
+		}
+	})
+}
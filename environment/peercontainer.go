@@ -17,6 +17,7 @@
 package environment
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"net"
@@ -154,7 +155,7 @@ func (e *PeerContainer) Stat(name string) (fs.FileInfo, error) {
 }
 
 // Exec executes a command in the container
-func (e *PeerContainer) Exec(cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
+func (e *PeerContainer) Exec(ctx context.Context, cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
 	cengine, err := container.GetContainerEngine(false)
 	if err != nil {
 		return "", "", 0, fmt.Errorf("failed to get the container engine. Error: %w", err)
@@ -166,7 +167,7 @@ func (e *PeerContainer) Exec(cmd environmenttypes.Command, envList []string) (st
 		envs = append(envs, GRPCEnvName+"="+hostname+":"+port)
 	}
 	envs = append(envs, envList...)
-	return cengine.RunCmdInContainer(e.ContainerInfo.ID, cmd, e.ContainerInfo.WorkingDir, envs)
+	return cengine.RunCmdInContainer(ctx, e.ContainerInfo.ID, cmd, e.ContainerInfo.WorkingDir, envs)
 }
 
 // Destroy destroys the container instance
@@ -236,3 +237,12 @@ func (e *PeerContainer) GetContext() string {
 func (e *PeerContainer) GetSource() string {
 	return e.WorkspaceSource
 }
+
+// GetHost returns the IP address of the container
+func (e *PeerContainer) GetHost() (string, error) {
+	cengine, err := container.GetContainerEngine(false)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the container engine. Error: %w", err)
+	}
+	return cengine.GetContainerIP(e.ContainerInfo.ID)
+}
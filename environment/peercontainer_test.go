@@ -17,6 +17,7 @@
 package environment
 
 import (
+	"context"
 	"net"
 	"os"
 	"testing"
@@ -138,7 +139,7 @@ func TestPeerContainer(t *testing.T) {
 		}
 		defer container.Destroy()
 
-		stdout, stderr, exitcode, err := container.Exec(environmenttypes.Command{"echo", "-n", "Hello World"}, []string{})
+		stdout, stderr, exitcode, err := container.Exec(context.Background(), environmenttypes.Command{"echo", "-n", "Hello World"}, []string{})
 		if stderr != "" || err != nil || exitcode != 0 {
 			t.Fatalf("Error executing command stderr: %v, exitcode: %v, err: %v", stderr, exitcode, err)
 		}
@@ -26,6 +26,8 @@ type EnvInfo struct {
 	ProjectName string
 
 	Isolated bool
+	// KeepAlive keeps the environment instance when the environment is reset, so that long running processes started in it keep running
+	KeepAlive bool
 
 	Source                string
 	Output                string
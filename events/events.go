@@ -0,0 +1,138 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EventType is the type of a progress event
+type EventType string
+
+const (
+	// PlanStartedEventType is emitted when the planning starts
+	PlanStartedEventType EventType = "PlanStarted"
+	// PlanFinishedEventType is emitted when the planning finishes
+	PlanFinishedEventType EventType = "PlanFinished"
+	// DirectoryDetectedEventType is emitted when a transformer finishes detecting services in a directory
+	DirectoryDetectedEventType EventType = "DirectoryDetected"
+	// TransformStartedEventType is emitted when the transformation starts
+	TransformStartedEventType EventType = "TransformStarted"
+	// TransformFinishedEventType is emitted when the transformation finishes
+	TransformFinishedEventType EventType = "TransformFinished"
+	// IterationStartedEventType is emitted when an iteration of the transformation starts
+	IterationStartedEventType EventType = "IterationStarted"
+	// TransformerStartedEventType is emitted when a transformer starts transforming artifacts
+	TransformerStartedEventType EventType = "TransformerStarted"
+	// TransformerFinishedEventType is emitted when a transformer finishes transforming artifacts
+	TransformerFinishedEventType EventType = "TransformerFinished"
+	// ArtifactCreatedEventType is emitted for every artifact created by a transformer
+	ArtifactCreatedEventType EventType = "ArtifactCreated"
+	// PathMappingCreatedEventType is emitted for every path mapping created by a transformer
+	PathMappingCreatedEventType EventType = "PathMappingCreated"
+	// QuestionAskedEventType is emitted when the answer to a question is fetched
+	QuestionAskedEventType EventType = "QuestionAsked"
+	// ErrorEventType is emitted for every error that is logged
+	ErrorEventType EventType = "Error"
+	// HistoryTruncatedEventType is sent to the Server-Sent Events clients that missed events which are no longer kept in memory
+	HistoryTruncatedEventType EventType = "HistoryTruncated"
+)
+
+// Event is a single progress event
+type Event struct {
+	ID   int64                  `json:"id"`
+	Type EventType              `json:"type"`
+	Time time.Time              `json:"time"`
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// Sink receives the events.
+// Send can be called concurrently, so the events emitted at the same time can arrive out of order.
+type Sink interface {
+	Send(event Event) error
+	Close() error
+}
+
+var (
+	sinks     = []Sink{}
+	sinksLock sync.Mutex
+	lastID    int64
+)
+
+// AddSink adds a sink that receives all the events emitted after this call
+func AddSink(sink Sink) {
+	sinksLock.Lock()
+	defer sinksLock.Unlock()
+	sinks = append(sinks, sink)
+}
+
+// IsEnabled returns true if there is at least one sink to receive the events
+func IsEnabled() bool {
+	sinksLock.Lock()
+	defer sinksLock.Unlock()
+	return len(sinks) > 0
+}
+
+// Emit sends an event to all the sinks. It does nothing if there are no sinks.
+// The sinks are called without holding the lock so that a slow sink doesn't block the other callers.
+func Emit(eventType EventType, data map[string]interface{}) {
+	sinksLock.Lock()
+	if len(sinks) == 0 {
+		sinksLock.Unlock()
+		return
+	}
+	lastID++
+	event := Event{ID: lastID, Type: eventType, Time: time.Now(), Data: data}
+	currentSinks := append([]Sink{}, sinks...)
+	sinksLock.Unlock()
+	for _, sink := range currentSinks {
+		if err := sink.Send(event); err != nil {
+			// logging an error here would emit another event
+			logrus.Debugf("failed to send the event %d of type %s to the sink %T . Error: %q", event.ID, event.Type, sink, err)
+		}
+	}
+}
+
+// Close closes all the sinks. Events emitted after this are dropped.
+func Close() {
+	sinksLock.Lock()
+	currentSinks := sinks
+	sinks = []Sink{}
+	sinksLock.Unlock()
+	for _, sink := range currentSinks {
+		if err := sink.Close(); err != nil {
+			logrus.Debugf("failed to close the event sink %T . Error: %q", sink, err)
+		}
+	}
+}
+
+// LogHook emits an error event for every error that is logged
+type LogHook struct{}
+
+// Fire emits the error event
+func (*LogHook) Fire(entry *logrus.Entry) error {
+	Emit(ErrorEventType, map[string]interface{}{"level": entry.Level.String(), "message": entry.Message})
+	return nil
+}
+
+// Levels returns the levels on which the hook gets called
+func (*LogHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
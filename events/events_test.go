@@ -0,0 +1,199 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSink(t *testing.T) {
+	eventsPath := filepath.Join(t.TempDir(), "events.ndjson")
+	sink, err := NewFileSink(eventsPath)
+	if err != nil {
+		t.Fatalf("failed to create the file sink. Error: %q", err)
+	}
+	AddSink(sink)
+	Emit(PlanStartedEventType, map[string]interface{}{"sourceDir": "src"})
+	Emit(PlanFinishedEventType, map[string]interface{}{"services": 2})
+	Close()
+	Emit(ErrorEventType, nil)
+	data, err := os.ReadFile(eventsPath)
+	if err != nil {
+		t.Fatalf("failed to read the events file. Error: %q", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 events. Actual: %d\n%s", len(lines), data)
+	}
+	events := []Event{}
+	for _, line := range lines {
+		event := Event{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("failed to parse the event %s . Error: %q", line, err)
+		}
+		events = append(events, event)
+	}
+	if events[0].Type != PlanStartedEventType || events[0].Data["sourceDir"] != "src" {
+		t.Fatalf("wrong first event: %+v", events[0])
+	}
+	if events[1].Type != PlanFinishedEventType || events[1].ID != events[0].ID+1 {
+		t.Fatalf("wrong second event: %+v", events[1])
+	}
+}
+
+// emittingSink emits an event from Send, which deadlocks if the sinks are called while holding the lock
+type emittingSink struct {
+	sent []EventType
+}
+
+func (s *emittingSink) Send(event Event) error {
+	s.sent = append(s.sent, event.Type)
+	if event.Type != ErrorEventType {
+		Emit(ErrorEventType, nil)
+	}
+	return nil
+}
+
+func (*emittingSink) Close() error {
+	return nil
+}
+
+func TestEmitFromSink(t *testing.T) {
+	sink := &emittingSink{}
+	AddSink(sink)
+	Emit(PlanStartedEventType, nil)
+	Close()
+	if len(sink.sent) != 2 || sink.sent[0] != PlanStartedEventType || sink.sent[1] != ErrorEventType {
+		t.Fatalf("expected the sink to get both the events. Actual: %v", sink.sent)
+	}
+}
+
+func readSSEEvents(port int, lastEventID string, count int) ([]string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:%d/events", port), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the request. Error: %w", err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the events server. Error: %w", err)
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		return nil, fmt.Errorf("wrong content type %s", contentType)
+	}
+	eventTypes := []string{}
+	scanner := bufio.NewScanner(resp.Body)
+	for len(eventTypes) < count && scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "event: ") {
+			eventTypes = append(eventTypes, strings.TrimPrefix(scanner.Text(), "event: "))
+		}
+	}
+	return eventTypes, scanner.Err()
+}
+
+func TestSSEServer(t *testing.T) {
+	server, err := NewSSEServer(0)
+	if err != nil {
+		t.Fatalf("failed to start the events server. Error: %q", err)
+	}
+	AddSink(server)
+	defer Close()
+	Emit(TransformStartedEventType, nil)
+	Emit(TransformerStartedEventType, map[string]interface{}{"transformer": "t1"})
+	firstID := lastID - 1
+	eventTypes, err := readSSEEvents(server.Port(), "", 2)
+	if err != nil {
+		t.Fatalf("failed to read the events. Error: %q", err)
+	}
+	if strings.Join(eventTypes, ",") != "TransformStarted,TransformerStarted" {
+		t.Fatalf("a client that connects late should get all the events. Actual: %v", eventTypes)
+	}
+	type result struct {
+		eventTypes []string
+		err        error
+	}
+	done := make(chan result)
+	go func() {
+		eventTypes, err := readSSEEvents(server.Port(), fmt.Sprint(firstID), 2)
+		done <- result{eventTypes, err}
+	}()
+	Emit(TransformFinishedEventType, nil)
+	r := <-done
+	if r.err != nil {
+		t.Fatalf("failed to read the events. Error: %q", r.err)
+	}
+	if strings.Join(r.eventTypes, ",") != "TransformerStarted,TransformFinished" {
+		t.Fatalf("a client that reconnects should get the events after the last event id. Actual: %v", r.eventTypes)
+	}
+}
+
+func TestSSEServerHistoryTruncated(t *testing.T) {
+	server, err := NewSSEServer(0)
+	if err != nil {
+		t.Fatalf("failed to start the events server. Error: %q", err)
+	}
+	defer server.Close()
+	for i := int64(1); i <= maxSSEHistory+1; i++ {
+		if err := server.Send(Event{ID: i, Type: DirectoryDetectedEventType}); err != nil {
+			t.Fatalf("failed to send the event %d . Error: %q", i, err)
+		}
+	}
+	eventTypes, err := readSSEEvents(server.Port(), "", 2)
+	if err != nil {
+		t.Fatalf("failed to read the events. Error: %q", err)
+	}
+	if strings.Join(eventTypes, ",") != "HistoryTruncated,DirectoryDetected" {
+		t.Fatalf("a client that connects after the history was truncated should be told about it. Actual: %v", eventTypes)
+	}
+	eventTypes, err = readSSEEvents(server.Port(), "1", 2)
+	if err != nil {
+		t.Fatalf("failed to read the events. Error: %q", err)
+	}
+	if strings.Join(eventTypes, ",") != "HistoryTruncated,DirectoryDetected" {
+		t.Fatalf("a client that reconnects with an event id that is no longer in the history should be told about it. Actual: %v", eventTypes)
+	}
+	eventTypes, err = readSSEEvents(server.Port(), fmt.Sprint(maxSSEHistory), 1)
+	if err != nil {
+		t.Fatalf("failed to read the events. Error: %q", err)
+	}
+	if strings.Join(eventTypes, ",") != "DirectoryDetected" {
+		t.Fatalf("a client that reconnects with an event id in the history should get the events after it. Actual: %v", eventTypes)
+	}
+	req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:%d/events", server.Port()), nil)
+	if err != nil {
+		t.Fatalf("failed to create the request. Error: %q", err)
+	}
+	req.Header.Set("Last-Event-ID", "abc")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to connect to the events server. Error: %q", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("an invalid Last-Event-ID should be rejected. Actual status: %d", resp.StatusCode)
+	}
+}
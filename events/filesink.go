@@ -0,0 +1,60 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/konveyor/move2kube/common"
+)
+
+// FileSink writes the events to a file as newline delimited json
+type FileSink struct {
+	file *os.File
+	enc  *json.Encoder
+	// lock keeps the lines of the events sent concurrently from interleaving
+	lock sync.Mutex
+}
+
+// NewFileSink creates the file, overwriting it if it exists, and returns a sink that writes the events to it
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, common.DefaultFilePermission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the events file at path %s . Error: %w", path, err)
+	}
+	return &FileSink{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Send writes the event as a single line of json
+func (s *FileSink) Send(event Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.enc.Encode(event); err != nil {
+		return fmt.Errorf("failed to write the event to the file %s . Error: %w", s.file.Name(), err)
+	}
+	return nil
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,253 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxSSEHistory is the maximum number of events kept in memory for the clients that connect late or reconnect
+	maxSSEHistory = 100000
+	// sseShutdownTimeout is how long the clients get to receive the remaining events when the server is closed
+	sseShutdownTimeout = 2 * time.Second
+)
+
+// SSEServer serves the events to http clients as Server-Sent Events.
+// Clients that connect late get all the events emitted so far, and clients that reconnect
+// with the Last-Event-ID header get the events after that id.
+// Clients that missed events which are no longer in the history get a HistoryTruncated event.
+type SSEServer struct {
+	server   *http.Server
+	listener net.Listener
+	lock     sync.Mutex
+	cond     *sync.Cond
+	history  []Event
+	// dropped is the number of events removed from the start of the history
+	dropped int64
+	closed  bool
+	clients sync.WaitGroup
+}
+
+// NewSSEServer starts a server on the port that serves the events at /events.
+// A random free port is used if the port is 0.
+func NewSSEServer(port int) (*SSEServer, error) {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the port %d . Error: %w", port, err)
+	}
+	s := &SSEServer{listener: listener}
+	s.cond = sync.NewCond(&s.lock)
+	r := mux.NewRouter()
+	r.HandleFunc("/events", s.handleEvents).Methods("GET")
+	s.server = &http.Server{Handler: r}
+	go func() {
+		if err := s.server.Serve(listener); err != http.ErrServerClosed {
+			logrus.Errorf("the events server stopped unexpectedly. Error: %q", err)
+		}
+	}()
+	logrus.Infof("Streaming the events at http://localhost:%d/events", s.Port())
+	return s, nil
+}
+
+// Port returns the port the server is listening on
+func (s *SSEServer) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Send stores the event and notifies the clients
+func (s *SSEServer) Send(event Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return fmt.Errorf("the events server is closed")
+	}
+	s.history = append(s.history, event)
+	if len(s.history) > maxSSEHistory {
+		drop := len(s.history) - maxSSEHistory/2
+		s.history = append([]Event{}, s.history[drop:]...)
+		s.dropped += int64(drop)
+	}
+	s.cond.Broadcast()
+	return nil
+}
+
+// Close lets the clients receive the remaining events and then stops the server
+func (s *SSEServer) Close() error {
+	s.lock.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.lock.Unlock()
+	done := make(chan struct{})
+	go func() {
+		s.clients.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(sseShutdownTimeout):
+		logrus.Debugf("timed out waiting for the clients of the events server to receive the remaining events")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sseShutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// getStartPosition returns the position in the history of the first event to send to a client that
+// received all the events up to the last event id. It returns true if some of the events the client
+// has not received are no longer in the history.
+func (s *SSEServer) getStartPosition(lastID int64) (int64, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if lastID <= 0 {
+		// nextEvents reports the events dropped before the client connected
+		return 0, false
+	}
+	for i, event := range s.history {
+		if event.ID == lastID {
+			return s.dropped + int64(i) + 1, false
+		}
+	}
+	if s.dropped > 0 && (len(s.history) == 0 || lastID < s.history[0].ID) {
+		return s.dropped, true
+	}
+	for i, event := range s.history {
+		if event.ID > lastID {
+			return s.dropped + int64(i), false
+		}
+	}
+	return s.dropped + int64(len(s.history)), false
+}
+
+// nextEvents waits for the events starting at the position in the history and returns them along with
+// the number of events that were missed because they are no longer in the history.
+// It returns false if the server is closed and there are no more events for the client.
+func (s *SSEServer) nextEvents(ctx context.Context, position int64) ([]Event, int64, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for {
+		missed := int64(0)
+		if position < s.dropped {
+			missed = s.dropped - position
+			position = s.dropped
+		}
+		if start := position - s.dropped; start < int64(len(s.history)) {
+			return append([]Event{}, s.history[start:]...), missed, true
+		}
+		if s.closed || ctx.Err() != nil {
+			return nil, missed, false
+		}
+		s.cond.Wait()
+	}
+}
+
+// writeEvent writes the event in the Server-Sent Events format. Events without an id don't change the client's last event id.
+func writeEvent(w http.ResponseWriter, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		logrus.Debugf("failed to encode the event %d as json. Error: %q", event.ID, err)
+		return nil
+	}
+	if event.ID != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+// newHistoryTruncatedEvent returns the event telling the client that some of the events were missed
+func newHistoryTruncatedEvent(lastID, missed int64) Event {
+	data := map[string]interface{}{"lastEventId": lastID}
+	if missed > 0 {
+		data["missedEvents"] = missed
+	}
+	return Event{Type: HistoryTruncatedEventType, Time: time.Now(), Data: data}
+}
+
+func (s *SSEServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	s.clients.Add(1)
+	defer s.clients.Done()
+	lastID := int64(0)
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("the Last-Event-ID header '%s' is not a valid event id", lastEventID), http.StatusBadRequest)
+			return
+		}
+		lastID = id
+	}
+	position, truncated := s.getStartPosition(lastID)
+	// wake up the waiting handler when the client disconnects
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-r.Context().Done():
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			s.cond.Broadcast()
+		case <-done:
+		}
+	}()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if truncated {
+		if err := writeEvent(w, newHistoryTruncatedEvent(lastID, 0)); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+	for {
+		events, missed, ok := s.nextEvents(r.Context(), position)
+		if missed > 0 {
+			if err := writeEvent(w, newHistoryTruncatedEvent(lastID, missed)); err != nil {
+				return
+			}
+		}
+		if !ok {
+			flusher.Flush()
+			return
+		}
+		for _, event := range events {
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			lastID = event.ID
+		}
+		position += int64(len(events)) + missed
+		flusher.Flush()
+	}
+}
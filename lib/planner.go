@@ -19,9 +19,12 @@ package lib
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/common/vcs"
+	"github.com/konveyor/move2kube/events"
+	"github.com/konveyor/move2kube/qaengine"
 	"github.com/konveyor/move2kube/transformer"
 	plantypes "github.com/konveyor/move2kube/types/plan"
 	"github.com/sirupsen/logrus"
@@ -29,10 +32,13 @@ import (
 )
 
 // CreatePlan creates the plan using all the tranformers.
-func CreatePlan(ctx context.Context, inputPath, outputPath string, customizationsPath, transformerSelector, prjName string) (plantypes.Plan, error) {
+// detectParallelism is the maximum number of transformers that detect in directories at the same time
+// and the detection results are cached in detectCacheDir when it is not empty.
+func CreatePlan(ctx context.Context, inputPath, outputPath string, customizationsPath, transformerSelector, prjName string, detectParallelism int, detectCacheDir string) (plantypes.Plan, error) {
 	logrus.Trace("CreatePlan start")
 	defer logrus.Trace("CreatePlan end")
 	plan := plantypes.NewPlan()
+	events.Emit(events.PlanStartedEventType, map[string]interface{}{"sourceDir": inputPath, "name": prjName})
 	remoteInputFSPath, err := vcs.GetClonedPath(inputPath, common.RemoteSourcesFolder, true)
 	if err != nil {
 		return plan, fmt.Errorf("failed to clone the repo '%s'. Error: %w", inputPath, err)
@@ -86,12 +92,134 @@ func CreatePlan(ctx context.Context, inputPath, outputPath string, customization
 	logrus.Info("Configuration loading done")
 
 	logrus.Info("Start planning")
+	transformer.SetDetectOptions(detectParallelism, detectCacheDir)
+	plan.Spec.ServiceNaming = getServiceNamingConfig()
+	transformer.SetServiceNamingConfig(plan.Spec.ServiceNaming)
 	if inputFSPath != "" {
-		plan.Spec.Services, err = transformer.GetServices(plan.Name, inputFSPath, nil)
+		plan.Spec.Services, err = transformer.GetServices(ctx, plan.Name, inputFSPath, nil)
 		if err != nil {
 			return plan, fmt.Errorf("failed to get services from the input directory '%s' . Error: %w", inputFSPath, err)
 		}
 	}
 	logrus.Infof("Planning done. Number of services identified: %d", len(plan.Spec.Services))
+	events.Emit(events.PlanFinishedEventType, map[string]interface{}{"services": len(plan.Spec.Services)})
 	return plan, nil
 }
+
+// getServiceNamingConfig gets the strategy used to name the services that were detected without a name.
+// The strategy is never asked, it defaults to the path prefix strategy unless it is set in the config.
+func getServiceNamingConfig() plantypes.ServiceNamingConfig {
+	strategies := []string{}
+	for _, strategy := range plantypes.ServiceNamingStrategies {
+		strategies = append(strategies, string(strategy))
+	}
+	// Problems without a description are answered by the default engine instead of the interactive engines
+	config := plantypes.ServiceNamingConfig{
+		Strategy: plantypes.ServiceNamingStrategy(qaengine.FetchSelectAnswer(
+			common.ConfigServiceNamingStrategyKey,
+			"",
+			nil,
+			string(plantypes.PathPrefixServiceNamingStrategy),
+			strategies,
+			nil,
+		)),
+	}
+	if config.Strategy != plantypes.RegexServiceNamingStrategy {
+		return config
+	}
+	rewrites := qaengine.FetchMultilineInputAnswer(
+		common.ConfigServiceNamingRewritesKey,
+		"Enter the service name rewrites:",
+		[]string{
+			"One rewrite per line of the form '<regex> " + plantypes.ServiceNameRewriteSeparator + " <replacement>'",
+			"The first matching regex is used. Directories that don't match any regex are named using the default strategy.",
+		},
+		"",
+		func(answer interface{}) error {
+			lines, ok := answer.(string)
+			if !ok {
+				return fmt.Errorf("expected a string. Actual: %T", answer)
+			}
+			_, err := plantypes.ParseServiceNameRewrites(lines)
+			return err
+		},
+	)
+	parsedRewrites, err := plantypes.ParseServiceNameRewrites(rewrites)
+	if err != nil {
+		logrus.Errorf("failed to parse the service name rewrites. Error: %q", err)
+	}
+	config.Rewrites = parsedRewrites
+	return config
+}
+
+// WritePlan writes the plan to the given path and stores the detected plan next to it as the base for future updates
+func WritePlan(planPath string, plan, detectedPlan plantypes.Plan) error {
+	if err := plantypes.WritePlan(planPath, plan); err != nil {
+		return fmt.Errorf("failed to write the plan to file at path %s . Error: %w", planPath, err)
+	}
+	basePlanPath := plantypes.GetPlanBasePath(planPath)
+	if err := plantypes.WritePlan(basePlanPath, detectedPlan); err != nil {
+		return fmt.Errorf("failed to write the base plan used for future updates to file at path %s . Error: %w", basePlanPath, err)
+	}
+	return nil
+}
+
+// UpdatePlan does a three way merge of the detected plan into the plan at the given path.
+// The base plan stored next to the existing plan by WritePlan is used to find the changes made by the user.
+// Without a base plan everything in the existing plan is kept and only the newly detected artifacts are added.
+func UpdatePlan(existingPlanPath string, detectedPlan plantypes.Plan) (plantypes.Plan, error) {
+	currentPlan, err := plantypes.ReadPlan(existingPlanPath, detectedPlan.Spec.SourceDir)
+	if err != nil {
+		return detectedPlan, fmt.Errorf("failed to read the existing plan at path %s . Error: %w", existingPlanPath, err)
+	}
+	basePlanPath := plantypes.GetPlanBasePath(existingPlanPath)
+	if _, err := os.Stat(basePlanPath); err != nil {
+		if !os.IsNotExist(err) {
+			return detectedPlan, fmt.Errorf("failed to stat the base plan at path %s . Error: %w", basePlanPath, err)
+		}
+		logrus.Infof("There is no base plan at path %s . Keeping the existing plan and adding the newly detected services to it.", basePlanPath)
+		return plantypes.MergePlans(plantypes.NewPlan(), currentPlan, detectedPlan), nil
+	}
+	basePlan, err := plantypes.ReadPlan(basePlanPath, detectedPlan.Spec.SourceDir)
+	if err != nil {
+		return detectedPlan, fmt.Errorf("failed to read the base plan at path %s . Error: %w", basePlanPath, err)
+	}
+	return plantypes.MergePlans(basePlan, currentPlan, detectedPlan), nil
+}
+
+// DiffPlans returns the differences between the plans at the given paths
+func DiffPlans(planPath1, planPath2 string) (plantypes.PlanDiff, error) {
+	plan1 := plantypes.Plan{}
+	if err := common.ReadMove2KubeYaml(planPath1, &plan1); err != nil {
+		return plantypes.PlanDiff{}, fmt.Errorf("failed to read the plan at path %s . Error: %w", planPath1, err)
+	}
+	plan2 := plantypes.Plan{}
+	if err := common.ReadMove2KubeYaml(planPath2, &plan2); err != nil {
+		return plantypes.PlanDiff{}, fmt.Errorf("failed to read the plan at path %s . Error: %w", planPath2, err)
+	}
+	return plantypes.DiffPlans(plan1, plan2), nil
+}
+
+// ValidatePlan checks the plan at the given path against the source directory and the customizations.
+// If sourceDir or customizationsPath is empty the one in the plan is used.
+func ValidatePlan(planPath, sourceDir, customizationsPath string) (plantypes.PlanValidationReport, error) {
+	logrus.Trace("ValidatePlan start")
+	defer logrus.Trace("ValidatePlan end")
+	plan, err := plantypes.ReadPlan(planPath, sourceDir)
+	if err != nil {
+		return plantypes.PlanValidationReport{}, fmt.Errorf("failed to read the plan at path %s . Error: %w", planPath, err)
+	}
+	if customizationsPath == "" {
+		customizationsPath = plan.Spec.CustomizationsDir
+	}
+	if customizationsPath != "" {
+		if err := CheckAndCopyCustomizations(customizationsPath); err != nil {
+			return plantypes.PlanValidationReport{}, fmt.Errorf("failed to check and copy the customizations. Error: %w", err)
+		}
+	}
+	transformerYamlPaths, err := transformer.GetTransformerYamlPaths(common.AssetsPath)
+	if err != nil {
+		return plantypes.PlanValidationReport{}, fmt.Errorf("failed to find the transformers. Error: %w", err)
+	}
+	return plantypes.ValidatePlan(plan, transformerYamlPaths), nil
+}
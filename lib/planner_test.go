@@ -0,0 +1,75 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	plantypes "github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+)
+
+func TestUpdatePlanAfterPlan(t *testing.T) {
+	common.TempPath = t.TempDir()
+	sourceDir := t.TempDir()
+	detected := plantypes.NewPlan()
+	detected.Spec.SourceDir = sourceDir
+	for _, serviceName := range []string{"web", "db"} {
+		serviceDir := filepath.Join(sourceDir, serviceName)
+		if err := os.MkdirAll(serviceDir, common.DefaultDirectoryPermission); err != nil {
+			t.Fatalf("failed to create the service directory. Error: %q", err)
+		}
+		detected.Spec.Services[serviceName] = []plantypes.PlanArtifact{{
+			TransformerName: "DockerfileDetector",
+			Artifact: transformertypes.Artifact{
+				Name:  serviceName,
+				Type:  artifacts.ServiceArtifactType,
+				Paths: map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {serviceDir}},
+			},
+		}}
+	}
+	planPath := filepath.Join(t.TempDir(), common.DefaultPlanFile)
+
+	// move2kube plan
+	if err := WritePlan(planPath, detected, detected); err != nil {
+		t.Fatalf("failed to write the plan. Error: %q", err)
+	}
+	// the user removes a service from the plan
+	edited, err := plantypes.ReadPlan(planPath, sourceDir)
+	if err != nil {
+		t.Fatalf("failed to read the plan. Error: %q", err)
+	}
+	delete(edited.Spec.Services, "db")
+	if err := plantypes.WritePlan(planPath, edited); err != nil {
+		t.Fatalf("failed to write the edited plan. Error: %q", err)
+	}
+	// move2kube plan --update
+	updated, err := UpdatePlan(planPath, detected)
+	if err != nil {
+		t.Fatalf("failed to update the plan. Error: %q", err)
+	}
+	if _, ok := updated.Spec.Services["db"]; ok {
+		t.Fatalf("expected the service removed by the user to stay removed. Actual: %+v", updated.Spec.Services)
+	}
+	if len(updated.Spec.Services["web"]) != 1 {
+		t.Fatalf("expected the service 'web' to be kept. Actual: %+v", updated.Spec.Services)
+	}
+}
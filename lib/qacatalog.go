@@ -0,0 +1,215 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/transformer"
+	"github.com/konveyor/move2kube/transformer/kubernetes"
+	plantypes "github.com/konveyor/move2kube/types/plan"
+	qaenginetypes "github.com/konveyor/move2kube/types/qaengine"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// qaCatalogAny replaces the dynamic parts of the problem IDs like service names and registry domains
+const qaCatalogAny = "*"
+
+var (
+	// templatedQuestionRegex matches the templated parts of the router question IDs
+	templatedQuestionRegex = regexp.MustCompile(`"?{{[^}]*}}"?`)
+
+	registryClasses       = []string{"DockerfileImageBuildScript", "ContainerImagesPushScript", "Kubernetes", "Knative", "Tekton", "BuildConfig", "ArgoCD", "ComposeGenerator"}
+	irPreprocessorClasses = []string{"Kubernetes", "Knative", "Tekton", "BuildConfig", "ArgoCD", "ComposeGenerator"}
+	apiResourceClasses    = []string{"Kubernetes", "Knative", "Tekton", "BuildConfig", "ArgoCD"}
+	portsClasses          = []string{"GolangDockerfileGenerator", "WinConsoleAppDockerfileGenerator", "WinSilverLightWebAppDockerfileGenerator"}
+	portClasses           = []string{"ComposeAnalyser", "NodejsDockerfileGenerator", "PHPDockerfileGenerator", "PythonDockerfileGenerator", "RubyDockerfileGenerator", "RustDockerfileGenerator"}
+	childModuleClasses    = []string{"GradleAnalyser", "MavenAnalyser"}
+	childProjectClasses   = []string{"DotNetCoreDockerfileGenerator", "WinWebAppDockerfileGenerator"}
+	dockerfileTypeClasses = []string{"DotNetCoreDockerfileGenerator", "GradleAnalyser", "MavenAnalyser", "WinWebAppDockerfileGenerator"}
+)
+
+// qaCatalogQuestion is a question in the built-in catalog along with the classes of the transformers that ask it.
+// Questions without any classes can be asked irrespective of the transformers that are active.
+type qaCatalogQuestion struct {
+	qaenginetypes.QACatalogEntry
+	classes []string
+}
+
+// GetQACatalog returns the questions that can be asked by the transformers that would be active for the customizations and the transformer selector.
+// Questions asked by external transformers like starlark and the parameterizer depend on their scripts and are not listed.
+func GetQACatalog(customizationsPath, transformerSelector string) (qaenginetypes.QACatalog, error) {
+	if err := initTransformers(customizationsPath, transformerSelector); err != nil {
+		return qaenginetypes.QACatalog{}, err
+	}
+	classToNames := map[string][]string{}
+	transformerNames := []string{}
+	routerQuestions := []qaenginetypes.QACatalogEntry{}
+	clusterTypes := []string{}
+	for _, t := range transformer.GetInitializedTransformers() {
+		tc, _ := t.GetConfig()
+		classToNames[tc.Spec.Class] = append(classToNames[tc.Spec.Class], tc.Name)
+		transformerNames = append(transformerNames, tc.Name)
+		switch tt := t.(type) {
+		case *transformer.Router:
+			routerQuestions = append(routerQuestions, getRouterQACatalogEntry(tt))
+		case *kubernetes.ClusterSelectorTransformer:
+			for clusterType := range tt.Clusters {
+				clusterTypes = append(clusterTypes, clusterType)
+			}
+		}
+	}
+	sort.Strings(transformerNames)
+	sort.Strings(clusterTypes)
+	catalog := qaenginetypes.QACatalog{}
+	for _, question := range getBuiltInQACatalog() {
+		if len(question.classes) > 0 {
+			names := []string{}
+			for _, class := range question.classes {
+				names = append(names, classToNames[class]...)
+			}
+			if len(names) == 0 {
+				continue
+			}
+			sort.Strings(names)
+			question.Transformers = names
+		}
+		switch question.ID {
+		case common.ConfigTransformerTypesKey:
+			question.Options = transformerNames
+			question.Default = transformerNames
+		case anyQAKey(common.ConfigTargetClusterTypeForLabelKey):
+			question.Options = clusterTypes
+		}
+		catalog.Questions = append(catalog.Questions, question.QACatalogEntry)
+	}
+	catalog.Questions = append(catalog.Questions, routerQuestions...)
+	return catalog, nil
+}
+
+// getRouterQACatalogEntry returns the question asked by a router with the templated parts of the ID replaced by a *
+func getRouterQACatalogEntry(t *transformer.Router) qaenginetypes.QACatalogEntry {
+	options := []string{}
+	selector, err := metav1.LabelSelectorAsSelector(&t.RouterConfig.TransformerSelector)
+	if err != nil {
+		logrus.Debugf("failed to get the transformer selector of the router '%s' . Error: %q", t.Config.Name, err)
+	} else {
+		for _, tr := range transformer.GetInitializedTransformersF(selector) {
+			tc, _ := tr.GetConfig()
+			options = append(options, tc.Name)
+		}
+	}
+	sort.Strings(options)
+	return qaenginetypes.QACatalogEntry{
+		ID:           templatedQuestionRegex.ReplaceAllString(t.RouterConfig.RouterQuestion.ID, qaCatalogAny),
+		Type:         qaenginetypes.SelectSolutionFormType,
+		Description:  t.RouterConfig.RouterQuestion.Desc,
+		Options:      options,
+		Transformers: []string{t.Config.Name},
+	}
+}
+
+// anyQAKey replaces all the dynamic parts of the problem ID format with a *
+func anyQAKey(key string) string {
+	return strings.ReplaceAll(key, "%s", qaCatalogAny)
+}
+
+// getBuiltInQACatalog returns the questions asked by move2kube and the built-in transformer classes.
+// The IDs are derived from the same key formats in the common package that are used to ask the questions.
+func getBuiltInQACatalog() []qaCatalogQuestion {
+	anyChildModule := anyQAKey(common.ConfigServicesChildModuleQASubKey)
+	anyChildProject := anyQAKey(common.ConfigServicesChildProjectQASubKey)
+	dockerfileTypeOptions := []string{"no build stage", "build stage in base image", "build stage in every image"}
+	strategies := []string{}
+	for _, strategy := range plantypes.ServiceNamingStrategies {
+		strategies = append(strategies, string(strategy))
+	}
+	q := func(id string, solutionType qaenginetypes.SolutionFormType, desc string, def interface{}, options []string, classes ...string) qaCatalogQuestion {
+		return qaCatalogQuestion{
+			QACatalogEntry: qaenginetypes.QACatalogEntry{ID: id, Type: solutionType, Description: desc, Default: def, Options: options},
+			classes:        classes,
+		}
+	}
+	return []qaCatalogQuestion{
+		// planning and transformation
+		q(common.TransformerSelectorKey, qaenginetypes.InputSolutionFormType, "Specify a Kubernetes style selector to select only the transformers that you want to run.", "", nil),
+		q(common.ConfigTransformerTypesKey, qaenginetypes.MultiSelectSolutionFormType, "Select all transformer types that you are interested in:", nil, nil),
+		q(common.ConfigServiceNamingStrategyKey, qaenginetypes.SelectSolutionFormType, "Select the strategy used to name the services:", string(plantypes.PathPrefixServiceNamingStrategy), strategies),
+		q(common.ConfigServiceNamingRewritesKey, qaenginetypes.MultilineInputSolutionFormType, "Enter the service name rewrites:", "", nil),
+		q(common.ConfigServicesNamesKey, qaenginetypes.MultiSelectSolutionFormType, "Select all services that are needed:", nil, nil),
+		q(common.ConfigSpawnContainersKey, qaenginetypes.ConfirmSolutionFormType, "Allow spawning containers?", false, nil),
+		// ssh keys and git
+		q(common.ConfigRepoLoadPubKey, qaenginetypes.ConfirmSolutionFormType, "Load the public keys of the git domains from the known_hosts file?", false, nil),
+		q(common.ConfigRepoLoadPrivKey, qaenginetypes.SelectSolutionFormType, "Select the source of the private SSH keys needed to access the git repos:", "No, I will add them later if necessary.", []string{"Load the private SSH keys from the directory '~/.ssh'", "Provide your own key", "No, I will add them later if necessary."}),
+		q(common.ConfigRepoKeyPathsKey, qaenginetypes.MultiSelectSolutionFormType, "Select the keys to consider from the SSH directory:", nil, nil),
+		q(anyQAKey(common.ConfigRepoPrivKeyPasswordKey), qaenginetypes.PasswordSolutionFormType, "Enter the password to decrypt the SSH private key:", nil, nil),
+		q(anyQAKey(common.ConfigRepoKeyDataForDomainKey), qaenginetypes.InputSolutionFormType, "Provide a PEM-formatted SSH private key for the domain:", "", nil),
+		q(anyQAKey(common.ConfigRepoKeyForDomainKey), qaenginetypes.SelectSolutionFormType, "Select the key to use for the git domain:", nil, nil),
+		q(anyQAKey(common.ConfigRepoPubKeyForDomainKey), qaenginetypes.InputSolutionFormType, "Provide the public key of the git domain for the known_hosts file:", nil, nil, "Tekton"),
+		q(common.ConfigGitAuthorNameKey, qaenginetypes.InputSolutionFormType, "Enter git author name : ", "", nil),
+		q(common.ConfigGitAuthorEmailKey, qaenginetypes.InputSolutionFormType, "Enter git author email : ", "", nil),
+		q(common.ConfigGitUserNameKey, qaenginetypes.InputSolutionFormType, "Enter git username : ", "", nil),
+		q(common.ConfigGitPasswordKey, qaenginetypes.PasswordSolutionFormType, "Enter git password : ", nil, nil),
+		// image registry
+		q(common.ConfigImageRegistryURLKey, qaenginetypes.SelectSolutionFormType, "Enter the URL of the image registry where the new images should be pushed : ", "quay.io", []string{"quay.io"}, registryClasses...),
+		q(common.ConfigImageRegistryNamespaceKey, qaenginetypes.InputSolutionFormType, "Enter the namespace where the new images should be pushed : ", nil, nil, registryClasses...),
+		q(anyQAKey(common.ConfigImageRegistryLoginTypeKey), qaenginetypes.SelectSolutionFormType, "What type of container registry login do you want to use?", nil, []string{"no authentication", "username and password", "use an existing pull secret", "use the credentials from the docker config.json file"}, irPreprocessorClasses...),
+		q(anyQAKey(common.ConfigImageRegistryPullSecretKey), qaenginetypes.InputSolutionFormType, "Enter the name of the pull secret : ", nil, nil, irPreprocessorClasses...),
+		q(anyQAKey(common.ConfigImageRegistryUserNameKey), qaenginetypes.InputSolutionFormType, "Enter the username to login into the registry : ", "iamapikey", nil, irPreprocessorClasses...),
+		q(anyQAKey(common.ConfigImageRegistryPasswordKey), qaenginetypes.PasswordSolutionFormType, "Enter the password to login into the registry : ", nil, nil, irPreprocessorClasses...),
+		// cluster and deployment
+		q(anyQAKey(common.ConfigTargetClusterTypeForLabelKey), qaenginetypes.SelectSolutionFormType, "Choose the cluster type:", "Kubernetes", nil, "ClusterSelectorTransformer"),
+		q(common.ConfigMinReplicasKey, qaenginetypes.InputSolutionFormType, "Provide the minimum number of replicas each service should have", "2", nil, irPreprocessorClasses...),
+		q(anyQAKey(common.ConfigServicesDeploymentTypeKey), qaenginetypes.SelectSolutionFormType, "For the service, which type of deployment is required?", "Deployment", []string{"Deployment", "StatefulSet", "ArgoRollout"}, irPreprocessorClasses...),
+		q(common.ConfigServicesArgoRolloutTypeKey, qaenginetypes.SelectSolutionFormType, "Which type of Argo rollout should be generated?", "BlueGreen", []string{"BlueGreen", "Canary"}, apiResourceClasses...),
+		// network
+		q(anyQAKey(common.ConfigServicesServiceTypeKey), qaenginetypes.SelectSolutionFormType, "What kind of service/ingress should be created for the port of the service?", common.IngressKind, []string{common.IngressKind, "LoadBalancer", "NodePort", "ClusterIP", "Don't create service"}, irPreprocessorClasses...),
+		q(anyQAKey(common.ConfigServicesURLPathKey), qaenginetypes.InputSolutionFormType, "Specify the ingress path to expose the port of the service on?", "/<service name>", nil, irPreprocessorClasses...),
+		q(anyQAKey(common.ConfigTargetIngressHostKey), qaenginetypes.InputSolutionFormType, "Provide the ingress host domain", nil, nil, apiResourceClasses...),
+		q(anyQAKey(common.ConfigTargetIngressClassNameKey), qaenginetypes.InputSolutionFormType, "Provide the Ingress class name for ingress", "", nil, apiResourceClasses...),
+		q(anyQAKey(common.ConfigTargetIngressTLSKey), qaenginetypes.InputSolutionFormType, "Provide the TLS secret for ingress", nil, nil, apiResourceClasses...),
+		q(common.ConfigRouteTLSTerminationPolicy, qaenginetypes.SelectSolutionFormType, "Select a TLS termination policy for the route.", "passthrough", []string{"edge", "passthrough", "reencrypt"}, apiResourceClasses...),
+		q(common.ConfigRouteTLSKeyKey, qaenginetypes.MultilineInputSolutionFormType, "Enter the contents of the TLS key. (PEM Format)", "", nil, apiResourceClasses...),
+		q(common.ConfigRouteTLSCertificateKey, qaenginetypes.MultilineInputSolutionFormType, "Enter the contents of the TLS Certificate. (PEM Format)", "", nil, apiResourceClasses...),
+		// cicd
+		q(common.ConfigCICDTektonGitRepoSSHSecretNameKey, qaenginetypes.InputSolutionFormType, "Enter the name of an existing K8s secret that has ssh credentials for cloning the git repo", nil, nil, "Tekton"),
+		q(common.ConfigCICDTektonGitRepoBasicAuthSecretNameKey, qaenginetypes.InputSolutionFormType, "Enter the name of an existing K8s secret that has username and password for cloning the git repo", nil, nil, "Tekton"),
+		q(common.ConfigCICDTektonRegistryPushSecretNameKey, qaenginetypes.InputSolutionFormType, "Enter the name of an existing K8s secret that has Docker config.json for pushing images to the registry", nil, nil, "Tekton"),
+		q(common.ConfigTransformersKubernetesArgoCDNamespaceKey, qaenginetypes.InputSolutionFormType, "Enter the destination namespace for the Argo CD pipeline", nil, nil, "ArgoCD"),
+		// source analysis and containerization
+		q(anyQAKey(common.ConfigStorageTypeForServiceKey), qaenginetypes.SelectSolutionFormType, "Select the storage type to create", "Ignore the data source", []string{"ConfigMap", "HostPath", "PVC", "Secret", "Ignore the data source"}, "ComposeAnalyser"),
+		q(anyQAKey(common.ConfigServicesContainerizationOptionKey), qaenginetypes.MultiSelectSolutionFormType, "Select the transformer to use for containerizing the service :", nil, nil, "CloudFoundry"),
+		q(anyQAKey(common.ConfigServicesPortsKey), qaenginetypes.MultiSelectSolutionFormType, "Select ports to be exposed for the service :", nil, nil, portsClasses...),
+		q(fmt.Sprintf(common.ConfigServicesPortsKey, anyChildProject), qaenginetypes.MultiSelectSolutionFormType, "Select ports to be exposed for the child project :", nil, nil, childProjectClasses...),
+		q(anyQAKey(common.ConfigServicesPortKey), qaenginetypes.SelectSolutionFormType, "Select the port to be exposed for the service :", nil, nil, portClasses...),
+		q(fmt.Sprintf(common.ConfigServicesPortKey, anyChildModule), qaenginetypes.SelectSolutionFormType, "Select the port to be exposed for the child module :", nil, nil, childModuleClasses...),
+		q(anyQAKey(common.ConfigServicesDockerfileTypeKey), qaenginetypes.SelectSolutionFormType, "What type of Dockerfiles should be generated for the service?", "build stage in base image", dockerfileTypeOptions, dockerfileTypeClasses...),
+		q(anyQAKey(common.ConfigServicesChildModulesNamesKey), qaenginetypes.MultiSelectSolutionFormType, "For the multi-module project, please select all the child modules that should be run as services in the cluster:", nil, nil, childModuleClasses...),
+		q(anyQAKey(common.ConfigServicesChildModulesSpringProfilesKey), qaenginetypes.MultiSelectSolutionFormType, "Select the spring boot profiles for the service :", nil, nil, childModuleClasses...),
+		q(anyQAKey(common.ConfigServicesMavenProfilesKey), qaenginetypes.MultiSelectSolutionFormType, "Select the maven profiles to use for the service", nil, nil, "MavenAnalyser"),
+		q(anyQAKey(common.ConfigServicesDotNetChildProjectsNamesKey), qaenginetypes.MultiSelectSolutionFormType, "For the multi-project Dot Net app, please select all the child projects that should be run as services in the cluster:", nil, nil, childProjectClasses...),
+		q(fmt.Sprintf(common.ConfigServicesPublishProfileKey, anyChildProject), qaenginetypes.SelectSolutionFormType, "Select the profile to be use for publishing the ASP.NET child project :", nil, nil, "DotNetCoreDockerfileGenerator"),
+		q(anyQAKey(common.ConfigServicesApacheConfFileKey), qaenginetypes.SelectSolutionFormType, "Choose the apache config file to be used for the service", nil, nil, "PHPDockerfileGenerator"),
+		q(anyQAKey(common.ConfigServicesMainPythonFileKey), qaenginetypes.SelectSolutionFormType, "Select the main file to be used for the service :", nil, nil, "PythonDockerfileGenerator"),
+		q(anyQAKey(common.ConfigServicesStartingPythonFileKey), qaenginetypes.SelectSolutionFormType, "Select the python file to be used for the service :", nil, nil, "PythonDockerfileGenerator"),
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/konveyor/move2kube/assets"
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/events"
+	"github.com/konveyor/move2kube/qaengine"
+	"gopkg.in/yaml.v3"
+)
+
+// askedQuestionsSink records the IDs of the questions that were asked
+type askedQuestionsSink struct {
+	lock sync.Mutex
+	ids  map[string]bool
+}
+
+func (s *askedQuestionsSink) Send(event events.Event) error {
+	if event.Type != events.QuestionAskedEventType {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.ids[event.Data["id"].(string)] = true
+	return nil
+}
+
+func (*askedQuestionsSink) Close() error {
+	return nil
+}
+
+// getQACatalogIDRegex returns a regex that matches the problem IDs of a catalog question.
+// Each * matches exactly one quoted or unquoted segment of the ID.
+func getQACatalogIDRegex(id string) *regexp.Regexp {
+	parts := strings.Split(id, qaCatalogAny)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile(`^` + strings.Join(parts, `("[^"]*"|[^."]+)`) + `$`)
+}
+
+func copyQACatalogTestSource(t *testing.T, src, dst string) {
+	t.Helper()
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, relPath), common.DefaultDirectoryPermission)
+		}
+		return common.CopyFile(filepath.Join(dst, relPath), path)
+	})
+	if err != nil {
+		t.Fatalf("failed to copy the directory %s to %s . Error: %q", src, dst, err)
+	}
+}
+
+func TestQACatalogMatchesAskedQuestions(t *testing.T) {
+	assetsFilePermissions := map[string]int{}
+	if err := yaml.Unmarshal([]byte(assets.AssetFilePermissions), &assetsFilePermissions); err != nil {
+		t.Fatalf("failed to unmarshal the assets permissions file as YAML. Error: %q", err)
+	}
+	assetsPath, tempPath, remoteTempPath, err := common.CreateAssetsData(assets.AssetsDir, assetsFilePermissions)
+	if err != nil {
+		t.Fatalf("failed to create the assets directory. Error: %q", err)
+	}
+	defer os.RemoveAll(tempPath)
+	defer os.RemoveAll(remoteTempPath)
+	common.AssetsPath, common.TempPath, common.RemoteTempPath = assetsPath, tempPath, remoteTempPath
+	qaengine.StartEngine(true, 0, true)
+	sink := &askedQuestionsSink{ids: map[string]bool{}}
+	events.AddSink(sink)
+	defer events.Close()
+
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	copyQACatalogTestSource(t, filepath.Join("..", "samples"), sourceDir)
+	copyQACatalogTestSource(t, filepath.Join("testdata", "qacatalog"), sourceDir)
+	// the transformation writes some files to the working directory
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get the working directory. Error: %q", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("failed to change the working directory. Error: %q", err)
+	}
+	defer os.Chdir(workingDir)
+	plan, err := CreatePlan(context.Background(), sourceDir, "", "", "", "myproject", 1, "")
+	if err != nil {
+		t.Fatalf("failed to create the plan. Error: %q", err)
+	}
+	outputDir := filepath.Join(t.TempDir(), "output")
+	if _, err := Transform(context.Background(), plan, false, outputDir, "", 2, 1, false, false, false, nil, nil); err != nil {
+		t.Fatalf("failed to transform. Error: %q", err)
+	}
+
+	// the transformers are initialized again without a source directory to get the catalog
+	catalog, err := GetQACatalog("", "")
+	if err != nil {
+		t.Fatalf("failed to get the QA catalog. Error: %q", err)
+	}
+	catalogIDRegexes := []*regexp.Regexp{}
+	for _, question := range catalog.Questions {
+		catalogIDRegexes = append(catalogIDRegexes, getQACatalogIDRegex(question.ID))
+	}
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+	if len(sink.ids) == 0 {
+		t.Fatalf("expected some questions to be asked")
+	}
+	missing := []string{}
+	for id := range sink.ids {
+		found := false
+		for _, catalogIDRegex := range catalogIDRegexes {
+			if catalogIDRegex.MatchString(id) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Fatalf("the questions asked are not in the QA catalog: %v", missing)
+	}
+	if !sink.ids[`move2kube.storage.type."cache"..options`] {
+		t.Fatalf("expected the storage type question to be asked for the volume in the sample source. Asked: %v", sink.ids)
+	}
+}
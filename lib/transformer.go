@@ -20,19 +20,26 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/common/vcs"
+	"github.com/konveyor/move2kube/events"
 	"github.com/konveyor/move2kube/qaengine"
 	"github.com/konveyor/move2kube/transformer"
 	"github.com/konveyor/move2kube/transformer/external"
 	plantypes "github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// Transform transforms the artifacts and writes output
+// Transform transforms the artifacts and writes output.
+// In dry run mode the transformers write to a scratch copy of the output directory, the output is not modified
+// and a summary of the changes is returned instead.
+// In resume mode the transformation continues from the checkpoint written by a previous transformation.
+// If failOnTransformerError is true, an error is returned when any of the transformers failed.
 func Transform(
 	ctx context.Context,
 	plan plantypes.Plan,
@@ -40,7 +47,13 @@ func Transform(
 	outputPath string,
 	transformerSelector string,
 	maxIterations int,
-) error {
+	parallelism int,
+	dryRun bool,
+	resume bool,
+	failOnTransformerError bool,
+	includeServices []string,
+	excludeServices []string,
+) (transformertypes.DryRunSummary, error) {
 	logrus.Infof("Starting transformation")
 	defer logrus.Infof("Transformation done")
 	common.ProjectName = plan.Name
@@ -48,34 +61,67 @@ func Transform(
 
 	transformerSelectorObj, err := common.ConvertStringSelectorsToSelectors(transformerSelector)
 	if err != nil {
-		return fmt.Errorf("failed to parse the transformer selector string. Error: %w", err)
+		return nil, fmt.Errorf("failed to parse the transformer selector string. Error: %w", err)
 	}
 	selectorsInPlan, err := metav1.LabelSelectorAsSelector(&plan.Spec.TransformerSelector)
 	if err != nil {
-		return fmt.Errorf("failed to convert label selector to selector. Error: %w", err)
+		return nil, fmt.Errorf("failed to convert label selector to selector. Error: %w", err)
 	}
 	requirements, _ := selectorsInPlan.Requirements()
 	transformerSelectorObj = transformerSelectorObj.Add(requirements...)
 
 	remoteOutputFSPath, err := vcs.GetClonedPath(outputPath, common.RemoteOutputsFolder, true)
 	if err != nil {
-		return fmt.Errorf("failed to clone the repo '%s'. Error: %w", outputPath, err)
+		return nil, fmt.Errorf("failed to clone the repo '%s'. Error: %w", outputPath, err)
 	}
 	outputFSPath := outputPath
 	if remoteOutputFSPath != "" {
 		outputFSPath = remoteOutputFSPath
 	}
+	transformerOutputPath := outputFSPath
+	var dryRunOutput *transformer.DryRunOutput
+	if dryRun {
+		dryRunOutput, err = transformer.NewDryRunOutput(outputFSPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare the output directory for the dry run. Error: %w", err)
+		}
+		defer func() {
+			if err := dryRunOutput.Remove(); err != nil {
+				logrus.Errorf("failed to remove the scratch directory '%s' of the dry run. Error: %q", dryRunOutput.ScratchPath, err)
+			}
+		}()
+		transformerOutputPath = dryRunOutput.ScratchPath
+	}
 
 	if _, err := transformer.InitTransformers(
 		plan.Spec.Transformers,
 		transformerSelectorObj,
 		plan.Spec.SourceDir,
-		outputFSPath,
+		transformerOutputPath,
 		plan.Name,
 		true,
 		preExistingPlan,
 	); err != nil {
-		return fmt.Errorf("failed to initialize the transformers. Error: %w", err)
+		return nil, fmt.Errorf("failed to initialize the transformers. Error: %w", err)
+	}
+	transformer.SetServiceNamingConfig(plan.Spec.ServiceNaming)
+
+	// filter the services using the glob patterns
+	allServiceNames := []string{}
+	for serviceName := range plan.Spec.Services {
+		allServiceNames = append(allServiceNames, serviceName)
+	}
+	isFiltered := len(includeServices) > 0 || len(excludeServices) > 0
+	if isFiltered {
+		filteredServices, skippedServiceNames, err := plantypes.FilterServices(plan.Spec.Services, includeServices, excludeServices)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter the services in the plan. Error: %w", err)
+		}
+		if len(filteredServices) == 0 {
+			logrus.Warnf("None of the services in the plan match the service filters. Only the transformers that are invoked by default will run.")
+		}
+		logrus.Infof("Skipping the services that don't match the service filters: %+v", skippedServiceNames)
+		plan.Spec.Services = filteredServices
 	}
 
 	// select only the services the user is interested in
@@ -117,9 +163,58 @@ func Transform(
 		}
 	}
 
+	// record the services that are transformed so that partial outputs are labeled in the transform report
+	transformer.SetServiceFilter(nil)
+	if isFiltered {
+		filter := &transformertypes.ServiceFilter{
+			Include:          includeServices,
+			Exclude:          excludeServices,
+			SelectedServices: []string{},
+			SkippedServices:  []string{},
+		}
+		isSelected := map[string]bool{}
+		for _, option := range selectedTransformationOptions {
+			if !isSelected[option.ServiceName] {
+				isSelected[option.ServiceName] = true
+				filter.SelectedServices = append(filter.SelectedServices, option.ServiceName)
+			}
+		}
+		sort.Strings(allServiceNames)
+		for _, serviceName := range allServiceNames {
+			if !isSelected[serviceName] {
+				filter.SkippedServices = append(filter.SkippedServices, serviceName)
+			}
+		}
+		transformer.SetServiceFilter(filter)
+	}
+
+	// store the plan along with the checkpoint so that the transformation can be resumed
+	if !dryRun && !resume {
+		checkpointDir := transformer.GetCheckpointDir(outputFSPath)
+		if err := os.MkdirAll(checkpointDir, common.DefaultDirectoryPermission); err != nil {
+			return nil, fmt.Errorf("failed to create the checkpoint directory at path '%s' . Error: %w", checkpointDir, err)
+		}
+		checkpointPlanPath := filepath.Join(checkpointDir, common.DefaultPlanFile)
+		if err := plantypes.WritePlan(checkpointPlanPath, plan); err != nil {
+			return nil, fmt.Errorf("failed to write the plan to the checkpoint directory at path '%s' . Error: %w", checkpointPlanPath, err)
+		}
+	}
+
 	// transform the selected services using the selected transformation options
-	if err := transformer.Transform(selectedTransformationOptions, plan.Spec.SourceDir, outputFSPath, maxIterations); err != nil {
-		return fmt.Errorf("failed to transform using the plan. Error: %w", err)
+	events.Emit(events.TransformStartedEventType, map[string]interface{}{
+		"name":      plan.Name,
+		"services":  len(selectedTransformationOptions),
+		"outputDir": outputPath,
+		"dryRun":    dryRun,
+		"resume":    resume,
+	})
+	dryRunSummary, err := transformer.Transform(ctx, selectedTransformationOptions, plan.Spec.SourceDir, outputFSPath, maxIterations, parallelism, dryRunOutput, resume)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform using the plan. Error: %w", err)
+	}
+	events.Emit(events.TransformFinishedEventType, map[string]interface{}{"failedInvocations": len(transformer.GetTransformReport().Failed())})
+	if dryRun {
+		return dryRunSummary, checkTransformerErrors(failOnTransformerError)
 	}
 
 	if vcs.IsRemotePath(outputPath) {
@@ -128,13 +223,36 @@ func Transform(
 		}
 		logrus.Infof("move2kube generated artifcats are commited and pushed")
 	}
-	return nil
+	return nil, checkTransformerErrors(failOnTransformerError)
+}
+
+// checkTransformerErrors returns an error if any of the transformer invocations in the transform report failed
+func checkTransformerErrors(failOnTransformerError bool) error {
+	if !failOnTransformerError {
+		return nil
+	}
+	failed := transformer.GetTransformReport().Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"%d transformer invocation(s) failed, see %s for details. The first failure was in the transformer '%s' . Error: %s",
+		len(failed), transformertypes.ReportTextFileName, failed[0].TransformerName, failed[0].Error,
+	)
+}
+
+// GetCheckpointDir returns the directory where the checkpoint of the transformation written to the output path is stored
+func GetCheckpointDir(outputPath string) string {
+	return transformer.GetCheckpointDir(outputPath)
 }
 
 // Destroy destroys the tranformers
 func Destroy() {
 	logrus.Debugf("Cleaning up!")
 	transformer.Destroy()
+	if err := qaengine.StopEngine(); err != nil {
+		logrus.Errorf("failed to write the QA audit log. Error: %q", err)
+	}
 	if err := os.RemoveAll(common.TempPath); err != nil {
 		logrus.Debugf("failed to delete temp directory. Error: %+v", err)
 	}
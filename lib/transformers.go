@@ -0,0 +1,146 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/transformer"
+	"github.com/konveyor/move2kube/transformer/transformertest"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+)
+
+// ValidateTransformers loads the built-in transformers and the customizations and statically checks the graph they form
+func ValidateTransformers(customizationsPath, transformerSelector string) (transformertypes.ValidationReport, error) {
+	logrus.Trace("ValidateTransformers start")
+	defer logrus.Trace("ValidateTransformers end")
+	if customizationsPath != "" {
+		if err := CheckAndCopyCustomizations(customizationsPath); err != nil {
+			return transformertypes.ValidationReport{}, fmt.Errorf("failed to check and copy the customizations. Error: %w", err)
+		}
+	}
+	selector, err := common.ConvertStringSelectorsToSelectors(transformerSelector)
+	if err != nil {
+		return transformertypes.ValidationReport{}, fmt.Errorf("failed to parse the transformer selector string. Error: %w", err)
+	}
+	report, err := transformer.Validate(common.AssetsPath, selector)
+	if err != nil {
+		return report, fmt.Errorf("failed to validate the transformers. Error: %w", err)
+	}
+	return report, nil
+}
+
+// ListTransformers returns a summary of the transformers that would be active for the customizations and the transformer selector
+func ListTransformers(customizationsPath, transformerSelector string) ([]transformertypes.TransformerSummary, error) {
+	logrus.Trace("ListTransformers start")
+	defer logrus.Trace("ListTransformers end")
+	if err := initTransformers(customizationsPath, transformerSelector); err != nil {
+		return nil, err
+	}
+	summaries := []transformertypes.TransformerSummary{}
+	for _, t := range transformer.GetInitializedTransformers() {
+		tc, _ := t.GetConfig()
+		sortOrder, err := transformer.GetTransformerSortOrder(tc.Name)
+		if err != nil {
+			logrus.Debugf("failed to get the sort order of the transformer '%s' . Error: %q", tc.Name, err)
+		}
+		summaries = append(summaries, transformertypes.TransformerSummary{
+			Name:           tc.Name,
+			Class:          tc.Spec.Class,
+			Labels:         tc.Labels,
+			SortOrder:      sortOrder,
+			Isolated:       tc.Spec.Isolated,
+			ContainerBased: cast.ToBool(tc.Labels[transformer.CONTAINER_BASED_LABEL]),
+		})
+	}
+	return summaries, nil
+}
+
+// DescribeTransformer returns the resolved configuration of a transformer that would be active for the customizations and the transformer selector
+func DescribeTransformer(customizationsPath, transformerSelector, name string) (transformertypes.TransformerDescription, error) {
+	logrus.Trace("DescribeTransformer start")
+	defer logrus.Trace("DescribeTransformer end")
+	if err := initTransformers(customizationsPath, transformerSelector); err != nil {
+		return transformertypes.TransformerDescription{}, err
+	}
+	t, err := transformer.GetTransformerByName(name)
+	if err != nil {
+		return transformertypes.TransformerDescription{}, fmt.Errorf("the transformer '%s' is not active. Error: %w", name, err)
+	}
+	tc, _ := t.GetConfig()
+	sortOrder, err := transformer.GetTransformerSortOrder(tc.Name)
+	if err != nil {
+		logrus.Debugf("failed to get the sort order of the transformer '%s' . Error: %q", tc.Name, err)
+	}
+	description := transformertypes.TransformerDescription{
+		Transformer:    tc,
+		YamlPath:       tc.Spec.TransformerYamlPath,
+		SortOrder:      sortOrder,
+		ContainerBased: cast.ToBool(tc.Labels[transformer.CONTAINER_BASED_LABEL]),
+		Overrides:      transformer.GetOverriddenTransformers(tc.Name),
+	}
+	if tc.Spec.TemplatesDir != "" {
+		description.TemplatesPath = filepath.Join(filepath.Dir(tc.Spec.TransformerYamlPath), tc.Spec.TemplatesDir)
+	}
+	return description, nil
+}
+
+// initTransformers initializes the transformers without a source or output directory so that they can be inspected
+func initTransformers(customizationsPath, transformerSelector string) error {
+	if customizationsPath != "" {
+		if err := CheckAndCopyCustomizations(customizationsPath); err != nil {
+			return fmt.Errorf("failed to check and copy the customizations. Error: %w", err)
+		}
+	}
+	selector, err := common.ConvertStringSelectorsToSelectors(transformerSelector)
+	if err != nil {
+		return fmt.Errorf("failed to parse the transformer selector string. Error: %w", err)
+	}
+	if _, err := transformer.Init(common.AssetsPath, "", selector, "", ""); err != nil {
+		return fmt.Errorf("failed to initialize the transformers. Error: %w", err)
+	}
+	return nil
+}
+
+// TestTransformer runs a single transformer against the test cases and compares its output with the golden files in each test case
+func TestTransformer(ctx context.Context, customizationsPath, name string, testCaseDirs []string, update bool) (transformertypes.TransformerTestReport, error) {
+	logrus.Trace("TestTransformer start")
+	defer logrus.Trace("TestTransformer end")
+	if customizationsPath != "" {
+		if err := CheckAndCopyCustomizations(customizationsPath); err != nil {
+			return transformertypes.TransformerTestReport{}, fmt.Errorf("failed to check and copy the customizations. Error: %w", err)
+		}
+	}
+	transformerYamlPaths, err := transformer.GetTransformerYamlPaths(common.AssetsPath)
+	if err != nil {
+		return transformertypes.TransformerTestReport{}, fmt.Errorf("failed to find the transformers. Error: %w", err)
+	}
+	transformerYamlPath, ok := transformerYamlPaths[name]
+	if !ok {
+		return transformertypes.TransformerTestReport{}, fmt.Errorf("no transformer named '%s' was found in the built-in transformers or the customizations", name)
+	}
+	testCaseDirs, err = transformertest.FindTestCases(testCaseDirs)
+	if err != nil {
+		return transformertypes.TransformerTestReport{}, fmt.Errorf("failed to find the test cases. Error: %w", err)
+	}
+	return transformertest.Run(ctx, name, transformerYamlPath, testCaseDirs, update), nil
+}
@@ -0,0 +1,120 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/konveyor/move2kube/common"
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// auditLog is the file where an audit log entry is written for every answer
+	auditLog *os.File
+	// auditLogErr is the first error that occurred while writing to the audit log
+	auditLogErr error
+	// answerMetadatas contains the metadata of the latest answer to each problem
+	answerMetadatas = map[string]qatypes.AnswerMetadata{}
+	// answers contains the latest answer to each problem. They are used to evaluate the conditions of later problems.
+	answers = map[string]interface{}{}
+)
+
+// SetupAuditLog creates the audit log file. An entry is written to it for every answer.
+// The file stays open until the engine is stopped.
+func SetupAuditLog(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, common.DefaultFilePermission)
+	if err != nil {
+		return fmt.Errorf("failed to create the QA audit log file at path '%s' . Error: %w", path, err)
+	}
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
+	if err := closeAuditLog(); err != nil {
+		logrus.Errorf("failed to close the previous QA audit log. Error: %q", err)
+	}
+	auditLog = f
+	return nil
+}
+
+// closeAuditLog closes the audit log file and returns the first error that occurred while writing to it
+func closeAuditLog() error {
+	if auditLog == nil {
+		return nil
+	}
+	err := auditLogErr
+	if cerr := auditLog.Close(); cerr != nil && err == nil {
+		err = fmt.Errorf("failed to close the QA audit log file at path '%s' . Error: %w", auditLog.Name(), cerr)
+	}
+	auditLog = nil
+	auditLogErr = nil
+	return err
+}
+
+// getAnswerMetadata returns the metadata for the answer given by the engine
+func getAnswerMetadata(engine Engine, prob qatypes.Problem) qatypes.AnswerMetadata {
+	metadata := qatypes.AnswerMetadata{Engine: fmt.Sprintf("%T", engine), Time: time.Now()}
+	switch e := engine.(type) {
+	case *CliEngine:
+		metadata.Source = qatypes.CLIAnswerSource
+	case *HTTPRESTEngine:
+		metadata.Source = qatypes.RESTAnswerSource
+	case *DefaultEngine, *StrictEngine:
+		metadata.Source = qatypes.DefaultAnswerSource
+	case *StoreEngine:
+		metadata.Source, metadata.Origin = e.store.GetSolutionSource(prob)
+		if metadata.Source == "" {
+			// the answer was added to the store earlier during this run, so use where it originally came from
+			if previous, ok := answerMetadatas[prob.ID]; ok {
+				metadata.Source, metadata.Origin = previous.Source, previous.Origin
+			}
+		}
+	}
+	return metadata
+}
+
+// recordAnswer sets the metadata of the answered problem and writes it to the audit log
+func recordAnswer(engine Engine, prob qatypes.Problem) qatypes.Problem {
+	if prob.Answer == nil {
+		return prob
+	}
+	return recordAnswerWithMetadata(prob, getAnswerMetadata(engine, prob))
+}
+
+// recordAnswerWithMetadata sets the given metadata on the answered problem and writes it to the audit log
+func recordAnswerWithMetadata(prob qatypes.Problem, metadata qatypes.AnswerMetadata) qatypes.Problem {
+	prob.Metadata = &metadata
+	answerMetadatas[prob.ID] = metadata
+	answers[prob.ID] = prob.Answer
+	if auditLog == nil {
+		return prob
+	}
+	entryBytes, err := json.Marshal(qatypes.NewAuditLogEntry(prob, metadata))
+	if err == nil {
+		_, err = auditLog.Write(append(entryBytes, '\n'))
+	}
+	if err != nil {
+		logrus.Errorf("failed to write the answer to the problem '%s' to the QA audit log file at path '%s' . Error: %q", prob.ID, auditLog.Name(), err)
+		if auditLogErr == nil {
+			auditLogErr = fmt.Errorf("failed to write the answer to the problem '%s' to the QA audit log file at path '%s' . Error: %w", prob.ID, auditLog.Name(), err)
+		}
+	}
+	return prob
+}
@@ -0,0 +1,106 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+)
+
+func TestAuditLog(t *testing.T) {
+	engines = []Engine{}
+	stores = []qatypes.Store{}
+	defer func() {
+		engines = []Engine{}
+		stores = []qatypes.Store{}
+		StopEngine()
+		answerMetadatas = map[string]qatypes.AnswerMetadata{}
+		answers = map[string]interface{}{}
+	}()
+	auditPath := filepath.Join(t.TempDir(), common.QAAuditLogFile)
+	if err := SetupAuditLog(auditPath); err != nil {
+		t.Fatalf("failed to setup the audit log. Error: %q", err)
+	}
+	AddEngine(NewDefaultEngine())
+	AddCaches("testdata/qaenginetest.yaml")
+	setConfigKey := common.JoinQASubKeys(common.BaseKey, "audit", "setconfig")
+	SetupConfigFile("", []string{setConfigKey + `="from set-config"`}, nil, nil, false)
+
+	FetchStringAnswer(setConfigKey, "Enter a value : ", nil, "", nil)
+	FetchStringAnswer(common.JoinQASubKeys(common.BaseKey, "input"), "Enter the container registry username : ", nil, "", nil)
+	defaultKey := common.JoinQASubKeys(common.BaseKey, "audit", "default")
+	FetchStringAnswer(defaultKey, "Enter another value : ", nil, "default value", nil)
+	FetchStringAnswer(defaultKey, "Enter another value : ", nil, "default value", nil)
+	if err := StopEngine(); err != nil {
+		t.Fatalf("failed to stop the engine. Error: %q", err)
+	}
+
+	auditBytes, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatalf("failed to read the audit log. Error: %q", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(auditBytes)), "\n")
+	want := []qatypes.AnswerSource{qatypes.SetConfigAnswerSource, qatypes.CacheAnswerSource, qatypes.DefaultAnswerSource, qatypes.DefaultAnswerSource}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d audit log entries. Actual: %q", len(want), lines)
+	}
+	for i, line := range lines {
+		entry := qatypes.AuditLogEntry{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("failed to unmarshal the audit log entry %q . Error: %q", line, err)
+		}
+		if entry.Source != want[i] {
+			t.Errorf("expected the source of the answer for '%s' to be '%s'. Actual: '%s'", entry.ID, want[i], entry.Source)
+		}
+		if entry.Time.IsZero() {
+			t.Errorf("expected the answer for '%s' to have a timestamp", entry.ID)
+		}
+	}
+}
+
+func TestAuditLogWriteError(t *testing.T) {
+	engines = []Engine{}
+	stores = []qatypes.Store{}
+	defer func() {
+		engines = []Engine{}
+		stores = []qatypes.Store{}
+		StopEngine()
+		answerMetadatas = map[string]qatypes.AnswerMetadata{}
+		answers = map[string]interface{}{}
+	}()
+	if err := SetupAuditLog(filepath.Join(t.TempDir(), common.QAAuditLogFile)); err != nil {
+		t.Fatalf("failed to setup the audit log. Error: %q", err)
+	}
+	AddEngine(NewDefaultEngine())
+	// make the writes fail
+	if err := auditLog.Close(); err != nil {
+		t.Fatalf("failed to close the audit log file. Error: %q", err)
+	}
+	FetchStringAnswer(common.JoinQASubKeys(common.BaseKey, "audit", "writeerror"), "Enter a value : ", nil, "default value", nil)
+	if err := StopEngine(); err == nil {
+		t.Fatalf("expected an error since the answer could not be written to the audit log")
+	}
+	if auditLog != nil {
+		t.Fatalf("expected the audit log to be closed")
+	}
+}
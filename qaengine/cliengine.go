@@ -224,6 +224,13 @@ func getQAMessage(prob qatypes.Problem) string {
 	categoryList := qatypes.GetProblemCategories(prob.ID, prob.Categories)
 	// We add the category list on the same line as the ID, but aligned to the right
 	idAndCategoryLine := AddRightAlignedString(fmt.Sprintf("ID: %s", prob.ID), fmt.Sprintf("Categories: (%s)", strings.Join(categoryList, ", ")))
+	if len(prob.Conditions) > 0 {
+		conditions := []string{}
+		for _, condition := range prob.Conditions {
+			conditions = append(conditions, condition.String())
+		}
+		idAndCategoryLine += fmt.Sprintf("\nAsked because: %s", strings.Join(conditions, " and "))
+	}
 	if len(prob.Hints) == 0 {
 		return fmt.Sprintf("%s\n%s\n", prob.Desc, idAndCategoryLine)
 	}
@@ -0,0 +1,104 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"fmt"
+	"time"
+
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+	"github.com/sirupsen/logrus"
+)
+
+// getDependencyAnswer returns the answer to the problem with the given ID.
+// Problems answered during this run are checked first, then the configs, caches and secrets.
+func getDependencyAnswer(probID string) (interface{}, bool) {
+	if answer, ok := answers[probID]; ok {
+		return answer, true
+	}
+	for _, engine := range engines {
+		storeEngine, ok := engine.(*StoreEngine)
+		if !ok {
+			continue
+		}
+		var prob qatypes.Problem
+		var err error
+		if secretStore, ok := storeEngine.store.(*qatypes.SecretStore); ok {
+			// looking up a dependency must not change the source recorded for the latest answer from the secrets
+			prob, err = secretStore.LookupSolution(qatypes.Problem{ID: probID})
+		} else {
+			prob, err = storeEngine.store.GetSolution(qatypes.Problem{ID: probID})
+		}
+		if err == nil && prob.Answer != nil {
+			return prob.Answer, true
+		}
+	}
+	return nil, false
+}
+
+// getUnsatisfiedCondition returns the first condition of the problem that is not satisfied.
+// A condition on a skipped problem is never satisfied.
+// Problems must be asked after the problems they depend on. If a dependency has not been answered
+// during this run and is not answered by the configs, caches or secrets, an error is returned.
+func getUnsatisfiedCondition(prob qatypes.Problem) (qatypes.ProblemCondition, bool, error) {
+	for _, condition := range prob.Conditions {
+		if metadata, ok := answerMetadatas[condition.ProblemID]; ok && metadata.Source == qatypes.ConditionAnswerSource {
+			// the problems that depend on a skipped problem are skipped as well
+			return condition, true, nil
+		}
+		answer, ok := getDependencyAnswer(condition.ProblemID)
+		if !ok {
+			return condition, false, fmt.Errorf("the problem '%s' depends on the problem '%s' which has not been answered yet. Problems must be asked after the problems they depend on", prob.ID, condition.ProblemID)
+		}
+		if !condition.IsSatisfiedBy(answer) {
+			return condition, true, nil
+		}
+	}
+	return qatypes.ProblemCondition{}, false, nil
+}
+
+// skipProblem answers a problem whose condition is not satisfied without asking it.
+// The default answer is used if there is one, otherwise an empty answer.
+func skipProblem(prob qatypes.Problem, condition qatypes.ProblemCondition) (qatypes.Problem, error) {
+	logrus.Debugf("skipping the problem '%s' since the condition '%s' is not satisfied", prob.ID, condition)
+	answered, err := defaultEngine.FetchAnswer(prob)
+	if err != nil || answered.Answer == nil {
+		answered = prob
+		if err := answered.SetAnswer(getEmptyAnswer(prob), false); err != nil {
+			return prob, fmt.Errorf("failed to set an empty answer for the skipped problem '%s' . Error: %w", prob.ID, err)
+		}
+	}
+	metadata := qatypes.AnswerMetadata{Source: qatypes.ConditionAnswerSource, Origin: condition.String(), Engine: fmt.Sprintf("%T", defaultEngine), Time: time.Now()}
+	return recordAnswerWithMetadata(answered, metadata), nil
+}
+
+// getEmptyAnswer returns the answer used for skipped problems that do not have a default
+func getEmptyAnswer(prob qatypes.Problem) interface{} {
+	switch prob.Type {
+	case qatypes.ConfirmSolutionFormType:
+		return false
+	case qatypes.MultiSelectSolutionFormType:
+		return []string{}
+	case qatypes.SelectSolutionFormType:
+		if len(prob.Options) > 0 {
+			return prob.Options[0]
+		}
+		return ""
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+)
+
+func TestConditionalProblems(t *testing.T) {
+	engines = []Engine{}
+	stores = []qatypes.Store{}
+	defer func() {
+		engines = []Engine{}
+		stores = []qatypes.Store{}
+		answerMetadatas = map[string]qatypes.AnswerMetadata{}
+		answers = map[string]interface{}{}
+	}()
+	AddEngine(NewDefaultEngine())
+	enableKey := common.JoinQASubKeys(common.BaseKey, "condition", "enable")
+	configuredKey := common.JoinQASubKeys(common.BaseKey, "condition", "configured")
+	SetupConfigFile("", []string{enableKey + "=false", configuredKey + `="tls"`}, nil, nil, false)
+
+	if FetchBoolAnswer(enableKey, "Enable the feature?", nil, true, nil) {
+		t.Fatal("expected the answer from the config to be false")
+	}
+
+	t.Run("skipped when the condition is not satisfied", func(t *testing.T) {
+		prob, err := qatypes.NewInputProblem(common.JoinQASubKeys(common.BaseKey, "condition", "skipped"), "Enter the feature setting:", nil, "default setting", nil)
+		if err != nil {
+			t.Fatalf("failed to create the problem. Error: %q", err)
+		}
+		prob.Conditions = []qatypes.ProblemCondition{{ProblemID: enableKey}}
+		answered, err := FetchAnswer(prob)
+		if err != nil {
+			t.Fatalf("failed to fetch the answer. Error: %q", err)
+		}
+		if answered.Answer != "default setting" {
+			t.Fatalf("expected the skipped problem to get the default answer. Actual: %#v", answered.Answer)
+		}
+		if answered.Metadata == nil || answered.Metadata.Source != qatypes.ConditionAnswerSource {
+			t.Fatalf("expected the answer source to be '%s'. Actual: %+v", qatypes.ConditionAnswerSource, answered.Metadata)
+		}
+	})
+
+	t.Run("skipped when the dependency was skipped", func(t *testing.T) {
+		prob, err := qatypes.NewConfirmProblem(common.JoinQASubKeys(common.BaseKey, "condition", "nested"), "Enable the nested feature?", nil, true, nil)
+		if err != nil {
+			t.Fatalf("failed to create the problem. Error: %q", err)
+		}
+		prob.Conditions = []qatypes.ProblemCondition{{ProblemID: common.JoinQASubKeys(common.BaseKey, "condition", "skipped")}}
+		answered, err := FetchAnswer(prob)
+		if err != nil {
+			t.Fatalf("failed to fetch the answer. Error: %q", err)
+		}
+		if answered.Metadata == nil || answered.Metadata.Source != qatypes.ConditionAnswerSource {
+			t.Fatalf("expected the answer source to be '%s'. Actual: %+v", qatypes.ConditionAnswerSource, answered.Metadata)
+		}
+	})
+
+	t.Run("skipped without a default", func(t *testing.T) {
+		prob, err := qatypes.NewPasswordProblem(common.JoinQASubKeys(common.BaseKey, "condition", "password"), "Enter the feature password:", nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create the problem. Error: %q", err)
+		}
+		prob.Conditions = []qatypes.ProblemCondition{{ProblemID: enableKey, Answers: []interface{}{true}}}
+		answered, err := FetchAnswer(prob)
+		if err != nil {
+			t.Fatalf("failed to fetch the answer. Error: %q", err)
+		}
+		if answered.Answer != "" {
+			t.Fatalf("expected the skipped problem to get an empty answer. Actual: %#v", answered.Answer)
+		}
+	})
+
+	t.Run("asked when the condition is satisfied", func(t *testing.T) {
+		prob, err := qatypes.NewInputProblem(configuredKey, "Enter the feature mode:", nil, "plain", nil)
+		if err != nil {
+			t.Fatalf("failed to create the problem. Error: %q", err)
+		}
+		prob.Conditions = []qatypes.ProblemCondition{{ProblemID: enableKey, Answers: []interface{}{false}}}
+		answered, err := FetchAnswer(prob)
+		if err != nil {
+			t.Fatalf("failed to fetch the answer. Error: %q", err)
+		}
+		if answered.Answer != "tls" {
+			t.Fatalf("expected the answer from the config. Actual: %#v", answered.Answer)
+		}
+	})
+
+	t.Run("fails when the dependency was not answered yet", func(t *testing.T) {
+		prob, err := qatypes.NewInputProblem(common.JoinQASubKeys(common.BaseKey, "condition", "unknown"), "Enter the other setting:", nil, "other", nil)
+		if err != nil {
+			t.Fatalf("failed to create the problem. Error: %q", err)
+		}
+		prob.Conditions = []qatypes.ProblemCondition{{ProblemID: common.JoinQASubKeys(common.BaseKey, "condition", "notasked")}}
+		if _, err := FetchAnswer(prob); err == nil {
+			t.Fatal("expected an error since the dependency has not been answered yet")
+		}
+	})
+}
@@ -17,13 +17,19 @@
 package qaengine
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/common/download"
+	"github.com/konveyor/move2kube/events"
 	qatypes "github.com/konveyor/move2kube/types/qaengine"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
 // Engine defines interface for qa engines
@@ -37,6 +43,10 @@ var (
 	engines       []Engine
 	stores        []qatypes.Store
 	defaultEngine = NewDefaultEngine()
+	// fetchAnswerMutex makes sure only one question is asked at a time when transformers run in parallel
+	fetchAnswerMutex = sync.Mutex{}
+	// answerSources has the contents of the configs and caches that answer the questions, used to fingerprint the answers
+	answerSources = []string{}
 )
 
 // StartEngine starts the QA Engines
@@ -52,6 +62,31 @@ func StartEngine(qaskip bool, qaport int, qadisablecli bool) {
 	AddEngine(e)
 }
 
+// StopEngine closes the audit log. It returns an error if any of the answers could not be written to the audit log.
+func StopEngine() error {
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
+	return closeAuditLog()
+}
+
+// StartStrictEngine starts a non-interactive QA engine that uses the default answers and keeps track of the questions
+// that were not answered by any config or cache. Use GetUnansweredProblems to get them.
+func StartStrictEngine() {
+	AddEngine(NewStrictEngine())
+}
+
+// GetUnansweredProblems returns the problems that were answered using the default values by the strict engine
+func GetUnansweredProblems() []qatypes.Problem {
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
+	for _, engine := range engines {
+		if strictEngine, ok := engine.(*StrictEngine); ok {
+			return strictEngine.GetUnansweredProblems()
+		}
+	}
+	return nil
+}
+
 // AddEngine appends an engine to the engines slice
 func AddEngine(e Engine) {
 	if err := e.StartEngine(); err != nil {
@@ -76,6 +111,7 @@ func AddEngineHighestPriority(e Engine) error {
 func AddCaches(cacheFiles ...string) {
 	common.ReverseInPlace(cacheFiles)
 	for _, cacheFile := range cacheFiles {
+		addAnswerSourceFile(cacheFile)
 		e := NewStoreEngineFromCache(cacheFile, false)
 		if err := AddEngineHighestPriority(e); err != nil {
 			logrus.Errorf("Ignoring engine %T due to error : %s", e, err)
@@ -85,13 +121,24 @@ func AddCaches(cacheFiles ...string) {
 }
 
 // SetupWriteCacheFile adds write cache
-func SetupWriteCacheFile(writeCachePath string, persistPasswords bool) {
+func SetupWriteCacheFile(writeCachePath string, persistPasswords, persistMetadata bool) {
 	cache := qatypes.NewCache(writeCachePath, persistPasswords)
+	cache.SetPersistMetadata(persistMetadata)
 	cache.Write()
 	stores = append(stores, cache)
 	AddCaches(writeCachePath)
 }
 
+// AddWriteCacheFile adds a cache that the answers are written to, without using it to answer questions
+func AddWriteCacheFile(writeCachePath string, persistPasswords bool) error {
+	cache := qatypes.NewCache(writeCachePath, persistPasswords)
+	if err := cache.Write(); err != nil {
+		return err
+	}
+	stores = append(stores, cache)
+	return nil
+}
+
 // SetupConfigFile adds config responders - should be called only once
 func SetupConfigFile(writeConfigFile string, configStrings, configFiles, presets []string, persistPasswords bool) {
 	presetPaths := []string{}
@@ -108,6 +155,12 @@ func SetupConfigFile(writeConfigFile string, configStrings, configFiles, presets
 		}
 	}
 	configFiles = append(presetPaths, configFiles...)
+	for _, configFile := range configFiles {
+		addAnswerSourceFile(configFile)
+	}
+	for _, configString := range configStrings {
+		answerSources = append(answerSources, "config string "+configString)
+	}
 	writeConfig := qatypes.NewConfig(writeConfigFile, configStrings, configFiles, persistPasswords)
 	if writeConfigFile != "" {
 		stores = append(stores, writeConfig)
@@ -118,6 +171,47 @@ func SetupConfigFile(writeConfigFile string, configStrings, configFiles, presets
 	}
 }
 
+// SetupSecretStores adds responders that answer using the environment variables, files and encrypted answers in the secrets files.
+// Later secrets files override earlier secrets files.
+func SetupSecretStores(secretsFiles ...string) {
+	secretsFiles = append([]string{}, secretsFiles...)
+	common.ReverseInPlace(secretsFiles)
+	for _, secretsFile := range secretsFiles {
+		// only the path is used since the contents are secret
+		answerSources = append(answerSources, "secrets file "+secretsFile)
+		e := &StoreEngine{store: qatypes.NewSecretStore(secretsFile)}
+		if err := AddEngineHighestPriority(e); err != nil {
+			logrus.Errorf("Ignoring engine %T due to error : %s", e, err)
+		}
+	}
+}
+
+// addAnswerSourceFile adds the contents of a config or cache file to the answer sources
+func addAnswerSourceFile(path string) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		logrus.Debugf("failed to read the file '%s' to fingerprint the answers. Using only its path. Error: %q", path, err)
+	}
+	answerSources = append(answerSources, fmt.Sprintf("file %s\n%s", path, contents))
+}
+
+// GetAnswersFingerprint returns a hash of the configs and caches that answer the questions and of the problems answered so far.
+// Results that depend on the answers to questions can be reused when the fingerprint is the same.
+func GetAnswersFingerprint() string {
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
+	h := sha256.New()
+	for _, answerSource := range answerSources {
+		fmt.Fprintf(h, "%d\n%s\n", len(answerSource), answerSource)
+	}
+	answersBytes, err := yaml.Marshal(answers)
+	if err != nil {
+		logrus.Debugf("failed to marshal the answers to yaml. Error: %q", err)
+	}
+	h.Write(answersBytes)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func isQuestionDisabled(prob qatypes.Problem) bool {
 	isDisabled := false
 	probCategories := qatypes.GetProblemCategories(prob.ID, prob.Categories)
@@ -135,18 +229,37 @@ func isQuestionDisabled(prob qatypes.Problem) bool {
 func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 	logrus.Trace("FetchAnswer start")
 	defer logrus.Trace("FetchAnswer end")
+	release := waitForTurn(prob.ID)
+	defer release()
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
 	logrus.Debugf("Fetching answer for the problem: %#v", prob)
 	if prob.Answer != nil {
 		logrus.Debugf("Problem already solved.")
 		return prob, nil
 	}
-	var err error
+	condition, unsatisfied, err := getUnsatisfiedCondition(prob)
+	if err != nil {
+		return prob, fmt.Errorf("failed to check the conditions of the problem '%s' . Error: %w", prob.ID, err)
+	}
+	if unsatisfied {
+		// skipped problems are not written to the config or the cache since the user was never asked
+		return skipProblem(prob, condition)
+	}
+	events.Emit(events.QuestionAskedEventType, map[string]interface{}{
+		"id":          prob.ID,
+		"type":        prob.Type,
+		"description": prob.Desc,
+	})
+	// answeredBy is the engine that answered the problem
+	var answeredBy Engine
 	logrus.Debug("looping through the engines to try and fetch the answer")
 	isDisabled := isQuestionDisabled(prob)
 	for _, engine := range engines {
 		logrus.Debugf("engine '%T'", engine)
 		if prob.Desc == "" && engine.IsInteractiveEngine() {
-			return defaultEngine.FetchAnswer(prob)
+			prob, err = defaultEngine.FetchAnswer(prob)
+			return recordAnswer(defaultEngine, prob), err
 		}
 		if isDisabled && engine.IsInteractiveEngine() {
 			logrus.Debugf("The question belongs to a disabled category so we won't ask the user for the answer")
@@ -155,6 +268,7 @@ func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 				return prob, err
 			}
 			if prob.Answer != nil {
+				answeredBy = defaultEngine
 				prob = changeSelectToInputForOther(prob)
 			}
 			break
@@ -169,6 +283,7 @@ func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 			continue
 		}
 		if prob.Answer != nil {
+			answeredBy = engine
 			prob = changeSelectToInputForOther(prob)
 			break
 		}
@@ -190,6 +305,7 @@ func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 			if err != nil || prob.Answer == nil {
 				return prob, fmt.Errorf("failed to fetch the answer for problem: %+v . Error: %w", prob, err)
 			}
+			answeredBy = defaultEngine
 		}
 		for err != nil || prob.Answer == nil {
 			prob, err = lastEngine.FetchAnswer(prob)
@@ -198,10 +314,24 @@ func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 				continue
 			}
 			if prob.Answer != nil {
+				answeredBy = lastEngine
 				prob = changeSelectToInputForOther(prob)
 			}
 		}
 	}
+	if answeredBy != nil {
+		prob = recordAnswer(answeredBy, prob)
+		if prob.Metadata != nil && prob.Metadata.Source == qatypes.SecretAnswerSource {
+			logrus.Debugf("the answer for the problem '%s' is a secret, so it won't be written to the config or the cache", prob.ID)
+			return prob, err
+		}
+		if _, ok := answeredBy.(*StrictEngine); ok {
+			// the default answers used by the strict engine are not written to the config or the cache,
+			// otherwise the next strict run using them would not fail even though nobody answered the question
+			logrus.Debugf("the problem '%s' was not answered by any config or cache, so the default answer won't be written to the config or the cache", prob.ID)
+			return prob, err
+		}
+	}
 	for _, store := range stores {
 		store.AddSolution(prob)
 	}
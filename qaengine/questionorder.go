@@ -0,0 +1,131 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"sync"
+)
+
+// QuestionOrder makes the order of the questions asked by callers that run concurrently deterministic.
+// A question is only asked when none of the callers is running, that is, when each of the started callers
+// is either waiting for an answer or done and no more callers can be started.
+// The waiting question with the smallest ID is asked first.
+type QuestionOrder struct {
+	cond        *sync.Cond
+	callers     int
+	parallelism int
+	started     int
+	done        int
+	asking      bool
+	waitingIDs  []string
+}
+
+var (
+	// questionOrder is the order of the questions of the callers that are running concurrently, if any
+	questionOrder      *QuestionOrder
+	questionOrderMutex = sync.Mutex{}
+)
+
+// StartQuestionOrder orders the questions asked until Stop is called. At most parallelism of the callers run at a time.
+// Every caller must call StartCaller before it runs and CallerDone after it is done.
+func StartQuestionOrder(callers int, parallelism int) *QuestionOrder {
+	o := &QuestionOrder{cond: sync.NewCond(&sync.Mutex{}), callers: callers, parallelism: parallelism}
+	questionOrderMutex.Lock()
+	defer questionOrderMutex.Unlock()
+	questionOrder = o
+	return o
+}
+
+// Stop stops ordering the questions
+func (o *QuestionOrder) Stop() {
+	questionOrderMutex.Lock()
+	defer questionOrderMutex.Unlock()
+	if questionOrder == o {
+		questionOrder = nil
+	}
+}
+
+// StartCaller blocks until the next caller can be started
+func (o *QuestionOrder) StartCaller() {
+	o.cond.L.Lock()
+	defer o.cond.L.Unlock()
+	for o.started-o.done >= o.parallelism {
+		o.cond.Wait()
+	}
+	o.started++
+}
+
+// CallerDone marks a caller as done
+func (o *QuestionOrder) CallerDone() {
+	o.cond.L.Lock()
+	defer o.cond.L.Unlock()
+	o.done++
+	o.cond.Broadcast()
+}
+
+// wait blocks until the question can be asked. The returned function must be called after the question is answered.
+func (o *QuestionOrder) wait(id string) func() {
+	o.cond.L.Lock()
+	defer o.cond.L.Unlock()
+	o.waitingIDs = append(o.waitingIDs, id)
+	o.cond.Broadcast()
+	for !o.canAsk(id) {
+		o.cond.Wait()
+	}
+	for i, waitingID := range o.waitingIDs {
+		if waitingID == id {
+			o.waitingIDs = append(o.waitingIDs[:i], o.waitingIDs[i+1:]...)
+			break
+		}
+	}
+	o.asking = true
+	return func() {
+		o.cond.L.Lock()
+		defer o.cond.L.Unlock()
+		o.asking = false
+		o.cond.Broadcast()
+	}
+}
+
+func (o *QuestionOrder) canAsk(id string) bool {
+	if o.asking {
+		return false
+	}
+	running := o.started - o.done - len(o.waitingIDs)
+	canStart := o.started < o.callers && o.started-o.done < o.parallelism
+	if running > 0 || canStart {
+		return false
+	}
+	for _, waitingID := range o.waitingIDs {
+		if waitingID < id {
+			return false
+		}
+	}
+	return true
+}
+
+// waitForTurn blocks until the question can be asked, when the questions are being ordered.
+// The returned function must be called after the question is answered.
+func waitForTurn(id string) func() {
+	questionOrderMutex.Lock()
+	o := questionOrder
+	questionOrderMutex.Unlock()
+	if o == nil {
+		return func() {}
+	}
+	return o.wait(id)
+}
@@ -0,0 +1,76 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+)
+
+func TestQuestionOrder(t *testing.T) {
+	engines = []Engine{NewStrictEngine()}
+	stores = []qatypes.Store{}
+	defer func() { engines = []Engine{} }()
+	ask := func(id string) {
+		prob, err := qatypes.NewInputProblem(id, "question "+id, nil, "default", nil)
+		if err != nil {
+			t.Errorf("failed to create the problem. Error: %q", err)
+			return
+		}
+		if _, err := FetchAnswer(prob); err != nil {
+			t.Errorf("failed to fetch the answer. Error: %q", err)
+		}
+	}
+	// the slower caller asks the question with the smaller ID
+	callers := []struct {
+		delay time.Duration
+		ids   []string
+	}{
+		{delay: 200 * time.Millisecond, ids: []string{"a.1", "c.1"}},
+		{delay: 0, ids: []string{"b.1", "b.2"}},
+	}
+	questionOrder := StartQuestionOrder(len(callers), len(callers))
+	wg := sync.WaitGroup{}
+	for _, caller := range callers {
+		wg.Add(1)
+		questionOrder.StartCaller()
+		go func(delay time.Duration, ids []string) {
+			defer func() {
+				questionOrder.CallerDone()
+				wg.Done()
+			}()
+			time.Sleep(delay)
+			for _, id := range ids {
+				ask(id)
+			}
+		}(caller.delay, caller.ids)
+	}
+	wg.Wait()
+	questionOrder.Stop()
+	askedIDs := []string{}
+	for _, prob := range GetUnansweredProblems() {
+		askedIDs = append(askedIDs, prob.ID)
+	}
+	want := []string{"a.1", "b.1", "b.2", "c.1"}
+	if diff := cmp.Diff(want, askedIDs); diff != "" {
+		t.Fatalf("the questions were asked in the wrong order. Differences: %s", diff)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/konveyor/move2kube/qaengine"
 	qatypes "github.com/konveyor/move2kube/types/qaengine"
@@ -33,6 +34,10 @@ import (
 
 var (
 	grpcReceiver net.Addr
+	grpcServer   *grpc.Server
+	// grpcReceiverUsers is the number of callers of StartGRPCReceiver that have not called StopGRPCReceiver
+	grpcReceiverUsers int
+	grpcReceiverLock  sync.Mutex
 )
 
 type server struct {
@@ -59,9 +64,13 @@ func (s *server) FetchAnswer(ctx context.Context, prob *qagrpc.Problem) (a *qagr
 	return a, err
 }
 
-// StartGRPCReceiver starts the GRPC receiver for QA Engine
+// StartGRPCReceiver starts the GRPC receiver for QA Engine.
+// The receiver is shared, so it is only started by the first caller.
 func StartGRPCReceiver() (addr net.Addr, err error) {
+	grpcReceiverLock.Lock()
+	defer grpcReceiverLock.Unlock()
 	if grpcReceiver != nil {
+		grpcReceiverUsers++
 		return grpcReceiver, nil
 	}
 	port, err := freeport.GetFreePort()
@@ -86,5 +95,24 @@ func StartGRPCReceiver() (addr net.Addr, err error) {
 	}(listener)
 	logrus.Info("Started QA GPRC Receiver engine on: " + listener.Addr().String())
 	grpcReceiver = listener.Addr()
+	grpcServer = s
+	grpcReceiverUsers = 1
 	return grpcReceiver, nil
 }
+
+// StopGRPCReceiver stops the GRPC receiver for QA Engine once all the callers of StartGRPCReceiver have stopped it
+func StopGRPCReceiver() {
+	grpcReceiverLock.Lock()
+	defer grpcReceiverLock.Unlock()
+	if grpcReceiver == nil {
+		return
+	}
+	grpcReceiverUsers--
+	if grpcReceiverUsers > 0 {
+		return
+	}
+	logrus.Debugf("stopping the QA GRPC Receiver engine on: %s", grpcReceiver.String())
+	grpcServer.Stop()
+	grpcReceiver = nil
+	grpcServer = nil
+}
@@ -0,0 +1,66 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+)
+
+// StrictEngine answers questions using the default values like the DefaultEngine,
+// but keeps track of them so that a non-interactive run can fail if any question was not answered by a config or cache.
+// Questions in the disabled categories and questions without a description are not tracked.
+// The answers it gives are not written to the config or the cache.
+type StrictEngine struct {
+	unanswered []qatypes.Problem
+}
+
+// NewStrictEngine creates a new instance of strict engine
+func NewStrictEngine() *StrictEngine {
+	return new(StrictEngine)
+}
+
+// StartEngine starts the strict qa engine
+func (*StrictEngine) StartEngine() error {
+	return nil
+}
+
+// IsInteractiveEngine returns true if the engine interacts with the user
+func (*StrictEngine) IsInteractiveEngine() bool {
+	return false
+}
+
+// FetchAnswer records the problem as unanswered and answers it using the default value
+func (se *StrictEngine) FetchAnswer(problem qatypes.Problem) (qatypes.Problem, error) {
+	if problem.Desc != "" && !isQuestionDisabled(problem) {
+		found := false
+		for _, unanswered := range se.unanswered {
+			if unanswered.ID == problem.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			se.unanswered = append(se.unanswered, problem)
+		}
+	}
+	return defaultEngine.FetchAnswer(problem)
+}
+
+// GetUnansweredProblems returns the problems that were not answered by any config or cache, in the order they were asked
+func (se *StrictEngine) GetUnansweredProblems() []qatypes.Problem {
+	return se.unanswered
+}
@@ -0,0 +1,91 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	qatypes "github.com/konveyor/move2kube/types/qaengine"
+)
+
+func TestStrictEngine(t *testing.T) {
+	qaTestPath := "testdata/qaenginetest.yaml"
+
+	engines = []Engine{}
+	StartStrictEngine()
+	if err := AddEngineHighestPriority(NewStoreEngineFromCache(qaTestPath, false)); err != nil {
+		t.Fatalf("failed to add the cache engine. Error: %q", err)
+	}
+
+	answeredKey := common.JoinQASubKeys(common.BaseKey, "input")
+	if answer := FetchStringAnswer(answeredKey, "Enter the container registry username : ", nil, "", nil); answer != "testuser" {
+		t.Fatalf("expected the answer from the cache. Actual: %s", answer)
+	}
+	unansweredKey := common.JoinQASubKeys(common.BaseKey, "strict", "unanswered")
+	for i := 0; i < 2; i++ {
+		if answer := FetchStringAnswer(unansweredKey, "Enter a value : ", nil, "default value", nil); answer != "default value" {
+			t.Fatalf("expected the default answer. Actual: %s", answer)
+		}
+	}
+
+	unanswered := GetUnansweredProblems()
+	if len(unanswered) != 1 || unanswered[0].ID != unansweredKey {
+		t.Fatalf("expected only the problem '%s' to be unanswered. Actual: %+v", unansweredKey, unanswered)
+	}
+}
+
+func TestStrictEngineTwiceWithTheSameCache(t *testing.T) {
+	defer func() {
+		engines = []Engine{}
+		stores = []qatypes.Store{}
+		answerMetadatas = map[string]qatypes.AnswerMetadata{}
+		answers = map[string]interface{}{}
+	}()
+	tempDir := t.TempDir()
+	previousCachePath := ""
+	previousConfigPaths := []string{}
+	unansweredKey := common.JoinQASubKeys(common.BaseKey, "strict", "cached")
+	for run := 1; run <= 2; run++ {
+		engines = []Engine{}
+		stores = []qatypes.Store{}
+		answerMetadatas = map[string]qatypes.AnswerMetadata{}
+		answers = map[string]interface{}{}
+		StartStrictEngine()
+		configPath := filepath.Join(tempDir, fmt.Sprintf("m2kconfig%d.yaml", run))
+		SetupConfigFile(configPath, nil, previousConfigPaths, nil, false)
+		cachePath := filepath.Join(tempDir, fmt.Sprintf("m2kqacache%d.yaml", run))
+		SetupWriteCacheFile(cachePath, false, false)
+		if previousCachePath != "" {
+			AddCaches(previousCachePath)
+		}
+		if answer := FetchStringAnswer(unansweredKey, "Enter a value : ", nil, "default value", nil); answer != "default value" {
+			t.Fatalf("expected the default answer in the run %d . Actual: %s", run, answer)
+		}
+		if err := WriteStoresToDisk(); err != nil {
+			t.Fatalf("failed to write the stores to disk in the run %d . Error: %q", run, err)
+		}
+		unanswered := GetUnansweredProblems()
+		if len(unanswered) != 1 || unanswered[0].ID != unansweredKey {
+			t.Fatalf("expected the problem '%s' to be unanswered in the run %d . Actual: %+v", unansweredKey, run, unanswered)
+		}
+		previousCachePath = cachePath
+		previousConfigPaths = []string{configPath}
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/common/deepcopy"
+	"github.com/konveyor/move2kube/common/pathconverters"
+	"github.com/konveyor/move2kube/filesystem"
+	graphtypes "github.com/konveyor/move2kube/types/graph"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+)
+
+const (
+	// CheckpointFileName is the name of the file in the checkpoint directory that stores the state of the transformation
+	CheckpointFileName = "checkpoint.yaml"
+	// checkpointIterationsDir is the name of the directory in the checkpoint directory that stores the artifacts and path mappings of each iteration
+	checkpointIterationsDir = "iterations"
+	// checkpointFilesDir is the name of the directory in the checkpoint directory that stores copies of the temporary files used by the path mappings and artifacts
+	checkpointFilesDir = "files"
+)
+
+// the prefixes of the paths stored in the checkpoint, so that the checkpoint does not depend on the directories of the process that wrote it
+const (
+	checkpointSourcePathPrefix     = "<source>"
+	checkpointOutputPathPrefix     = "<output>"
+	checkpointAssetsPathPrefix     = "<assets>"
+	checkpointCheckpointPathPrefix = "<checkpoint>"
+)
+
+// checkpoint is the state of the transformation restored from a checkpoint
+type checkpoint struct {
+	Iteration             int
+	AllArtifacts          []transformertypes.Artifact
+	NewArtifactsToProcess []transformertypes.Artifact
+	PathMappings          []transformertypes.PathMapping
+	Graph                 *graphtypes.Graph
+	Report                transformertypes.TransformReport
+}
+
+// checkpointState stores the state of the transformation after an iteration completes.
+// The source and output directories are recorded so that the checkpoint is not used to resume a different transformation.
+type checkpointState struct {
+	SourceDir string                           `yaml:"sourceDir"`
+	OutputDir string                           `yaml:"outputDir"`
+	Iteration int                              `yaml:"iteration"`
+	Graph     *graphtypes.Graph                `yaml:"graph"`
+	Report    transformertypes.TransformReport `yaml:"report"`
+}
+
+// checkpointIteration stores the artifacts and path mappings created in an iteration
+type checkpointIteration struct {
+	Artifacts    []transformertypes.Artifact    `yaml:"artifacts"`
+	PathMappings []transformertypes.PathMapping `yaml:"pathMappings"`
+}
+
+// checkpointPathPrefix is the prefix that stands for a directory in the paths stored in the checkpoint
+type checkpointPathPrefix struct {
+	prefix string
+	dir    string
+}
+
+// checkpointer writes the checkpoint of a transformation after every iteration and reads it back when the transformation is resumed.
+// Only the artifacts and path mappings created in an iteration are written.
+type checkpointer struct {
+	dir       string
+	sourceDir string
+	outputDir string
+}
+
+// GetCheckpointDir returns the checkpoint directory of the transformation written to the output path.
+// Every output path has its own checkpoint, stored next to the output directory so that it is not removed when the output directory is rebuilt.
+func GetCheckpointDir(outputPath string) string {
+	return filepath.Clean(outputPath) + "." + common.CheckpointDir
+}
+
+// newCheckpointer returns a checkpointer for the transformation of the source directory into the output path
+func newCheckpointer(sourceDir, outputPath string) *checkpointer {
+	return &checkpointer{dir: GetCheckpointDir(outputPath), sourceDir: sourceDir, outputDir: outputPath}
+}
+
+// write stores the artifacts and path mappings created in the iteration along with the graph and report of the transformation
+func (c *checkpointer) write(iteration int, artifacts []transformertypes.Artifact, pathMappings []transformertypes.PathMapping, graph *graphtypes.Graph) error {
+	iterationsDir := filepath.Join(c.dir, checkpointIterationsDir)
+	if err := os.MkdirAll(iterationsDir, common.DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the checkpoint directory at path '%s' . Error: %w", iterationsDir, err)
+	}
+	record := checkpointIteration{Artifacts: artifacts, PathMappings: pathMappings}
+	record = deepcopy.DeepCopy(record).(checkpointIteration)
+	filesDir := filepath.Join(c.dir, checkpointFilesDir, cast.ToString(iteration))
+	copiedPaths := map[string]string{}
+	encodePath := func(path string) (string, error) {
+		if path == "" || !filepath.IsAbs(path) {
+			return path, nil
+		}
+		for _, pathPrefix := range c.getPathPrefixes() {
+			if pathPrefix.dir != c.dir && common.IsParent(path, pathPrefix.dir) {
+				return getCheckpointPath(pathPrefix.prefix, pathPrefix.dir, path)
+			}
+		}
+		if !common.IsParent(path, common.TempPath) {
+			return path, nil
+		}
+		// temporary files are removed when the process exits, so they are copied into the checkpoint
+		if copiedPath, ok := copiedPaths[path]; ok {
+			return copiedPath, nil
+		}
+		copyPath := filepath.Join(filesDir, cast.ToString(len(copiedPaths)), filepath.Base(path))
+		if err := filesystem.Replicate(path, copyPath); err != nil {
+			return path, fmt.Errorf("failed to copy the temporary path '%s' to the checkpoint directory '%s' . Error: %w", path, copyPath, err)
+		}
+		copiedPath, err := getCheckpointPath(checkpointCheckpointPathPrefix, c.dir, copyPath)
+		if err != nil {
+			return path, err
+		}
+		copiedPaths[path] = copiedPath
+		return copiedPath, nil
+	}
+	if err := pathconverters.ProcessPaths(&record, encodePath); err != nil {
+		return fmt.Errorf("failed to store the paths of iteration %d in the checkpoint. Error: %w", iteration, err)
+	}
+	recordFilePath := filepath.Join(iterationsDir, cast.ToString(iteration)+".yaml")
+	if err := common.WriteYaml(recordFilePath, record); err != nil {
+		return fmt.Errorf("failed to write the checkpoint of iteration %d at path '%s' . Error: %w", iteration, recordFilePath, err)
+	}
+	// the state is written last so that a partially written iteration is ignored when resuming
+	stateFilePath := filepath.Join(c.dir, CheckpointFileName)
+	state := checkpointState{SourceDir: c.sourceDir, OutputDir: c.outputDir, Iteration: iteration, Graph: graph, Report: GetTransformReport()}
+	if err := common.WriteYaml(stateFilePath, state); err != nil {
+		return fmt.Errorf("failed to write the checkpoint file at path '%s' . Error: %w", stateFilePath, err)
+	}
+	return nil
+}
+
+// read restores the state of the transformation from the checkpoint written by a previous transformation.
+// It fails if the checkpoint was written by a transformation of a different source directory or into a different output directory.
+func (c *checkpointer) read() (checkpoint, error) {
+	cp := checkpoint{}
+	state := checkpointState{}
+	stateFilePath := filepath.Join(c.dir, CheckpointFileName)
+	if err := common.ReadYaml(stateFilePath, &state); err != nil {
+		return cp, fmt.Errorf("failed to read the checkpoint file at path '%s' . Error: %w", stateFilePath, err)
+	}
+	if state.SourceDir != c.sourceDir || state.OutputDir != c.outputDir {
+		return cp, fmt.Errorf(
+			"the checkpoint at path '%s' was written by the transformation of the source directory '%s' into the output directory '%s' , not of '%s' into '%s'",
+			c.dir, state.SourceDir, state.OutputDir, c.sourceDir, c.outputDir,
+		)
+	}
+	cp.Iteration = state.Iteration
+	cp.Graph = state.Graph
+	cp.Report = state.Report
+	if cp.Graph == nil {
+		cp.Graph = graphtypes.NewGraph()
+	}
+	cp.Graph.ResetIds()
+	decodePath := func(path string) (string, error) {
+		for _, pathPrefix := range c.getPathPrefixes() {
+			if path == pathPrefix.prefix {
+				return pathPrefix.dir, nil
+			}
+			if strings.HasPrefix(path, pathPrefix.prefix+"/") {
+				return filepath.Join(pathPrefix.dir, filepath.FromSlash(strings.TrimPrefix(path, pathPrefix.prefix+"/"))), nil
+			}
+		}
+		return path, nil
+	}
+	for iteration := 1; iteration <= state.Iteration; iteration++ {
+		recordFilePath := filepath.Join(c.dir, checkpointIterationsDir, cast.ToString(iteration)+".yaml")
+		if _, err := os.Stat(recordFilePath); os.IsNotExist(err) {
+			continue
+		}
+		record := checkpointIteration{}
+		if err := common.ReadYaml(recordFilePath, &record); err != nil {
+			return cp, fmt.Errorf("failed to read the checkpoint of iteration %d at path '%s' . Error: %w", iteration, recordFilePath, err)
+		}
+		if err := pathconverters.ProcessPaths(&record, decodePath); err != nil {
+			return cp, fmt.Errorf("failed to restore the paths of iteration %d from the checkpoint. Error: %w", iteration, err)
+		}
+		cp.AllArtifacts = append(cp.AllArtifacts, record.Artifacts...)
+		cp.NewArtifactsToProcess = record.Artifacts
+		cp.PathMappings = append(cp.PathMappings, record.PathMappings...)
+	}
+	logrus.Debugf("restored %d artifacts and %d path mappings from the checkpoint at path '%s'", len(cp.AllArtifacts), len(cp.PathMappings), c.dir)
+	return cp, nil
+}
+
+// reset deletes the state and the iterations written by a previous transformation into the same output directory.
+// The other files in the checkpoint directory, like the plan, are kept.
+func (c *checkpointer) reset() error {
+	for _, path := range []string{filepath.Join(c.dir, CheckpointFileName), filepath.Join(c.dir, checkpointIterationsDir), filepath.Join(c.dir, checkpointFilesDir)} {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove the path '%s' from the checkpoint directory. Error: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// remove deletes the checkpoint directory
+func (c *checkpointer) remove() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("failed to remove the checkpoint directory at path '%s' . Error: %w", c.dir, err)
+	}
+	return nil
+}
+
+// getPathPrefixes returns the directories whose paths are stored relative to them in the checkpoint
+func (c *checkpointer) getPathPrefixes() []checkpointPathPrefix {
+	pathPrefixes := []checkpointPathPrefix{}
+	for _, pathPrefix := range []checkpointPathPrefix{
+		{prefix: checkpointSourcePathPrefix, dir: c.sourceDir},
+		{prefix: checkpointOutputPathPrefix, dir: c.outputDir},
+		{prefix: checkpointAssetsPathPrefix, dir: common.AssetsPath},
+		{prefix: checkpointCheckpointPathPrefix, dir: c.dir},
+	} {
+		if pathPrefix.dir != "" {
+			pathPrefixes = append(pathPrefixes, pathPrefix)
+		}
+	}
+	return pathPrefixes
+}
+
+// getCheckpointPath returns the path relative to the directory, with the prefix that stands for the directory
+func getCheckpointPath(prefix, dir, path string) (string, error) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return path, fmt.Errorf("failed to make the path '%s' relative to the directory '%s' . Error: %w", path, dir, err)
+	}
+	if rel == "." {
+		return prefix, nil
+	}
+	return prefix + "/" + filepath.ToSlash(rel), nil
+}
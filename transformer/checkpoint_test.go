@@ -0,0 +1,283 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+	graphtypes "github.com/konveyor/move2kube/types/graph"
+	plantypes "github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+)
+
+func TestCheckpoint(t *testing.T) {
+	common.TempPath = t.TempDir()
+	sourceDir := t.TempDir()
+	outputPath := filepath.Join(t.TempDir(), "myproject")
+	tempDir, err := os.MkdirTemp(common.TempPath, "*")
+	if err != nil {
+		t.Fatalf("Error: %q", err)
+	}
+	tempFilePath := filepath.Join(tempDir, "Dockerfile")
+	if err := os.WriteFile(tempFilePath, []byte("FROM scratch"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("Error: %q", err)
+	}
+	graph := graphtypes.NewGraph()
+	startVertexId := graph.AddVertex("start", 1, nil)
+	graph.AddEdge(startVertexId, graph.AddVertex("transformer", 2, nil), "artifact", nil)
+	artifact := transformertypes.Artifact{
+		Name:    "svc1",
+		Type:    "Service",
+		Paths:   map[transformertypes.PathType][]string{"ServiceDirPath": {filepath.Join(sourceDir, "svc1")}, "Dockerfile": {tempFilePath}},
+		Configs: map[transformertypes.ConfigType]interface{}{graphtypes.GraphSourceVertexKey: startVertexId},
+	}
+	pathMapping := transformertypes.PathMapping{Type: transformertypes.DefaultPathMappingType, SrcPath: tempFilePath, DestPath: "Dockerfile"}
+	cp := newCheckpointer(sourceDir, outputPath)
+	if err := cp.write(1, []transformertypes.Artifact{artifact}, []transformertypes.PathMapping{pathMapping}, graph); err != nil {
+		t.Fatalf("failed to write the checkpoint. Error: %q", err)
+	}
+	if err := cp.write(2, nil, nil, graph); err != nil {
+		t.Fatalf("failed to write the checkpoint. Error: %q", err)
+	}
+	if cp.dir != outputPath+"."+common.CheckpointDir {
+		t.Fatalf("expected the checkpoint to be stored next to the output directory. Actual: %s", cp.dir)
+	}
+	otherOutputPath := filepath.Join(filepath.Dir(outputPath), "otherproject")
+	if _, err := newCheckpointer(sourceDir, otherOutputPath).read(); err == nil {
+		t.Fatalf("expected the output directory '%s' to not share the checkpoint of the output directory '%s'", otherOutputPath, outputPath)
+	}
+	if _, err := (&checkpointer{dir: cp.dir, sourceDir: t.TempDir(), outputDir: outputPath}).read(); err == nil {
+		t.Fatalf("expected the checkpoint to not be used to resume the transformation of a different source directory")
+	}
+	// the temporary files of the transformation are removed when the process exits
+	if err := os.RemoveAll(common.TempPath); err != nil {
+		t.Fatalf("Error: %q", err)
+	}
+	got, err := cp.read()
+	if err != nil {
+		t.Fatalf("failed to read the checkpoint. Error: %q", err)
+	}
+	want := checkpoint{Iteration: 2, AllArtifacts: []transformertypes.Artifact{artifact}, PathMappings: []transformertypes.PathMapping{pathMapping}, Graph: graph}
+	ignoreTempPaths := cmp.FilterPath(func(p cmp.Path) bool {
+		return strings.HasSuffix(p.GoString(), "SrcPath") || strings.Contains(p.GoString(), `"Dockerfile"`)
+	}, cmp.Ignore())
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(graphtypes.Graph{}), cmpopts.EquateEmpty(), ignoreTempPaths); diff != "" {
+		t.Fatalf("the checkpoint is different after reading it back. Differences:\n%s", diff)
+	}
+	for _, path := range []string{got.PathMappings[0].SrcPath, got.AllArtifacts[0].Paths["Dockerfile"][0]} {
+		if !common.IsParent(path, cp.dir) {
+			t.Fatalf("expected the temporary file to be restored from the checkpoint. Actual: %s", path)
+		}
+		if content, err := os.ReadFile(path); err != nil || string(content) != "FROM scratch" {
+			t.Fatalf("the temporary file was not copied to the checkpoint. Content: %s Error: %q", content, err)
+		}
+	}
+	if err := cp.remove(); err != nil {
+		t.Fatalf("failed to remove the checkpoint. Error: %q", err)
+	}
+	if _, err := os.Stat(cp.dir); !os.IsNotExist(err) {
+		t.Fatalf("expected the checkpoint directory to be removed. Error: %q", err)
+	}
+}
+
+// chainTransformer appends its name to the content of the file of the artifact it consumes.
+// It writes the result to a temporary file and passes it to the next transformer in the chain.
+type chainTransformer struct {
+	config transformertypes.Transformer
+	env    *environment.Environment
+	// cancel is called when the transformer runs, to interrupt the transformation
+	cancel context.CancelFunc
+}
+
+func (t *chainTransformer) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+	t.config = tc
+	t.env = env
+	return nil
+}
+
+func (t *chainTransformer) GetConfig() (transformertypes.Transformer, *environment.Environment) {
+	return t.config, t.env
+}
+
+func (t *chainTransformer) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+	return nil, nil
+}
+
+func (t *chainTransformer) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	pathMappings := []transformertypes.PathMapping{}
+	artifacts := []transformertypes.Artifact{}
+	for _, artifact := range newArtifacts {
+		content, err := os.ReadFile(artifact.Paths["File"][0])
+		if err != nil {
+			return nil, nil, err
+		}
+		tempDir, err := os.MkdirTemp(common.TempPath, "*")
+		if err != nil {
+			return nil, nil, err
+		}
+		filePath := filepath.Join(tempDir, artifact.Name)
+		if err := os.WriteFile(filePath, append(content, []byte(t.config.Name)...), common.DefaultFilePermission); err != nil {
+			return nil, nil, err
+		}
+		pathMappings = append(pathMappings, transformertypes.PathMapping{
+			Type:     transformertypes.DefaultPathMappingType,
+			SrcPath:  filePath,
+			DestPath: filepath.Join(t.config.Name, artifact.Name),
+		})
+		for artifactType := range t.config.Spec.ProducedArtifacts {
+			artifacts = append(artifacts, transformertypes.Artifact{Name: artifact.Name, Type: artifactType, Paths: map[transformertypes.PathType][]string{"File": {filePath}}})
+		}
+	}
+	return pathMappings, artifacts, nil
+}
+
+func TestTransformResume(t *testing.T) {
+	common.TempPath = t.TempDir()
+	workingDir := t.TempDir()
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get the working directory. Error: %q", err)
+	}
+	if err := os.Chdir(workingDir); err != nil {
+		t.Fatalf("failed to change the working directory. Error: %q", err)
+	}
+	defer os.Chdir(currentDir)
+	defer Reset()
+	sourceDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sourceDir, "a"), []byte("a"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write the source file. Error: %q", err)
+	}
+	// T1 runs in the first iteration, T2 in the second and T3 in the third
+	chain := []transformertypes.ArtifactType{"Input", "A", "B", ""}
+	chainTransformers := []*chainTransformer{}
+	for i, name := range []string{"T1", "T2", "T3"} {
+		tc := transformertypes.Transformer{}
+		tc.Name = name
+		tc.Labels = map[string]string{transformertypes.LabelName: tc.Name}
+		tc.Spec.Class = "Chain"
+		tc.Spec.ConsumedArtifacts = map[transformertypes.ArtifactType]transformertypes.ArtifactProcessConfig{chain[i]: {}}
+		tc.Spec.ProducedArtifacts = map[transformertypes.ArtifactType]transformertypes.ProducedArtifact{}
+		if chain[i+1] != "" {
+			tc.Spec.ProducedArtifacts[chain[i+1]] = transformertypes.ProducedArtifact{}
+		}
+		env, err := environment.NewEnvironment(environment.EnvInfo{
+			Name:              tc.Name,
+			ProjectName:       "myproject",
+			Source:            sourceDir,
+			Context:           t.TempDir(),
+			EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("failed to create the environment. Error: %q", err)
+		}
+		tr := &chainTransformer{}
+		if err := tr.Init(tc, env); err != nil {
+			t.Fatalf("failed to initialize the transformer. Error: %q", err)
+		}
+		transformers = append(transformers, tr)
+		chainTransformers = append(chainTransformers, tr)
+	}
+	planArtifacts := []plantypes.PlanArtifact{{
+		ServiceName:     "a",
+		TransformerName: "T1",
+		Artifact:        transformertypes.Artifact{Name: "a", Type: "Input", Paths: map[transformertypes.PathType][]string{"File": {filepath.Join(sourceDir, "a")}}},
+	}}
+	run := func(outputPath string, resume bool) error {
+		for _, tr := range chainTransformers {
+			tr.env.Output = outputPath
+		}
+		_, err := Transform(context.Background(), planArtifacts, sourceDir, outputPath, -1, 1, nil, resume)
+		return err
+	}
+
+	expectedOutputPath := filepath.Join(t.TempDir(), "myproject")
+	if err := run(expectedOutputPath, false); err != nil {
+		t.Fatalf("failed to transform. Error: %q", err)
+	}
+	outputPath := filepath.Join(t.TempDir(), "myproject")
+	ctx, cancel := context.WithCancel(context.Background())
+	chainTransformers[1].cancel = cancel
+	if _, err := Transform(ctx, planArtifacts, sourceDir, outputPath, -1, 1, nil, false); err == nil {
+		t.Fatalf("expected the interrupted transformation to fail")
+	}
+	chainTransformers[1].cancel = nil
+	checkpointDir := GetCheckpointDir(outputPath)
+	if _, err := os.Stat(filepath.Join(checkpointDir, CheckpointFileName)); err != nil {
+		t.Fatalf("expected the interrupted transformation to leave a checkpoint. Error: %q", err)
+	}
+	// the temporary files of the interrupted transformation are removed when the process exits
+	for _, tr := range chainTransformers {
+		entries, err := os.ReadDir(tr.env.TempPath)
+		if err != nil {
+			t.Fatalf("failed to read the temporary directory. Error: %q", err)
+		}
+		for _, entry := range entries {
+			if err := os.RemoveAll(filepath.Join(tr.env.TempPath, entry.Name())); err != nil {
+				t.Fatalf("failed to remove the temporary files. Error: %q", err)
+			}
+		}
+	}
+	if err := run(outputPath, true); err != nil {
+		t.Fatalf("failed to resume the transformation. Error: %q", err)
+	}
+	expectedOutput := readDirContents(t, expectedOutputPath)
+	if len(expectedOutput) != len(chainTransformers) {
+		t.Fatalf("expected every transformer in the chain to write a file. Actual: %+v", expectedOutput)
+	}
+	if diff := cmp.Diff(expectedOutput, readDirContents(t, outputPath)); diff != "" {
+		t.Fatalf("the output of the resumed transformation is different. Differences:\n%s", diff)
+	}
+	if _, err := os.Stat(checkpointDir); !os.IsNotExist(err) {
+		t.Fatalf("expected the checkpoint to be removed after the transformation completed. Error: %q", err)
+	}
+}
+
+// readDirContents returns the contents of all the files in the directory, keyed by their relative paths
+func readDirContents(t *testing.T, dir string) map[string]string {
+	contents := map[string]string{}
+	if err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		contents[relPath] = string(content)
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to read the directory %s . Error: %q", dir, err)
+	}
+	return contents
+}
@@ -218,7 +218,7 @@ func (t *CloudFoundry) Transform(newArtifacts []transformertypes.Artifact, alrea
 			ir.Services[serviceConfig.ServiceName] = irService
 		}
 		if len(containerizationOptionsConfig) != 0 {
-			quesKey := common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceConfig.ServiceName+`"`, common.ConfigContainerizationOptionServiceKeySegment)
+			quesKey := fmt.Sprintf(common.ConfigServicesContainerizationOptionKey, `"`+serviceConfig.ServiceName+`"`)
 			containerizationOptions := qaengine.FetchMultiSelectAnswer(
 				quesKey,
 				fmt.Sprintf("Select the transformer to use for containerizing the '%s' service :", serviceConfig.ServiceName),
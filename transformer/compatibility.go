@@ -0,0 +1,80 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/konveyor/move2kube/types/info"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrIncompatibleTransformer is returned when the version requirements of a transformer are not satisfied
+var ErrIncompatibleTransformer = errors.New("the transformer is not compatible with this version of move2kube")
+
+// checkTransformerRequirements checks the version requirements of the transformer against the move2kube version and the artifact schema versions
+func checkTransformerRequirements(tc transformertypes.Transformer) error {
+	if tc.Spec.Requires.Move2Kube != "" {
+		constraint, err := semver.NewConstraint(tc.Spec.Requires.Move2Kube)
+		if err != nil {
+			return fmt.Errorf("failed to parse the move2kube version range '%s' . Error: %w", tc.Spec.Requires.Move2Kube, err)
+		}
+		if version, err := getReleaseVersion(info.GetVersion()); err != nil {
+			logrus.Warnf("Not checking the move2kube version range '%s' of the transformer '%s' since the version '%s' of this build is not a valid semantic version. Error: %q", tc.Spec.Requires.Move2Kube, tc.Name, info.GetVersion(), err)
+		} else if !constraint.Check(version) {
+			return fmt.Errorf("%w : it requires move2kube '%s' but the version is '%s'", ErrIncompatibleTransformer, tc.Spec.Requires.Move2Kube, info.GetVersion())
+		}
+	}
+	configTypes := []transformertypes.ConfigType{}
+	for configType := range tc.Spec.Requires.ArtifactSchemas {
+		configTypes = append(configTypes, configType)
+	}
+	sort.Strings(configTypes)
+	for _, configType := range configTypes {
+		versionRange := tc.Spec.Requires.ArtifactSchemas[configType]
+		constraint, err := semver.NewConstraint(versionRange)
+		if err != nil {
+			return fmt.Errorf("failed to parse the version range '%s' for the schema of the config type '%s' . Error: %w", versionRange, configType, err)
+		}
+		schemaVersion, ok := transformertypes.GetConfigSchemaVersion(configType)
+		if !ok {
+			return fmt.Errorf("%w : it requires the schema of the config type '%s' which this version of move2kube does not have", ErrIncompatibleTransformer, configType)
+		}
+		version, err := semver.NewVersion(schemaVersion)
+		if err != nil {
+			return fmt.Errorf("failed to parse the version '%s' of the schema for the config type '%s' . Error: %w", schemaVersion, configType, err)
+		}
+		if !constraint.Check(version) {
+			return fmt.Errorf("%w : it requires the schema '%s' of the config type '%s' but the version is '%s'", ErrIncompatibleTransformer, versionRange, configType, schemaVersion)
+		}
+	}
+	return nil
+}
+
+// getReleaseVersion returns the version without the pre-release and build metadata,
+// so that pre-release builds satisfy the same ranges as the release they lead to
+func getReleaseVersion(versionStr string) (*semver.Version, error) {
+	version, err := semver.NewVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	return semver.NewVersion(fmt.Sprintf("%d.%d.%d", version.Major(), version.Minor(), version.Patch()))
+}
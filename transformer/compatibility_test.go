@@ -0,0 +1,79 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"errors"
+	"testing"
+
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+)
+
+func TestCheckTransformerRequirements(t *testing.T) {
+	testCases := []struct {
+		name             string
+		requires         transformertypes.TransformerRequirements
+		wantErr          bool
+		wantIncompatible bool
+	}{
+		{name: "no requirements"},
+		{name: "satisfied move2kube range", requires: transformertypes.TransformerRequirements{Move2Kube: ">= 0.1.0"}},
+		{name: "unsatisfied move2kube range", requires: transformertypes.TransformerRequirements{Move2Kube: ">= 99.0.0"}, wantErr: true, wantIncompatible: true},
+		{name: "invalid move2kube range", requires: transformertypes.TransformerRequirements{Move2Kube: "not a range"}, wantErr: true},
+		{
+			name:     "satisfied schema range",
+			requires: transformertypes.TransformerRequirements{ArtifactSchemas: map[transformertypes.ConfigType]string{artifacts.ServiceConfigType: "^1.0.0"}},
+		},
+		{
+			name:             "unsatisfied schema range",
+			requires:         transformertypes.TransformerRequirements{ArtifactSchemas: map[transformertypes.ConfigType]string{artifacts.ServiceConfigType: ">= 2.0.0"}},
+			wantErr:          true,
+			wantIncompatible: true,
+		},
+		{
+			name:             "unknown config type",
+			requires:         transformertypes.TransformerRequirements{ArtifactSchemas: map[transformertypes.ConfigType]string{"DoesNotExist": "*"}},
+			wantErr:          true,
+			wantIncompatible: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tc := transformertypes.NewTransformer()
+			tc.Name = "t1"
+			tc.Spec.Requires = testCase.requires
+			err := checkTransformerRequirements(tc)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("expected error: %t . Actual: %v", testCase.wantErr, err)
+			}
+			if errors.Is(err, ErrIncompatibleTransformer) != testCase.wantIncompatible {
+				t.Fatalf("expected an incompatible transformer error: %t . Actual: %v", testCase.wantIncompatible, err)
+			}
+		})
+	}
+}
+
+func TestGetReleaseVersion(t *testing.T) {
+	version, err := getReleaseVersion("v0.4.0-rc.1+unreleased")
+	if err != nil {
+		t.Fatalf("failed to parse the version. Error: %q", err)
+	}
+	if version.String() != "0.4.0" {
+		t.Fatalf("expected the version 0.4.0 . Actual: %s", version)
+	}
+}
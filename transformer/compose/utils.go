@@ -238,7 +238,7 @@ func getUserInputsOnStorageType(filePath string, serviceName string) (string, er
 	defAnswer := ignoreDataAnswer
 	desc := "Select the storage type to create"
 	hints := []string{"By default, no storage type will be created. Data source will be ignored"}
-	volQaKey := common.JoinQASubKeys(common.VolQaPrefixKey, `"`+serviceName+`"`, ".options")
+	volQaKey := fmt.Sprintf(common.ConfigStorageTypeForServiceKey, `"`+serviceName+`"`)
 	options := []string{pvcOpt, ignoreDataAnswer}
 	if isPath(filePath) {
 		isWithinLimits, err := withinK8sConfigSizeLimit(filePath)
@@ -0,0 +1,215 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/qaengine"
+	"github.com/konveyor/move2kube/types/info"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// detectCache stores the results of directory detection on disk.
+// The results are keyed by the directory path, the hash of the directory contents, the transformer name,
+// the hash of the transformer config and the files in its directory and the fingerprint of the QA answers.
+type detectCache struct {
+	cacheDir          string
+	dirHashesMu       sync.Mutex
+	dirHashes         map[string]string
+	mu                sync.Mutex
+	transformerHashes map[string]string
+}
+
+// newDetectCache returns nil if the cache directory is empty. The directories are hashed when they are first detected in.
+func newDetectCache(cacheDir string) (*detectCache, error) {
+	if cacheDir == "" {
+		return nil, nil
+	}
+	cacheDir, err := filepath.Abs(cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make the detection cache directory path absolute. Error: %w", err)
+	}
+	if err := os.MkdirAll(cacheDir, common.DefaultDirectoryPermission); err != nil {
+		return nil, fmt.Errorf("failed to create the detection cache directory at path %s . Error: %w", cacheDir, err)
+	}
+	return &detectCache{cacheDir: cacheDir, dirHashes: map[string]string{}, transformerHashes: map[string]string{}}, nil
+}
+
+// getDirHash returns the hash of the directory. The hashes of the sub directories are reused by later calls.
+func (c *detectCache) getDirHash(dir string) (string, error) {
+	c.dirHashesMu.Lock()
+	defer c.dirHashesMu.Unlock()
+	return hashDirectory(dir, c.dirHashes, c.cacheDir)
+}
+
+// hashDirectory returns the hash of the names and the contents of all the files in the directory.
+// The hashes of all the sub directories are stored in dirHashes and the hashes already in it are reused.
+// Ignored directories and the skipped directory are not hashed.
+func hashDirectory(dir string, dirHashes map[string]string, skipDir string) (string, error) {
+	if dirHash, ok := dirHashes[dir]; ok {
+		return dirHash, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.Type()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "link %s %s\n", entry.Name(), target)
+		case entry.IsDir():
+			if isIgnoredDirectoryName(entry.Name()) || path == skipDir {
+				continue
+			}
+			subDirHash, err := hashDirectory(path, dirHashes, skipDir)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "dir %s %s\n", entry.Name(), subDirHash)
+		case entry.Type().IsRegular():
+			fileHash, err := hashFile(path)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "file %s %s\n", entry.Name(), fileHash)
+		}
+	}
+	dirHash := hex.EncodeToString(h.Sum(nil))
+	dirHashes[dir] = dirHash
+	return dirHash, nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isIgnoredDirectoryName(name string) bool {
+	for _, dirRegExp := range common.DefaultIgnoreDirRegexps {
+		if dirRegExp.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTransformerHash returns the hash of the move2kube version, the transformer config and the files in the transformer directory
+func (c *detectCache) getTransformerHash(tc transformertypes.Transformer) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if transformerHash, ok := c.transformerHashes[tc.Name]; ok {
+		return transformerHash, nil
+	}
+	tcBytes, err := yaml.Marshal(tc)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the transformer config to yaml. Error: %w", err)
+	}
+	h := sha256.New()
+	versionInfo := info.GetVersionInfo()
+	fmt.Fprintf(h, "%s %s\n", versionInfo.Version, versionInfo.GitCommit)
+	h.Write(tcBytes)
+	if tc.Spec.TransformerYamlPath != "" {
+		transformerDirHash, err := hashDirectory(filepath.Dir(tc.Spec.TransformerYamlPath), map[string]string{}, c.cacheDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash the directory of the transformer. Error: %w", err)
+		}
+		fmt.Fprintf(h, "%s\n", transformerDirHash)
+	}
+	transformerHash := hex.EncodeToString(h.Sum(nil))
+	c.transformerHashes[tc.Name] = transformerHash
+	return transformerHash, nil
+}
+
+// getCachePath returns the path of the file where the detection results for the directory and the transformer are stored.
+// It must be called before detecting, since the questions asked during the detection change the fingerprint of the answers.
+// It returns an empty path when caching is disabled or the key could not be computed.
+func (c *detectCache) getCachePath(dir string, tc transformertypes.Transformer) string {
+	if c == nil {
+		return ""
+	}
+	dirHash, err := c.getDirHash(dir)
+	if err != nil {
+		logrus.Debugf("failed to hash the directory %s for the detection cache. Error: %q", dir, err)
+		return ""
+	}
+	transformerHash, err := c.getTransformerHash(tc)
+	if err != nil {
+		logrus.Debugf("failed to hash the transformer %s for the detection cache. Error: %q", tc.Name, err)
+		return ""
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s\n%s\n", dir, dirHash, tc.Name, transformerHash, qaengine.GetAnswersFingerprint())
+	key := hex.EncodeToString(h.Sum(nil))
+	return filepath.Join(c.cacheDir, key[:2], key+".yaml")
+}
+
+// get returns the cached detection results stored at the cache path
+func (c *detectCache) get(cachePath string) (map[string][]transformertypes.Artifact, bool) {
+	if c == nil || cachePath == "" {
+		return nil, false
+	}
+	if _, err := os.Stat(cachePath); err != nil {
+		return nil, false
+	}
+	services := map[string][]transformertypes.Artifact{}
+	if err := common.ReadYaml(cachePath, &services); err != nil {
+		logrus.Debugf("failed to read the cached detection results at path %s . Error: %q", cachePath, err)
+		return nil, false
+	}
+	return services, true
+}
+
+// put stores the detection results at the cache path
+func (c *detectCache) put(cachePath string, services map[string][]transformertypes.Artifact) {
+	if c == nil || cachePath == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), common.DefaultDirectoryPermission); err != nil {
+		logrus.Warnf("failed to create the detection cache directory at path %s . Error: %q", filepath.Dir(cachePath), err)
+		return
+	}
+	if services == nil {
+		services = map[string][]transformertypes.Artifact{}
+	}
+	if err := common.WriteYaml(cachePath, services); err != nil {
+		logrus.Warnf("failed to write the detection results to the cache. Error: %q", err)
+	}
+}
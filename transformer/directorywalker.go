@@ -0,0 +1,268 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/konveyor/move2kube/common"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+// directoryWalkerLookahead is the maximum number of directories the transformers can detect in ahead of the walk
+const directoryWalkerLookahead = 32
+
+// walkDirectory is a directory that is visited while walking the source directory for services
+type walkDirectory struct {
+	path string
+	// detect is false for ignored directories whose sub directories are still walked
+	detect bool
+}
+
+// detectOutput is the result of the directory detection of a single transformer
+type detectOutput struct {
+	services map[string][]transformertypes.Artifact
+	err      error
+}
+
+// walkDirectoryOutputs collects the detection results of all the transformers for a single directory
+type walkDirectoryOutputs struct {
+	remaining int
+	outputs   []detectOutput
+	ready     chan struct{}
+}
+
+// directoryWalker runs the directory detection of the transformers ahead of the walk.
+// Each transformer detects in the directories in walk order on its own goroutine, so a transformer never runs concurrently with itself.
+// The walk consumes the results in order, so the services found are the same as when walking serially.
+// A directory is detected in only after the walk has processed all of its parent directories, so the directories
+// pruned by the walk are never detected in. The only exception is a directory that a detection in a directory
+// outside its parents reports as a service directory, its results are discarded.
+type directoryWalker struct {
+	ctx          context.Context
+	directories  []walkDirectory
+	transformers []Transformer
+	cache        *detectCache
+	concurrent   bool
+	semaphore    chan struct{}
+	wg           sync.WaitGroup
+	mu           sync.Mutex
+	cond         *sync.Cond
+	stopped      bool
+	// parents has the index of the closest parent directory of each directory, -1 if there is none
+	parents []int
+	// next is the index of the directory that the walk will process next
+	next int
+	// pruned has the directories whose sub directories are skipped
+	pruned map[string]bool
+	// serviceDirs has the service directories of the services detected so far, they are skipped but their sub directories are not
+	serviceDirs map[string]bool
+	outputs     map[int]*walkDirectoryOutputs
+}
+
+// getDirectoriesToWalk returns the directories under the input path in walk order.
+// Directories whose contents are ignored are returned but their sub directories are not.
+func getDirectoriesToWalk(inputPath string, ignoreDirectories, ignoreContents []string) ([]walkDirectory, error) {
+	directories := []walkDirectory{}
+	err := filepath.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
+		if err != nil {
+			logrus.Warnf("Skipping path %q due to error. Error: %q", path, err)
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if isIgnoredDirectoryName(filepath.Base(path)) {
+			return filepath.SkipDir
+		}
+		if common.IsPresent(ignoreDirectories, path) {
+			if common.IsPresent(ignoreContents, path) {
+				return filepath.SkipDir
+			}
+			directories = append(directories, walkDirectory{path: path})
+			return nil
+		}
+		directories = append(directories, walkDirectory{path: path, detect: true})
+		if common.IsPresent(ignoreContents, path) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return directories, err
+}
+
+// newDirectoryWalker starts detecting in the directories when parallelism is more than 1
+func newDirectoryWalker(ctx context.Context, directories []walkDirectory, transformers []Transformer, cache *detectCache, parallelism int) *directoryWalker {
+	w := &directoryWalker{
+		ctx:          ctx,
+		directories:  directories,
+		transformers: transformers,
+		cache:        cache,
+		concurrent:   parallelism > 1 && len(transformers) > 0,
+		pruned:       map[string]bool{},
+		serviceDirs:  map[string]bool{},
+		outputs:      map[int]*walkDirectoryOutputs{},
+	}
+	w.cond = sync.NewCond(&w.mu)
+	if !w.concurrent {
+		return w
+	}
+	logrus.Debugf("detecting in %d directories using %d transformers with parallelism %d", len(directories), len(transformers), parallelism)
+	w.parents = getParentDirectoryIndices(directories)
+	w.semaphore = make(chan struct{}, parallelism)
+	for ti := range transformers {
+		w.wg.Add(1)
+		go w.runTransformer(ti)
+	}
+	return w
+}
+
+// getParentDirectoryIndices returns the index of the closest parent of each directory. The directories must be in walk order.
+func getParentDirectoryIndices(directories []walkDirectory) []int {
+	parents := make([]int, len(directories))
+	stack := []int{}
+	for i, directory := range directories {
+		for len(stack) > 0 && !common.IsParent(directory.path, directories[stack[len(stack)-1]].path) {
+			stack = stack[:len(stack)-1]
+		}
+		parents[i] = -1
+		if len(stack) > 0 {
+			parents[i] = stack[len(stack)-1]
+		}
+		stack = append(stack, i)
+	}
+	return parents
+}
+
+// runTransformer detects in the directories in order using a single transformer.
+// It waits for the walk to process the parent directories, since they decide whether the directory is pruned.
+func (w *directoryWalker) runTransformer(ti int) {
+	defer w.wg.Done()
+	for i, directory := range w.directories {
+		if !directory.detect {
+			continue
+		}
+		w.mu.Lock()
+		for !w.stopped && (i >= w.next+directoryWalkerLookahead || w.parents[i] >= w.next) {
+			w.cond.Wait()
+		}
+		if w.stopped {
+			w.mu.Unlock()
+			return
+		}
+		skip := i < w.next || w.isSkippedLocked(directory.path)
+		w.mu.Unlock()
+		output := detectOutput{}
+		if !skip {
+			w.semaphore <- struct{}{}
+			output.services, output.err = detectInDirectory(w.ctx, w.transformers[ti], directory.path, w.cache)
+			<-w.semaphore
+		}
+		w.setOutput(i, ti, output)
+	}
+}
+
+func (w *directoryWalker) getOutputsLocked(i int) *walkDirectoryOutputs {
+	o, ok := w.outputs[i]
+	if !ok {
+		o = &walkDirectoryOutputs{remaining: len(w.transformers), outputs: make([]detectOutput, len(w.transformers)), ready: make(chan struct{})}
+		w.outputs[i] = o
+	}
+	return o
+}
+
+func (w *directoryWalker) setOutput(i, ti int, output detectOutput) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if i < w.next {
+		return
+	}
+	o := w.getOutputsLocked(i)
+	o.outputs[ti] = output
+	o.remaining--
+	if o.remaining == 0 {
+		close(o.ready)
+	}
+}
+
+// getOutputs returns the detection results of all the transformers, in order, for the directory at the given index
+func (w *directoryWalker) getOutputs(i int) []detectOutput {
+	if !w.concurrent {
+		outputs := make([]detectOutput, len(w.transformers))
+		for ti, transformer := range w.transformers {
+			outputs[ti].services, outputs[ti].err = detectInDirectory(w.ctx, transformer, w.directories[i].path, w.cache)
+		}
+		return outputs
+	}
+	w.mu.Lock()
+	o := w.getOutputsLocked(i)
+	w.mu.Unlock()
+	<-o.ready
+	return o.outputs
+}
+
+func (w *directoryWalker) isSkippedLocked(path string) bool {
+	if w.serviceDirs[path] {
+		return true
+	}
+	for {
+		if w.pruned[path] {
+			return true
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return false
+		}
+		path = parent
+	}
+}
+
+// isSkipped returns true if the directory is the service directory of a detected service or if it or one of its parents was pruned by the walk
+func (w *directoryWalker) isSkipped(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.isSkippedLocked(path)
+}
+
+// advance marks the directory at the given index as processed. If prune is true its sub directories are skipped.
+// The service directories of the services detected in it are skipped.
+func (w *directoryWalker) advance(i int, prune bool, serviceDirs []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if prune {
+		w.pruned[w.directories[i].path] = true
+	}
+	for _, serviceDir := range serviceDirs {
+		w.serviceDirs[serviceDir] = true
+	}
+	delete(w.outputs, i)
+	w.next = i + 1
+	w.cond.Broadcast()
+}
+
+// stop waits for the transformers to finish detecting in the directories they are currently in
+func (w *directoryWalker) stop() {
+	w.mu.Lock()
+	w.stopped = true
+	w.cond.Broadcast()
+	w.mu.Unlock()
+	w.wg.Wait()
+}
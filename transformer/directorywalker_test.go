@@ -0,0 +1,202 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	"github.com/konveyor/move2kube/qaengine"
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+	plantypes "github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+)
+
+// markerFileTransformer detects a service in every directory that contains its marker file
+type markerFileTransformer struct {
+	config     transformertypes.Transformer
+	env        *environment.Environment
+	markerFile string
+	mu         sync.Mutex
+	detected   []string
+}
+
+func (t *markerFileTransformer) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+	t.config = tc
+	t.env = env
+	return nil
+}
+
+func (t *markerFileTransformer) GetConfig() (transformertypes.Transformer, *environment.Environment) {
+	return t.config, t.env
+}
+
+func (t *markerFileTransformer) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+	t.mu.Lock()
+	t.detected = append(t.detected, dir)
+	t.mu.Unlock()
+	if _, err := os.Stat(filepath.Join(dir, t.markerFile)); err != nil {
+		return nil, nil
+	}
+	return map[string][]transformertypes.Artifact{
+		filepath.Base(dir): {{Paths: map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {dir}}}},
+	}, nil
+}
+
+func (t *markerFileTransformer) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	return nil, nil, nil
+}
+
+func TestWalkForServices(t *testing.T) {
+	common.TempPath = t.TempDir()
+	sourceDir := t.TempDir()
+	files := []string{
+		"app1/go.mod",
+		"app1/internal/go.mod",
+		"app2/package.json",
+		"libs/lib1/go.mod",
+		"libs/lib2/package.json",
+		".hidden/go.mod",
+		"docs/README.md",
+	}
+	for _, file := range files {
+		path := filepath.Join(sourceDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), common.DefaultDirectoryPermission); err != nil {
+			t.Fatalf("failed to create the directory for %s . Error: %q", file, err)
+		}
+		if err := os.WriteFile(path, []byte(file), common.DefaultFilePermission); err != nil {
+			t.Fatalf("failed to write the file %s . Error: %q", file, err)
+		}
+	}
+	newTransformers := func() []*markerFileTransformer {
+		ts := []*markerFileTransformer{}
+		for _, nameAndMarkerFile := range [][2]string{{"Golang", "go.mod"}, {"Nodejs", "package.json"}} {
+			name, markerFile := nameAndMarkerFile[0], nameAndMarkerFile[1]
+			tc := transformertypes.Transformer{}
+			tc.Name = name
+			tc.Spec.DirectoryDetect.Levels = -1
+			env, err := environment.NewEnvironment(environment.EnvInfo{
+				Name:              name,
+				ProjectName:       "myproject",
+				Source:            sourceDir,
+				Context:           t.TempDir(),
+				EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+			}, nil)
+			if err != nil {
+				t.Fatalf("failed to create the environment. Error: %q", err)
+			}
+			tr := &markerFileTransformer{markerFile: markerFile}
+			if err := tr.Init(tc, env); err != nil {
+				t.Fatalf("failed to initialize the transformer. Error: %q", err)
+			}
+			ts = append(ts, tr)
+		}
+		return ts
+	}
+	walk := func(ts []*markerFileTransformer, parallelism int, cacheDir string) map[string][]plantypes.PlanArtifact {
+		defer Reset()
+		for _, tr := range ts {
+			transformers = append(transformers, tr)
+		}
+		SetDetectOptions(parallelism, cacheDir)
+		defer SetDetectOptions(1, "")
+		cache, err := newDetectCache(cacheDir)
+		if err != nil {
+			t.Fatalf("failed to create the detection cache. Error: %q", err)
+		}
+		services, err := walkForServices(context.Background(), sourceDir, map[string][]plantypes.PlanArtifact{}, cache)
+		if err != nil {
+			t.Fatalf("failed to walk for services. Error: %q", err)
+		}
+		return services
+	}
+
+	serialTransformers := newTransformers()
+	expected := walk(serialTransformers, 1, "")
+	expectedServices := []string{"app1", "app2", "lib1", "lib2"}
+	for _, serviceName := range expectedServices {
+		if len(expected[serviceName]) != 1 {
+			t.Fatalf("expected the service %s to be detected. Actual: %+v", serviceName, expected)
+		}
+	}
+	if len(expected) != len(expectedServices) {
+		t.Fatalf("expected the services %v . Actual: %+v", expectedServices, expected)
+	}
+	for _, tr := range serialTransformers {
+		if common.IsPresent(tr.detected, filepath.Join(sourceDir, "app1", "internal")) {
+			t.Fatalf("the sub directory of a detected service should not be walked. Detected in: %v", tr.detected)
+		}
+	}
+
+	for run := 0; run < 5; run++ {
+		parallelTransformers := newTransformers()
+		if actual := walk(parallelTransformers, 4, ""); !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("the services detected in parallel are different. Expected: %+v Actual: %+v", expected, actual)
+		}
+		for i, tr := range parallelTransformers {
+			if common.IsPresent(tr.detected, filepath.Join(sourceDir, "app1", "internal")) {
+				t.Fatalf("the sub directory of a detected service should not be detected in by the parallel walk. Detected in: %v", tr.detected)
+			}
+			if !reflect.DeepEqual(tr.detected, serialTransformers[i].detected) {
+				t.Fatalf("expected the parallel walk to detect in the same directories as the serial walk. Expected: %v Actual: %v", serialTransformers[i].detected, tr.detected)
+			}
+		}
+	}
+
+	cacheDir := t.TempDir()
+	if actual := walk(newTransformers(), 4, cacheDir); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("the services detected with an empty cache are different. Expected: %+v Actual: %+v", expected, actual)
+	}
+	if err := os.WriteFile(filepath.Join(sourceDir, "app2", "index.js"), []byte("changed"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to change the source. Error: %q", err)
+	}
+	cachedTransformers := newTransformers()
+	if actual := walk(cachedTransformers, 4, cacheDir); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("the services detected using the cache are different. Expected: %+v Actual: %+v", expected, actual)
+	}
+	changedDirs := []string{sourceDir, filepath.Join(sourceDir, "app2")}
+	for _, tr := range cachedTransformers {
+		for _, dir := range tr.detected {
+			if !common.IsPresent(changedDirs, dir) {
+				t.Fatalf("the transformer %s detected in the unchanged directory %s", tr.config.Name, dir)
+			}
+		}
+	}
+
+	// the cached results can not be used once the answers are different
+	qaengine.StartEngine(true, 0, true)
+	qaengine.FetchStringAnswer(fmt.Sprintf("move2kube.test.detectcache%d", time.Now().UnixNano()), "Test question", nil, "answer", nil)
+	answeredTransformers := newTransformers()
+	if actual := walk(answeredTransformers, 4, cacheDir); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("the services detected after answering a question are different. Expected: %+v Actual: %+v", expected, actual)
+	}
+	for i, tr := range answeredTransformers {
+		if !reflect.DeepEqual(tr.detected, serialTransformers[i].detected) {
+			t.Fatalf("expected the transformer %s to detect again in all the directories. Expected: %v Actual: %v", tr.config.Name, serialTransformers[i].detected, tr.detected)
+		}
+	}
+}
@@ -42,7 +42,7 @@ const (
 
 // AskUserForDockerfileType asks the user what type of Dockerfiles to generate.
 func AskUserForDockerfileType(rootProjectName string) (buildOption, error) {
-	quesId := common.JoinQASubKeys(common.ConfigServicesKey, `"`+rootProjectName+`"`, "dockerfileType")
+	quesId := fmt.Sprintf(common.ConfigServicesDockerfileTypeKey, `"`+rootProjectName+`"`)
 	desc := fmt.Sprintf("What type of Dockerfiles should be generated for the service '%s'?", rootProjectName)
 	options := []string{
 		string(NO_BUILD_STAGE),
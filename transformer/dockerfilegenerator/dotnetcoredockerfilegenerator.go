@@ -457,7 +457,7 @@ func (t *DotNetCoreDockerfileGenerator) TransformArtifact(newArtifact transforme
 
 		// select a profile to use for publishing the child project
 
-		qaSubKey := common.JoinQASubKeys(`"`+newArtifact.Name+`"`, "childProjects", `"`+childProject.Name+`"`)
+		qaSubKey := fmt.Sprintf(common.ConfigServicesChildProjectQASubKey, `"`+newArtifact.Name+`"`, `"`+childProject.Name+`"`)
 		relSelectedProfilePath, _, err := getPublishProfile(publishProfilePaths, qaSubKey, serviceDir)
 		if err != nil {
 			logrus.Errorf("failed to select one of the publish profiles for the asp net app. Error: %q Profiles: %+v", err, publishProfilePaths)
@@ -645,7 +645,7 @@ func getPublishProfile(profilePaths []string, subKey, baseDir string) (string, s
 	}
 	relSelectedProfilePath := relProfilePaths[0]
 	if len(relProfilePaths) > 1 {
-		quesKey := common.JoinQASubKeys(common.ConfigServicesKey, subKey, common.ConfigPublishProfileForServiceKeySegment)
+		quesKey := fmt.Sprintf(common.ConfigServicesPublishProfileKey, subKey)
 		desc := fmt.Sprintf("Select the profile to be use for publishing the ASP.NET child project %s :", subKey)
 		relSelectedProfilePath = qaengine.FetchSelectAnswer(quesKey, desc, nil, relSelectedProfilePath, relProfilePaths, nil)
 	}
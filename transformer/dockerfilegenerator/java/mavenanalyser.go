@@ -343,7 +343,7 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 
 		// have the user select the port to use
 
-		selectedPort := commonqa.GetPortForService(detectedPorts, common.JoinQASubKeys(`"`+serviceConfig.ServiceName+`"`, "childModules", `"`+childModule.Name+`"`))
+		selectedPort := commonqa.GetPortForService(detectedPorts, fmt.Sprintf(common.ConfigServicesChildModuleQASubKey, `"`+serviceConfig.ServiceName+`"`, `"`+childModule.Name+`"`))
 		if childModuleInfo.SpringBoot != nil {
 			envVarsMap["SERVER_PORT"] = cast.ToString(selectedPort)
 		} else {
@@ -447,7 +447,7 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 	// ask the user which maven profiles should be used while building the app
 
 	selectedMavenProfiles := qaengine.FetchMultiSelectAnswer(
-		common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceConfig.ServiceName+`"`, "mavenProfiles"),
+		fmt.Sprintf(common.ConfigServicesMavenProfilesKey, `"`+serviceConfig.ServiceName+`"`),
 		fmt.Sprintf("Select the maven profiles to use for the '%s' service", serviceConfig.ServiceName),
 		[]string{"The selected maven profiles will be used during the build."},
 		rootPomInfo.MavenProfiles,
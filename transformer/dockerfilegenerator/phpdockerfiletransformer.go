@@ -130,7 +130,7 @@ func detectConfFiles(dir string) ([]string, error) {
 func GetConfFileForService(confFiles []string, serviceName string) string {
 	noAnswer := "none of the above"
 	confFiles = append(confFiles, noAnswer)
-	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceName+`"`, common.ConfigApacheConfFileForServiceKeySegment)
+	quesKey := fmt.Sprintf(common.ConfigServicesApacheConfFileKey, `"`+serviceName+`"`)
 	desc := fmt.Sprintf("Choose the apache config file to be used for the service %s", serviceName)
 	hints := []string{fmt.Sprintf("Selected apache config file will be used for identifying the port to be exposed for the service %s", serviceName)}
 	selectedConfFile := qaengine.FetchSelectAnswer(quesKey, desc, hints, confFiles[0], confFiles, nil)
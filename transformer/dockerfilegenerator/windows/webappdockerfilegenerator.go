@@ -325,7 +325,7 @@ func (t *WinWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes
 
 			// have the user select the ports to use for the child project
 
-			selectedPorts := commonqa.GetPortsForService(detectedPorts, common.JoinQASubKeys(`"`+newArtifact.Name+`"`, "childProjects", `"`+childProject.Name+`"`))
+			selectedPorts := commonqa.GetPortsForService(detectedPorts, fmt.Sprintf(common.ConfigServicesChildProjectQASubKey, `"`+newArtifact.Name+`"`, `"`+childProject.Name+`"`))
 
 			// data to fill the Dockerfile template
 
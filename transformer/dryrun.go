@@ -0,0 +1,165 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/filesystem"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+)
+
+// DryRunOutput is a scratch copy of the output directory that a dry run writes to instead of the output directory.
+// The transformers see the same output as in a real run, and the copy is compared with the output directory at the end.
+type DryRunOutput struct {
+	// OutputPath is the output directory, which is not modified by the dry run
+	OutputPath string
+	// ScratchPath is the copy of the output directory that the transformers write to
+	ScratchPath string
+}
+
+// NewDryRunOutput copies the output directory to a new scratch directory with the same name
+func NewDryRunOutput(outputPath string) (*DryRunOutput, error) {
+	scratchDir, err := os.MkdirTemp(common.TempPath, "dryrun-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a scratch directory for the dry run. Error: %w", err)
+	}
+	dryRunOutput := &DryRunOutput{OutputPath: outputPath, ScratchPath: filepath.Join(scratchDir, filepath.Base(outputPath))}
+	if _, err := os.Stat(outputPath); err != nil {
+		if os.IsNotExist(err) {
+			return dryRunOutput, nil
+		}
+		return nil, fmt.Errorf("failed to stat the output directory '%s' . Error: %w", outputPath, err)
+	}
+	if err := filesystem.Replicate(outputPath, dryRunOutput.ScratchPath); err != nil {
+		return nil, fmt.Errorf("failed to copy the output directory '%s' to the scratch directory '%s' . Error: %w", outputPath, dryRunOutput.ScratchPath, err)
+	}
+	return dryRunOutput, nil
+}
+
+// Remove deletes the scratch copy of the output directory
+func (d *DryRunOutput) Remove() error {
+	return os.RemoveAll(filepath.Dir(d.ScratchPath))
+}
+
+// getDryRunSummary compares the files in the scratch copy of the output directory after the dry run with the files in the output directory.
+// The changes are grouped by the transformers whose path mappings wrote or deleted the files.
+func getDryRunSummary(pathMappings []transformertypes.PathMapping, sourceDir, outputPath, scratchOutputPath string) (transformertypes.DryRunSummary, error) {
+	summary := transformertypes.DryRunSummary{}
+	getChanges := func(transformerName string) *transformertypes.DryRunChanges {
+		if _, ok := summary[transformerName]; !ok {
+			summary[transformerName] = &transformertypes.DryRunChanges{}
+		}
+		return summary[transformerName]
+	}
+	existingFiles, err := getRelativeFilePaths(outputPath)
+	if err != nil {
+		return summary, err
+	}
+	resultFiles, err := getRelativeFilePaths(scratchOutputPath)
+	if err != nil {
+		return summary, err
+	}
+	manifest := getProvenanceManifest(pathMappings, sourceDir, scratchOutputPath)
+	for resultFile := range resultFiles {
+		fileProvenances, ok := manifest.Files[resultFile]
+		if existingFiles[resultFile] {
+			if ok {
+				changes := getChanges(fileProvenances[len(fileProvenances)-1].TransformerName)
+				changes.Overwritten = append(changes.Overwritten, resultFile)
+			}
+			continue
+		}
+		transformerName := transformertypes.UnattributedOutputGroupName
+		if ok {
+			transformerName = fileProvenances[len(fileProvenances)-1].TransformerName
+		}
+		changes := getChanges(transformerName)
+		changes.Created = append(changes.Created, resultFile)
+	}
+	for existingFile := range existingFiles {
+		if resultFiles[existingFile] {
+			continue
+		}
+		transformerName := transformertypes.PreviousOutputGroupName
+		for _, pm := range pathMappings {
+			if !strings.EqualFold(string(pm.Type), string(transformertypes.DeletePathMappingType)) || pm.Provenance == nil {
+				continue
+			}
+			destPath := pm.DestPath
+			if filepath.IsAbs(destPath) {
+				relDestPath, err := filepath.Rel(scratchOutputPath, destPath)
+				if err != nil {
+					continue
+				}
+				destPath = relDestPath
+			}
+			destPath = filepath.Clean(destPath)
+			if existingFile == destPath || strings.HasPrefix(existingFile, destPath+string(os.PathSeparator)) {
+				transformerName = pm.Provenance.TransformerName
+			}
+		}
+		changes := getChanges(transformerName)
+		changes.Deleted = append(changes.Deleted, existingFile)
+	}
+	pathMappingsByTransformer := map[string][]transformertypes.PathMapping{}
+	for _, pm := range pathMappings {
+		if pm.Provenance == nil {
+			continue
+		}
+		pathMappingsByTransformer[pm.Provenance.TransformerName] = append(pathMappingsByTransformer[pm.Provenance.TransformerName], pm)
+	}
+	for transformerName, pms := range pathMappingsByTransformer {
+		getChanges(transformerName).PathMappings = summarizePathMappings(pms)
+	}
+	for _, changes := range summary {
+		sort.Strings(changes.Created)
+		sort.Strings(changes.Overwritten)
+		sort.Strings(changes.Deleted)
+	}
+	return summary, nil
+}
+
+// getRelativeFilePaths returns the paths of all the files in the directory, relative to it
+func getRelativeFilePaths(dir string) (map[string]bool, error) {
+	relPaths := map[string]bool{}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return relPaths, nil
+	}
+	if err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		relPaths[relPath] = true
+		return nil
+	}); err != nil {
+		return relPaths, fmt.Errorf("failed to walk the directory '%s' . Error: %w", dir, err)
+	}
+	return relPaths, nil
+}
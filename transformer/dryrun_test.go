@@ -0,0 +1,87 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/konveyor/move2kube/common"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+)
+
+func TestGetDryRunSummary(t *testing.T) {
+	sourcePath := "./testdata"
+	outputPath := t.TempDir()
+	for _, existingFile := range []string{filepath.Join("yamls", "deployment.yaml"), "old.txt"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(outputPath, existingFile)), common.DefaultDirectoryPermission); err != nil {
+			t.Fatalf("Error: %q", err)
+		}
+		if err := os.WriteFile(filepath.Join(outputPath, existingFile), []byte("old"), common.DefaultFilePermission); err != nil {
+			t.Fatalf("Error: %q", err)
+		}
+	}
+	pms := []transformertypes.PathMapping{{
+		Type:       transformertypes.DefaultPathMappingType,
+		SrcPath:    filepath.Join(sourcePath, "k8s-yamls"),
+		DestPath:   "yamls",
+		Provenance: &transformertypes.PathMappingProvenance{TransformerName: "Kubernetes"},
+	}, {
+		Type:       transformertypes.SourcePathMappingType,
+		SrcPath:    "src",
+		DestPath:   "source",
+		Provenance: &transformertypes.PathMappingProvenance{TransformerName: "Nodejs-Dockerfile"},
+	}}
+	common.TempPath = t.TempDir()
+	dryRunOutput, err := NewDryRunOutput(outputPath)
+	if err != nil {
+		t.Fatalf("failed to copy the output directory. Error: %q", err)
+	}
+	defer dryRunOutput.Remove()
+	if err := newIncrementalPathMappingProcessor(sourcePath, dryRunOutput.ScratchPath).process(pms); err != nil {
+		t.Fatalf("failed to process the path mappings. Error: %q", err)
+	}
+	summary, err := getDryRunSummary(pms, sourcePath, outputPath, dryRunOutput.ScratchPath)
+	if err != nil {
+		t.Fatalf("failed to get the dry run summary. Error: %q", err)
+	}
+	want := transformertypes.DryRunSummary{
+		"Kubernetes": {
+			Overwritten:  []string{filepath.Join("yamls", "deployment.yaml")},
+			PathMappings: summarizePathMappings(pms[:1]),
+		},
+		"Nodejs-Dockerfile": {
+			Created: []string{
+				filepath.Join("source", "index.js"),
+				filepath.Join("source", "package-lock.json"),
+				filepath.Join("source", "package.json"),
+			},
+			PathMappings: summarizePathMappings(pms[1:]),
+		},
+		transformertypes.PreviousOutputGroupName: {
+			Deleted: []string{"old.txt"},
+		},
+	}
+	if diff := cmp.Diff(want, summary); diff != "" {
+		t.Fatalf("wrong dry run summary. Differences: %s", diff)
+	}
+	if _, err := os.Stat(filepath.Join(outputPath, "source")); !os.IsNotExist(err) {
+		t.Fatalf("expected the output directory to not be modified. Error: %q", err)
+	}
+}
@@ -17,6 +17,7 @@
 package external
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -111,6 +112,11 @@ func (t *Executable) GetConfig() (transformertypes.Transformer, *environment.Env
 
 // DirectoryDetect runs detect in each sub directory
 func (t *Executable) DirectoryDetect(dir string) (services map[string][]transformertypes.Artifact, err error) {
+	return t.DirectoryDetectWithContext(context.Background(), dir)
+}
+
+// DirectoryDetectWithContext runs detect in each sub directory and kills the detect command when the context is done
+func (t *Executable) DirectoryDetectWithContext(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error) {
 	if t.ExecConfig.DirectoryDetectCMD == nil {
 		return nil, nil
 	}
@@ -124,6 +130,7 @@ func (t *Executable) DirectoryDetect(dir string) (services map[string][]transfor
 		containerDetectOutputPath = env.Value
 	}
 	services, err = t.executeDetect(
+		ctx,
 		containerDetectInputPath,
 		containerDetectOutputPath,
 	)
@@ -146,6 +153,11 @@ func (t *Executable) DirectoryDetect(dir string) (services map[string][]transfor
 
 // Transform transforms the artifacts
 func (t *Executable) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	return t.TransformWithContext(context.Background(), newArtifacts, alreadySeenArtifacts)
+}
+
+// TransformWithContext transforms the artifacts and kills the transform command when the context is done
+func (t *Executable) TransformWithContext(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	createdArtifacts := []transformertypes.Artifact{}
 	if t.ExecConfig.TransformCMD == nil {
@@ -174,7 +186,7 @@ func (t *Executable) Transform(newArtifacts []transformertypes.Artifact, already
 			transformOutputPathEnvKey: transformOutputPath,
 		},
 	)
-	stdout, stderr, exitcode, err := t.Env.Exec(cmdToRun, envList)
+	stdout, stderr, exitcode, err := t.Env.ExecWithContext(ctx, cmdToRun, envList)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to run the transform.\nstdout: %s\nstderr: %s\nexit code: %d . Error: %w", stdout, stderr, exitcode, err)
 	}
@@ -198,6 +210,7 @@ func (t *Executable) Transform(newArtifacts []transformertypes.Artifact, already
 }
 
 func (t *Executable) executeDetect(
+	ctx context.Context,
 	inputPath string,
 	outputPath string,
 ) (services map[string][]transformertypes.Artifact, err error) {
@@ -208,7 +221,7 @@ func (t *Executable) executeDetect(
 			detectOutputPathEnvKey: outputPath,
 		},
 	)
-	stdout, stderr, exitcode, err := t.Env.Exec(cmdToRun, envList)
+	stdout, stderr, exitcode, err := t.Env.ExecWithContext(ctx, cmdToRun, envList)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute the command in the environment.\nstdout: %s\nstderr: %s\nexit code: %d\nError: %w", stdout, stderr, exitcode, err)
 	} else if exitcode != 0 {
@@ -0,0 +1,321 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	"github.com/konveyor/move2kube/qaengine/questionreceivers"
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+	"github.com/konveyor/move2kube/types/transformer/plugingrpc"
+	"github.com/phayes/freeport"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	core "k8s.io/kubernetes/pkg/apis/core"
+)
+
+const (
+	// pluginAddressEnvKey is the environment variable that tells the plugin which address to listen on
+	pluginAddressEnvKey = "M2K_PLUGIN_ADDRESS"
+	// defaultPluginStartupTimeout is the time to wait for the plugin to start listening
+	defaultPluginStartupTimeout = 30 * time.Second
+	// pluginShutdownTimeout is the time to wait for the plugin to shut down gracefully
+	pluginShutdownTimeout = 5 * time.Second
+)
+
+// GRPCPlugin implements transformer interface and talks to a long running plugin over grpc
+type GRPCPlugin struct {
+	Config       transformertypes.Transformer
+	Env          *environment.Environment
+	PluginConfig *GRPCPluginYamlConfig
+	conn         *grpc.ClientConn
+	client       plugingrpc.TransformerPluginClient
+	// qaReceiverStarted is true when the transformer started the QA GRPC receiver
+	qaReceiverStarted bool
+	// stopPluginCommand kills the plugin process started by the transformer
+	stopPluginCommand context.CancelFunc
+	// pluginExited is closed when the plugin process started by the transformer exits
+	pluginExited chan struct{}
+	// pluginErr is the reason the plugin process exited. It is set before pluginExited is closed.
+	pluginErr error
+}
+
+// GRPCPluginYamlConfig is the format of grpc plugin yaml config
+type GRPCPluginYamlConfig struct {
+	EnableQA bool `yaml:"enableQA"`
+	// Command starts the plugin in the environment of the transformer. The plugin must listen on the address in the M2K_PLUGIN_ADDRESS environment variable.
+	Command environmenttypes.Command `yaml:"command,omitempty"`
+	EnvList []core.EnvVar            `yaml:"env,omitempty"`
+	// Platforms and Container configure the environment that the command runs in, like they do for the Executable transformer
+	Platforms []string                   `yaml:"platforms,omitempty"`
+	Container environmenttypes.Container `yaml:"container,omitempty"`
+	// Address of an already running plugin. Used instead of starting the plugin.
+	Address        string `yaml:"address,omitempty"`
+	StartupTimeout string `yaml:"startupTimeout,omitempty"`
+}
+
+// Init starts the plugin, connects to it and initializes it
+func (t *GRPCPlugin) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+	t.Config = tc
+	t.Env = env
+	t.PluginConfig = &GRPCPluginYamlConfig{}
+	if err := common.GetObjFromInterface(t.Config.Spec.Config, t.PluginConfig); err != nil {
+		return fmt.Errorf("unable to load config for Transformer %+v into %T . Error: %w", t.Config.Spec.Config, t.PluginConfig, err)
+	}
+	if len(t.PluginConfig.Command) == 0 && t.PluginConfig.Address == "" {
+		return fmt.Errorf("the transformer '%s' must specify either a command to start the plugin or the address of a running plugin", t.Config.Name)
+	}
+	startupTimeout := defaultPluginStartupTimeout
+	if t.PluginConfig.StartupTimeout != "" {
+		var err error
+		if startupTimeout, err = time.ParseDuration(t.PluginConfig.StartupTimeout); err != nil {
+			return fmt.Errorf("failed to parse the startup timeout '%s' as a duration. Error: %w", t.PluginConfig.StartupTimeout, err)
+		}
+	}
+	var qaRPCReceiverAddr net.Addr
+	if t.PluginConfig.EnableQA {
+		var err error
+		qaRPCReceiverAddr, err = questionreceivers.StartGRPCReceiver()
+		if err != nil {
+			logrus.Errorf("failed to start the QA GRPC Receiver engine. Error: %q", err)
+			logrus.Infof("Starting transformer that requires QA without QA.")
+		} else {
+			t.qaReceiverStarted = true
+		}
+	}
+	address := t.PluginConfig.Address
+	if address == "" {
+		envInfo := env.EnvInfo
+		if t.PluginConfig.Container.Image != "" || len(t.PluginConfig.Platforms) != 0 {
+			envInfo.EnvPlatformConfig = environmenttypes.EnvPlatformConfig{
+				Container: t.PluginConfig.Container,
+				Platforms: t.PluginConfig.Platforms,
+			}
+		}
+		// the plugin keeps running across invocations, so its environment must not be recreated when it is reset
+		envInfo.KeepAlive = true
+		pluginEnv, err := environment.NewEnvironment(envInfo, qaRPCReceiverAddr)
+		if err != nil {
+			t.Destroy()
+			return fmt.Errorf("failed to create the environment for the plugin of the transformer '%s' . Error: %w", t.Config.Name, err)
+		}
+		t.Env = pluginEnv
+		if address, err = t.startPlugin(); err != nil {
+			t.destroyAfterInitFailure(env)
+			return fmt.Errorf("failed to start the plugin for the transformer '%s' . Error: %w", t.Config.Name, err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+	if t.pluginExited != nil {
+		// stop waiting for the plugin to start listening if it exits
+		go func(pluginExited <-chan struct{}) {
+			select {
+			case <-pluginExited:
+				cancel()
+			case <-ctx.Done():
+			}
+		}(t.pluginExited)
+	}
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		if t.pluginExited != nil {
+			select {
+			case <-t.pluginExited:
+				err = t.pluginErr
+			default:
+			}
+		}
+		t.destroyAfterInitFailure(env)
+		return fmt.Errorf("failed to connect to the plugin at the address '%s' within %s . Error: %w", address, startupTimeout, err)
+	}
+	t.conn = conn
+	t.client = plugingrpc.NewTransformerPluginClient(conn)
+	transformerConfig, err := json.Marshal(t.Config)
+	if err != nil {
+		t.destroyAfterInitFailure(env)
+		return fmt.Errorf("failed to encode the transformer config as json. Error: %w", err)
+	}
+	req := &plugingrpc.InitRequest{
+		TransformerConfig: transformerConfig,
+		SourcePath:        t.Env.GetEnvironmentSource(),
+		OutputPath:        t.Env.GetEnvironmentOutput(),
+		ContextPath:       t.Env.GetEnvironmentContext(),
+		ProjectName:       t.Env.GetProjectName(),
+	}
+	if qaRPCReceiverAddr != nil {
+		req.QaEngineAddress = qaRPCReceiverAddr.String()
+	}
+	if _, err := t.client.Init(ctx, req); err != nil {
+		t.destroyAfterInitFailure(env)
+		return fmt.Errorf("the plugin failed to initialize. Error: %w", err)
+	}
+	return nil
+}
+
+// destroyAfterInitFailure stops the plugin and destroys its environment, since the transformer is not used when Init fails
+func (t *GRPCPlugin) destroyAfterInitFailure(env *environment.Environment) {
+	t.Destroy()
+	if t.Env != env {
+		if err := t.Env.Destroy(); err != nil {
+			logrus.Debugf("failed to destroy the environment of the plugin for the transformer '%s' . Error: %q", t.Config.Name, err)
+		}
+		t.Env = env
+	}
+}
+
+// GetConfig returns the transformer config
+func (t *GRPCPlugin) GetConfig() (transformertypes.Transformer, *environment.Environment) {
+	return t.Config, t.Env
+}
+
+// DirectoryDetect asks the plugin to detect services in the directory
+func (t *GRPCPlugin) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+	return t.DirectoryDetectWithContext(context.Background(), dir)
+}
+
+// DirectoryDetectWithContext asks the plugin to detect services in the directory and cancels the call when the context is done
+func (t *GRPCPlugin) DirectoryDetectWithContext(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
+	resp, err := t.client.DirectoryDetect(ctx, &plugingrpc.DirectoryDetectRequest{Directory: dir})
+	if err != nil {
+		return nil, fmt.Errorf("the plugin failed to detect services in the directory '%s' . Error: %w", dir, err)
+	}
+	if len(resp.Services) == 0 {
+		return nil, nil
+	}
+	services := map[string][]transformertypes.Artifact{}
+	if err := json.Unmarshal(resp.Services, &services); err != nil {
+		return nil, fmt.Errorf("failed to decode the services returned by the plugin as json. Error: %w", err)
+	}
+	for sn, ns := range services {
+		for nsi, nst := range ns {
+			if len(nst.Paths) == 0 {
+				nst.Paths = map[transformertypes.PathType][]string{
+					artifacts.ServiceDirPathType: {dir},
+				}
+				ns[nsi] = nst
+			}
+		}
+		services[sn] = ns
+	}
+	return services, nil
+}
+
+// Transform asks the plugin to transform the artifacts
+func (t *GRPCPlugin) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	return t.TransformWithContext(context.Background(), newArtifacts, alreadySeenArtifacts)
+}
+
+// TransformWithContext asks the plugin to transform the artifacts and cancels the call when the context is done
+func (t *GRPCPlugin) TransformWithContext(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	transformInput, err := json.Marshal(transformertypes.TransformInput{NewArtifacts: newArtifacts, AlreadySeenArtifacts: alreadySeenArtifacts})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode the transform input as json. Error: %w", err)
+	}
+	resp, err := t.client.Transform(ctx, &plugingrpc.TransformRequest{TransformInput: transformInput})
+	if err != nil {
+		return nil, nil, fmt.Errorf("the plugin failed to transform the artifacts. Error: %w", err)
+	}
+	output := transformertypes.TransformOutput{}
+	if len(resp.TransformOutput) != 0 {
+		if err := json.Unmarshal(resp.TransformOutput, &output); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode the transform output returned by the plugin as json. Error: %w", err)
+		}
+	}
+	return output.PathMappings, output.CreatedArtifacts, nil
+}
+
+// Destroy asks the plugin to shut down and stops it
+func (t *GRPCPlugin) Destroy() error {
+	if t.conn != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), pluginShutdownTimeout)
+		defer cancel()
+		if _, err := t.client.Shutdown(ctx, &plugingrpc.ShutdownRequest{}); err != nil {
+			logrus.Debugf("the plugin for the transformer '%s' failed to shut down gracefully. Error: %q", t.Config.Name, err)
+		}
+		if err := t.conn.Close(); err != nil {
+			logrus.Debugf("failed to close the connection to the plugin for the transformer '%s' . Error: %q", t.Config.Name, err)
+		}
+		t.conn = nil
+	}
+	t.stopPlugin()
+	if t.qaReceiverStarted {
+		questionreceivers.StopGRPCReceiver()
+		t.qaReceiverStarted = false
+	}
+	return nil
+}
+
+// startPlugin runs the plugin command in the environment of the transformer and returns the address it should listen on
+func (t *GRPCPlugin) startPlugin() (string, error) {
+	host, err := t.Env.GetEnvironmentHost()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the address of the environment of the plugin. Error: %w", err)
+	}
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		return "", fmt.Errorf("failed to find a free port for the plugin. Error: %w", err)
+	}
+	address := net.JoinHostPort(host, cast.ToString(port))
+	envList := []string{}
+	for _, env := range t.PluginConfig.EnvList {
+		envList = append(envList, env.Name+envDelimiter+env.Value)
+	}
+	envList = append(envList, pluginAddressEnvKey+envDelimiter+address)
+	ctx, cancel := context.WithCancel(context.Background())
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		stdout, stderr, exitcode, err := t.Env.ExecWithContext(ctx, t.PluginConfig.Command, envList)
+		if err != nil {
+			t.pluginErr = fmt.Errorf("failed to run the command %+v . Error: %w", t.PluginConfig.Command, err)
+		} else {
+			t.pluginErr = fmt.Errorf("the command %+v exited with the code %d", t.PluginConfig.Command, exitcode)
+		}
+		logrus.Debugf("the plugin for the transformer '%s' exited. stdout: %s\nstderr: %s", t.Config.Name, stdout, stderr)
+	}()
+	t.stopPluginCommand = cancel
+	t.pluginExited = exited
+	logrus.Debugf("started the plugin for the transformer '%s' listening on %s", t.Config.Name, address)
+	return address, nil
+}
+
+// stopPlugin waits for the plugin process started by the transformer to exit and kills it if it does not
+func (t *GRPCPlugin) stopPlugin() {
+	if t.pluginExited == nil {
+		return
+	}
+	select {
+	case <-t.pluginExited:
+	case <-time.After(pluginShutdownTimeout):
+		logrus.Debugf("killing the plugin for the transformer '%s' since it did not exit within %s", t.Config.Name, pluginShutdownTimeout)
+	}
+	t.stopPluginCommand()
+	<-t.pluginExited
+	t.stopPluginCommand = nil
+	t.pluginExited = nil
+}
@@ -0,0 +1,204 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+	"github.com/konveyor/move2kube/types/transformer/plugingrpc"
+	"google.golang.org/grpc"
+)
+
+type fakePlugin struct {
+	plugingrpc.UnimplementedTransformerPluginServer
+	initReq  *plugingrpc.InitRequest
+	shutdown bool
+}
+
+func (p *fakePlugin) Init(ctx context.Context, req *plugingrpc.InitRequest) (*plugingrpc.InitResponse, error) {
+	p.initReq = req
+	return &plugingrpc.InitResponse{}, nil
+}
+
+func (p *fakePlugin) DirectoryDetect(ctx context.Context, req *plugingrpc.DirectoryDetectRequest) (*plugingrpc.DirectoryDetectResponse, error) {
+	services, err := json.Marshal(map[string][]transformertypes.Artifact{
+		"svc1": {{Name: "svc1", Type: "Service", Paths: map[transformertypes.PathType][]string{"ServiceDirPath": {req.Directory}}}},
+		"svc2": {{Name: "svc2", Type: "Service"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plugingrpc.DirectoryDetectResponse{Services: services}, nil
+}
+
+func (p *fakePlugin) Transform(ctx context.Context, req *plugingrpc.TransformRequest) (*plugingrpc.TransformResponse, error) {
+	input := transformertypes.TransformInput{}
+	if err := json.Unmarshal(req.TransformInput, &input); err != nil {
+		return nil, err
+	}
+	output := transformertypes.TransformOutput{}
+	for _, a := range input.NewArtifacts {
+		output.CreatedArtifacts = append(output.CreatedArtifacts, transformertypes.Artifact{Name: a.Name, Type: "Transformed"})
+		output.PathMappings = append(output.PathMappings, transformertypes.PathMapping{Type: transformertypes.DefaultPathMappingType, SrcPath: "src", DestPath: a.Name})
+	}
+	transformOutput, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+	return &plugingrpc.TransformResponse{TransformOutput: transformOutput}, nil
+}
+
+func (p *fakePlugin) Shutdown(ctx context.Context, req *plugingrpc.ShutdownRequest) (*plugingrpc.ShutdownResponse, error) {
+	p.shutdown = true
+	return &plugingrpc.ShutdownResponse{}, nil
+}
+
+func TestGRPCPlugin(t *testing.T) {
+	common.TempPath = t.TempDir()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen. Error: %q", err)
+	}
+	server := grpc.NewServer()
+	plugin := &fakePlugin{}
+	plugingrpc.RegisterTransformerPluginServer(server, plugin)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	sourceDir := t.TempDir()
+	env, err := environment.NewEnvironment(environment.EnvInfo{
+		Name:              "test",
+		ProjectName:       "myproject",
+		Source:            sourceDir,
+		Context:           t.TempDir(),
+		EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create the environment. Error: %q", err)
+	}
+	tc := transformertypes.Transformer{}
+	tc.Name = "test-plugin"
+	tc.Spec.Class = "GRPCPlugin"
+	tc.Spec.Config = map[string]interface{}{"address": listener.Addr().String()}
+
+	tr := &GRPCPlugin{}
+	if err := tr.Init(tc, env); err != nil {
+		t.Fatalf("failed to initialize the transformer. Error: %q", err)
+	}
+	if plugin.initReq == nil {
+		t.Fatalf("the plugin was not initialized")
+	}
+	if plugin.initReq.ProjectName != "myproject" {
+		t.Fatalf("expected the project name 'myproject'. Actual: '%s'", plugin.initReq.ProjectName)
+	}
+	initConfig := transformertypes.Transformer{}
+	if err := json.Unmarshal(plugin.initReq.TransformerConfig, &initConfig); err != nil {
+		t.Fatalf("failed to decode the transformer config sent to the plugin. Error: %q", err)
+	}
+	if initConfig.Name != tc.Name {
+		t.Fatalf("expected the transformer name '%s'. Actual: '%s'", tc.Name, initConfig.Name)
+	}
+
+	services, err := tr.DirectoryDetect(sourceDir)
+	if err != nil {
+		t.Fatalf("failed to detect services. Error: %q", err)
+	}
+	if len(services["svc1"]) != 1 || services["svc1"][0].Paths["ServiceDirPath"][0] != sourceDir {
+		t.Fatalf("unexpected services detected: %+v", services)
+	}
+	if len(services["svc2"]) != 1 || len(services["svc2"][0].Paths[artifacts.ServiceDirPathType]) != 1 || services["svc2"][0].Paths[artifacts.ServiceDirPathType][0] != sourceDir {
+		t.Fatalf("expected the service without paths to get the detected directory as its service directory. Actual: %+v", services["svc2"])
+	}
+
+	pathMappings, artifacts, err := tr.Transform([]transformertypes.Artifact{{Name: "a1"}, {Name: "a2"}}, nil)
+	if err != nil {
+		t.Fatalf("failed to transform. Error: %q", err)
+	}
+	if len(pathMappings) != 2 || len(artifacts) != 2 || artifacts[1].Name != "a2" || artifacts[1].Type != "Transformed" {
+		t.Fatalf("unexpected transform output. Path mappings: %+v Artifacts: %+v", pathMappings, artifacts)
+	}
+
+	if err := tr.Destroy(); err != nil {
+		t.Fatalf("failed to destroy the transformer. Error: %q", err)
+	}
+	if !plugin.shutdown {
+		t.Fatalf("the plugin was not shut down")
+	}
+}
+
+func TestGRPCPluginRequiresCommandOrAddress(t *testing.T) {
+	tc := transformertypes.Transformer{}
+	tc.Name = "test-plugin"
+	tc.Spec.Config = map[string]interface{}{}
+	if err := (&GRPCPlugin{}).Init(tc, nil); err == nil {
+		t.Fatalf("expected an error when neither a command nor an address is specified")
+	}
+}
+
+func TestGRPCPluginCommandFails(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test uses the false command")
+	}
+	testCases := []struct {
+		name                  string
+		disableLocalExecution bool
+		expectedError         string
+	}{
+		{name: "local execution is disabled", disableLocalExecution: true, expectedError: common.DisableLocalExecutionFlag},
+		{name: "plugin exits", expectedError: "exited with the code 1"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			common.TempPath = t.TempDir()
+			common.DisableLocalExecution = testCase.disableLocalExecution
+			defer func() { common.DisableLocalExecution = false }()
+			env, err := environment.NewEnvironment(environment.EnvInfo{
+				Name:              "test",
+				ProjectName:       "myproject",
+				Source:            t.TempDir(),
+				Context:           t.TempDir(),
+				EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+			}, nil)
+			if err != nil {
+				t.Fatalf("failed to create the environment. Error: %q", err)
+			}
+			tc := transformertypes.Transformer{}
+			tc.Name = "test-plugin"
+			tc.Spec.Class = "GRPCPlugin"
+			tc.Spec.Config = map[string]interface{}{"command": []string{"false"}, "startupTimeout": "20s"}
+			startTime := time.Now()
+			err = (&GRPCPlugin{}).Init(tc, env)
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Fatalf("expected the initialization to fail with the error '%s' . Actual: %q", testCase.expectedError, err)
+			}
+			if duration := time.Since(startTime); duration > 10*time.Second {
+				t.Fatalf("expected the initialization to fail without waiting for the startup timeout. Actual duration: %s", duration)
+			}
+		})
+	}
+}
@@ -0,0 +1,433 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package external
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// rulesCurrentDir is the path used in detect rules to refer to the directory being detected
+	rulesCurrentDir = "."
+)
+
+// Rules implements transformer interface and is used to write declarative transformers without code
+type Rules struct {
+	Config      transformertypes.Transformer
+	Env         *environment.Environment
+	RulesConfig *RulesYamlConfig
+}
+
+// RulesYamlConfig is the format of rules yaml config
+type RulesYamlConfig struct {
+	DetectRules    []RulesDetectRule    `yaml:"detect,omitempty"`
+	TransformRules []RulesTransformRule `yaml:"transform,omitempty"`
+}
+
+// RulesDetectRule creates an artifact for a directory when the directory contains matching files
+type RulesDetectRule struct {
+	// Files are globs relative to the directory. The rule matches if any of them matches a file.
+	Files []string `yaml:"files"`
+	// ContentRegex when specified has to match the contents of at least one of the matched files
+	ContentRegex string `yaml:"contentRegex,omitempty"`
+	// ServiceName is a template for the name of the service. Defaults to the name of the directory.
+	ServiceName  string                                                      `yaml:"serviceName,omitempty"`
+	ArtifactType transformertypes.ArtifactType                               `yaml:"artifactType,omitempty"`
+	Paths        map[transformertypes.PathType][]string                      `yaml:"paths,omitempty"`
+	Configs      map[transformertypes.ConfigType]map[string]RulesConfigValue `yaml:"configs,omitempty"`
+}
+
+// RulesConfigValue is either a static value or a value extracted from a file in the directory
+type RulesConfigValue struct {
+	Value interface{} `yaml:"value,omitempty"`
+	// File is a glob relative to the directory. The first matching file is used.
+	File string `yaml:"file,omitempty"`
+	// Path is a dot separated path into a JSON or YAML file. Example: dependencies.express or spec.ports[0].port
+	Path string `yaml:"path,omitempty"`
+	// Regex is matched against the contents of the file and the first capture group is used
+	Regex string `yaml:"regex,omitempty"`
+}
+
+// RulesTransformRule creates path mappings and artifacts for each matching input artifact
+type RulesTransformRule struct {
+	// ArtifactTypes are the types of the input artifacts this rule applies to. Empty matches all the artifacts.
+	ArtifactTypes []transformertypes.ArtifactType `yaml:"artifactTypes,omitempty"`
+	Templates     []RulesTemplate                 `yaml:"templates,omitempty"`
+	Artifacts     []RulesArtifact                 `yaml:"artifacts,omitempty"`
+}
+
+// RulesTemplate maps a file or directory in the templates directory to a path in the output
+type RulesTemplate struct {
+	// Src is relative to the templates directory of the transformer
+	Src string `yaml:"src"`
+	// Dest is a template for the destination path relative to the output directory
+	Dest string `yaml:"dest"`
+}
+
+// RulesArtifact is an artifact created by a transform rule.
+// The paths and configs of the input artifact are carried over unless overridden.
+type RulesArtifact struct {
+	// Name is a template for the name of the artifact. Defaults to the name of the input artifact.
+	Name    string                                      `yaml:"name,omitempty"`
+	Type    transformertypes.ArtifactType               `yaml:"type"`
+	Paths   map[transformertypes.PathType][]string      `yaml:"paths,omitempty"`
+	Configs map[transformertypes.ConfigType]interface{} `yaml:"configs,omitempty"`
+}
+
+// rulesDetectTemplateData is the data available to the templates in detect rules
+type rulesDetectTemplateData struct {
+	Dir     string
+	DirName string
+	Files   []string
+	Configs map[transformertypes.ConfigType]map[string]interface{}
+}
+
+// rulesTransformTemplateData is the data available to the templates in transform rules
+type rulesTransformTemplateData struct {
+	Name       string
+	Type       transformertypes.ArtifactType
+	ServiceDir string
+	// RelServiceDir is the service directory relative to the source directory
+	RelServiceDir string
+	Paths         map[transformertypes.PathType][]string
+	Configs       map[transformertypes.ConfigType]interface{}
+}
+
+// Init Initializes the transformer
+func (t *Rules) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+	t.Config = tc
+	t.Env = env
+	t.RulesConfig = &RulesYamlConfig{}
+	if err := common.GetObjFromInterface(t.Config.Spec.Config, t.RulesConfig); err != nil {
+		return fmt.Errorf("unable to load config for Transformer %+v into %T . Error: %w", t.Config.Spec.Config, t.RulesConfig, err)
+	}
+	for i, rule := range t.RulesConfig.DetectRules {
+		if len(rule.Files) == 0 {
+			return fmt.Errorf("the detect rule %d of the transformer %s does not specify any files", i, t.Config.Name)
+		}
+		if rule.ContentRegex != "" {
+			if _, err := regexp.Compile(rule.ContentRegex); err != nil {
+				return fmt.Errorf("the detect rule %d of the transformer %s has an invalid content regex. Error: %w", i, t.Config.Name, err)
+			}
+		}
+		for configType, values := range rule.Configs {
+			for key, value := range values {
+				if value.Regex == "" {
+					continue
+				}
+				if _, err := regexp.Compile(value.Regex); err != nil {
+					return fmt.Errorf("the detect rule %d of the transformer %s has an invalid regex for the config %s.%s . Error: %w", i, t.Config.Name, configType, key, err)
+				}
+			}
+		}
+	}
+	for i, rule := range t.RulesConfig.TransformRules {
+		for _, a := range rule.Artifacts {
+			if a.Type == "" {
+				return fmt.Errorf("the transform rule %d of the transformer %s creates an artifact without a type", i, t.Config.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// GetConfig returns the transformer config
+func (t *Rules) GetConfig() (transformertypes.Transformer, *environment.Environment) {
+	return t.Config, t.Env
+}
+
+// DirectoryDetect runs detect in each sub directory
+func (t *Rules) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+	services := map[string][]transformertypes.Artifact{}
+	for i, rule := range t.RulesConfig.DetectRules {
+		matchedFiles, err := t.getMatchingFiles(dir, rule)
+		if err != nil {
+			return services, fmt.Errorf("failed to evaluate the detect rule %d of the transformer %s in the directory %s . Error: %w", i, t.Config.Name, dir, err)
+		}
+		if len(matchedFiles) == 0 {
+			continue
+		}
+		data := rulesDetectTemplateData{
+			Dir:     dir,
+			DirName: filepath.Base(dir),
+			Files:   matchedFiles,
+			Configs: map[transformertypes.ConfigType]map[string]interface{}{},
+		}
+		for configType, values := range rule.Configs {
+			config := map[string]interface{}{}
+			for key, value := range values {
+				v, err := getRulesConfigValue(dir, value)
+				if err != nil {
+					logrus.Debugf("failed to get the value of the config %s.%s for the transformer %s in the directory %s . Error: %q", configType, key, t.Config.Name, dir, err)
+					continue
+				}
+				config[key] = v
+			}
+			data.Configs[configType] = config
+		}
+		originalServiceName := data.DirName
+		if rule.ServiceName != "" {
+			originalServiceName, err = common.GetStringFromTemplate(rule.ServiceName, data)
+			if err != nil {
+				return services, fmt.Errorf("failed to evaluate the service name template of the detect rule %d of the transformer %s . Error: %w", i, t.Config.Name, err)
+			}
+			originalServiceName = strings.TrimSpace(originalServiceName)
+		}
+		serviceName := common.MakeStringK8sServiceNameCompliant(originalServiceName)
+		artifactType := rule.ArtifactType
+		if artifactType == "" {
+			artifactType = artifacts.ServiceArtifactType
+		}
+		artifact := transformertypes.Artifact{
+			Name:  serviceName,
+			Type:  artifactType,
+			Paths: map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {dir}},
+			Configs: map[transformertypes.ConfigType]interface{}{
+				artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: originalServiceName},
+			},
+		}
+		for pathType, patterns := range rule.Paths {
+			paths := []string{}
+			for _, pattern := range patterns {
+				if pattern == rulesCurrentDir {
+					paths = common.AppendIfNotPresent(paths, dir)
+					continue
+				}
+				matches, err := filepath.Glob(filepath.Join(dir, pattern))
+				if err != nil {
+					return services, fmt.Errorf("the path pattern '%s' of the detect rule %d of the transformer %s is invalid. Error: %w", pattern, i, t.Config.Name, err)
+				}
+				paths = common.AppendIfNotPresent(paths, matches...)
+			}
+			if len(paths) > 0 {
+				artifact.Paths[pathType] = paths
+			}
+		}
+		for configType, config := range data.Configs {
+			artifact.Configs[configType] = config
+		}
+		services[serviceName] = append(services[serviceName], artifact)
+	}
+	return services, nil
+}
+
+// Transform transforms the artifacts
+func (t *Rules) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	pathMappings := []transformertypes.PathMapping{}
+	createdArtifacts := []transformertypes.Artifact{}
+	for _, newArtifact := range newArtifacts {
+		data := t.getTransformTemplateData(newArtifact)
+		for i, rule := range t.RulesConfig.TransformRules {
+			if len(rule.ArtifactTypes) > 0 && !common.IsPresent(rule.ArtifactTypes, newArtifact.Type) {
+				continue
+			}
+			for _, tpl := range rule.Templates {
+				destPath, err := common.GetStringFromTemplate(tpl.Dest, data)
+				if err != nil {
+					return pathMappings, createdArtifacts, fmt.Errorf("failed to evaluate the destination path template of the transform rule %d of the transformer %s . Error: %w", i, t.Config.Name, err)
+				}
+				pathMappings = append(pathMappings, transformertypes.PathMapping{
+					Type:           transformertypes.TemplatePathMappingType,
+					SrcPath:        filepath.Join(t.Env.Context, t.Config.Spec.TemplatesDir, tpl.Src),
+					DestPath:       strings.TrimSpace(destPath),
+					TemplateConfig: data,
+				})
+			}
+			for _, ruleArtifact := range rule.Artifacts {
+				artifact, err := t.createArtifact(ruleArtifact, newArtifact, data)
+				if err != nil {
+					return pathMappings, createdArtifacts, fmt.Errorf("failed to create an artifact using the transform rule %d of the transformer %s . Error: %w", i, t.Config.Name, err)
+				}
+				createdArtifacts = append(createdArtifacts, artifact)
+			}
+		}
+	}
+	return pathMappings, createdArtifacts, nil
+}
+
+func (t *Rules) getMatchingFiles(dir string, rule RulesDetectRule) ([]string, error) {
+	var contentRegex *regexp.Regexp
+	if rule.ContentRegex != "" {
+		var err error
+		contentRegex, err = regexp.Compile(rule.ContentRegex)
+		if err != nil {
+			return nil, fmt.Errorf("the content regex '%s' is invalid. Error: %w", rule.ContentRegex, err)
+		}
+	}
+	matchedFiles := []string{}
+	for _, pattern := range rule.Files {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("the file pattern '%s' is invalid. Error: %w", pattern, err)
+		}
+		for _, match := range matches {
+			if finfo, err := os.Stat(match); err != nil || finfo.IsDir() {
+				continue
+			}
+			if contentRegex != nil {
+				content, err := os.ReadFile(match)
+				if err != nil {
+					logrus.Debugf("failed to read the file at path %s . Error: %q", match, err)
+					continue
+				}
+				if !contentRegex.Match(content) {
+					continue
+				}
+			}
+			relPath, err := filepath.Rel(dir, match)
+			if err != nil {
+				relPath = match
+			}
+			matchedFiles = common.AppendIfNotPresent(matchedFiles, relPath)
+		}
+	}
+	return matchedFiles, nil
+}
+
+func (t *Rules) getTransformTemplateData(a transformertypes.Artifact) rulesTransformTemplateData {
+	data := rulesTransformTemplateData{
+		Name:    a.Name,
+		Type:    a.Type,
+		Paths:   a.Paths,
+		Configs: a.Configs,
+	}
+	if len(a.Paths[artifacts.ServiceDirPathType]) != 0 {
+		data.ServiceDir = a.Paths[artifacts.ServiceDirPathType][0]
+		relServiceDir, err := filepath.Rel(t.Env.GetEnvironmentSource(), data.ServiceDir)
+		if err != nil {
+			logrus.Errorf("failed to make the service directory %s relative to the source directory. Error: %q", data.ServiceDir, err)
+		} else {
+			data.RelServiceDir = relServiceDir
+		}
+	}
+	return data
+}
+
+func (t *Rules) createArtifact(ruleArtifact RulesArtifact, inputArtifact transformertypes.Artifact, data rulesTransformTemplateData) (transformertypes.Artifact, error) {
+	artifact := transformertypes.Artifact{
+		Name:    inputArtifact.Name,
+		Type:    ruleArtifact.Type,
+		Paths:   map[transformertypes.PathType][]string{},
+		Configs: map[transformertypes.ConfigType]interface{}{},
+	}
+	if ruleArtifact.Name != "" {
+		name, err := common.GetStringFromTemplate(ruleArtifact.Name, data)
+		if err != nil {
+			return artifact, fmt.Errorf("failed to evaluate the artifact name template '%s' . Error: %w", ruleArtifact.Name, err)
+		}
+		artifact.Name = strings.TrimSpace(name)
+	}
+	for pathType, paths := range inputArtifact.Paths {
+		artifact.Paths[pathType] = paths
+	}
+	for pathType, pathTemplates := range ruleArtifact.Paths {
+		paths := []string{}
+		for _, pathTemplate := range pathTemplates {
+			path, err := common.GetStringFromTemplate(pathTemplate, data)
+			if err != nil {
+				return artifact, fmt.Errorf("failed to evaluate the path template '%s' . Error: %w", pathTemplate, err)
+			}
+			paths = append(paths, strings.TrimSpace(path))
+		}
+		artifact.Paths[pathType] = paths
+	}
+	for configType, config := range inputArtifact.Configs {
+		artifact.Configs[configType] = config
+	}
+	for configType, config := range ruleArtifact.Configs {
+		artifact.Configs[configType] = config
+	}
+	return artifact, nil
+}
+
+// getRulesConfigValue returns the static value or extracts the value from a file in the directory
+func getRulesConfigValue(dir string, value RulesConfigValue) (interface{}, error) {
+	if value.File == "" {
+		return value.Value, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, value.File))
+	if err != nil {
+		return nil, fmt.Errorf("the file pattern '%s' is invalid. Error: %w", value.File, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no file matches the pattern '%s'", value.File)
+	}
+	content, err := os.ReadFile(matches[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the file at path %s . Error: %w", matches[0], err)
+	}
+	if value.Regex != "" {
+		re, err := regexp.Compile(value.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("the regex '%s' is invalid. Error: %w", value.Regex, err)
+		}
+		submatches := re.FindSubmatch(content)
+		if submatches == nil {
+			return nil, fmt.Errorf("the regex '%s' does not match the contents of the file %s", value.Regex, matches[0])
+		}
+		if len(submatches) > 1 {
+			return string(submatches[1]), nil
+		}
+		return string(submatches[0]), nil
+	}
+	if value.Path == "" {
+		return string(content), nil
+	}
+	var obj interface{}
+	// JSON is a subset of YAML so this handles both formats
+	if err := yaml.Unmarshal(content, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse the file %s as JSON or YAML. Error: %w", matches[0], err)
+	}
+	return getValueAtRulesPath(obj, value.Path)
+}
+
+// getValueAtRulesPath returns the value at a dot separated path like spec.ports[0].port
+func getValueAtRulesPath(obj interface{}, path string) (interface{}, error) {
+	path = strings.ReplaceAll(strings.ReplaceAll(path, "[", "."), "]", "")
+	curr := obj
+	for _, key := range strings.Split(strings.Trim(path, "."), ".") {
+		switch c := curr.(type) {
+		case map[string]interface{}:
+			v, ok := c[key]
+			if !ok {
+				return nil, fmt.Errorf("the key '%s' is not present", key)
+			}
+			curr = v
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(c) {
+				return nil, fmt.Errorf("the index '%s' is invalid for a list of length %d", key, len(c))
+			}
+			curr = c[idx]
+		default:
+			return nil, fmt.Errorf("the key '%s' cannot be looked up in a value of type %T", key, curr)
+		}
+	}
+	return curr, nil
+}
@@ -0,0 +1,161 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package external
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+	"gopkg.in/yaml.v3"
+)
+
+const testRulesConfig = `
+detect:
+  - files: ["package.json"]
+    contentRegex: '"express"'
+    serviceName: '{{ index .Configs "NodeApp" "name" }}'
+    artifactType: NodeApp
+    paths:
+      PackageJSON: ["package.json"]
+    configs:
+      NodeApp:
+        name:
+          file: package.json
+          path: name
+        expressVersion:
+          file: package.json
+          path: dependencies.express
+        port:
+          file: .env
+          regex: 'PORT=(\d+)'
+        runtime:
+          value: node
+transform:
+  - artifactTypes: [NodeApp]
+    templates:
+      - src: Dockerfile
+        dest: 'source/{{ .RelServiceDir }}/Dockerfile'
+    artifacts:
+      - type: Dockerfile
+        name: '{{ .Name }}-image'
+        paths:
+          Dockerfile: ['source/{{ .RelServiceDir }}/Dockerfile']
+`
+
+func TestRules(t *testing.T) {
+	common.TempPath = t.TempDir()
+	sourceDir := t.TempDir()
+	serviceDir := filepath.Join(sourceDir, "web")
+	if err := os.MkdirAll(serviceDir, common.DefaultDirectoryPermission); err != nil {
+		t.Fatalf("failed to create the service directory. Error: %q", err)
+	}
+	packageJSON := `{"name": "My App", "dependencies": {"express": "^4.18.2"}}`
+	if err := os.WriteFile(filepath.Join(serviceDir, "package.json"), []byte(packageJSON), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write package.json . Error: %q", err)
+	}
+	if err := os.WriteFile(filepath.Join(serviceDir, ".env"), []byte("PORT=8080\n"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write .env . Error: %q", err)
+	}
+	otherDir := filepath.Join(sourceDir, "other")
+	if err := os.MkdirAll(otherDir, common.DefaultDirectoryPermission); err != nil {
+		t.Fatalf("failed to create the other directory. Error: %q", err)
+	}
+	if err := os.WriteFile(filepath.Join(otherDir, "package.json"), []byte(`{"name": "other"}`), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write package.json . Error: %q", err)
+	}
+
+	env, err := environment.NewEnvironment(environment.EnvInfo{
+		Name:              "test",
+		ProjectName:       "myproject",
+		Source:            sourceDir,
+		Context:           t.TempDir(),
+		EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create the environment. Error: %q", err)
+	}
+	var config interface{}
+	if err := yaml.Unmarshal([]byte(testRulesConfig), &config); err != nil {
+		t.Fatalf("failed to parse the rules config. Error: %q", err)
+	}
+	tc := transformertypes.Transformer{}
+	tc.Name = "test-rules"
+	tc.Spec.Class = "Rules"
+	tc.Spec.TemplatesDir = "templates"
+	tc.Spec.Config = config
+
+	tr := &Rules{}
+	if err := tr.Init(tc, env); err != nil {
+		t.Fatalf("failed to initialize the transformer. Error: %q", err)
+	}
+
+	services, err := tr.DirectoryDetect(otherDir)
+	if err != nil {
+		t.Fatalf("failed to detect services. Error: %q", err)
+	}
+	if len(services) != 0 {
+		t.Fatalf("expected no services when the content regex does not match. Actual: %+v", services)
+	}
+
+	services, err = tr.DirectoryDetect(serviceDir)
+	if err != nil {
+		t.Fatalf("failed to detect services. Error: %q", err)
+	}
+	if len(services["my-app"]) != 1 {
+		t.Fatalf("expected the service 'my-app' to be detected. Actual: %+v", services)
+	}
+	a := services["my-app"][0]
+	if a.Type != "NodeApp" || a.Paths["PackageJSON"][0] != filepath.Join(serviceDir, "package.json") || a.Paths[artifacts.ServiceDirPathType][0] != serviceDir {
+		t.Fatalf("unexpected artifact detected: %+v", a)
+	}
+	nodeApp, ok := a.Configs["NodeApp"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected the config NodeApp to be present. Actual: %+v", a.Configs)
+	}
+	expectedConfig := map[string]interface{}{"name": "My App", "expressVersion": "^4.18.2", "port": "8080", "runtime": "node"}
+	for k, v := range expectedConfig {
+		if nodeApp[k] != v {
+			t.Fatalf("expected the config value %s to be %v . Actual: %v", k, v, nodeApp[k])
+		}
+	}
+
+	pathMappings, createdArtifacts, err := tr.Transform([]transformertypes.Artifact{a, {Name: "ignored", Type: "Other"}}, nil)
+	if err != nil {
+		t.Fatalf("failed to transform. Error: %q", err)
+	}
+	if len(pathMappings) != 1 || pathMappings[0].Type != transformertypes.TemplatePathMappingType ||
+		pathMappings[0].SrcPath != filepath.Join(env.Context, "templates", "Dockerfile") || pathMappings[0].DestPath != "source/web/Dockerfile" {
+		t.Fatalf("unexpected path mappings: %+v", pathMappings)
+	}
+	if len(createdArtifacts) != 1 {
+		t.Fatalf("expected one artifact to be created. Actual: %+v", createdArtifacts)
+	}
+	created := createdArtifacts[0]
+	if created.Name != "my-app-image" || created.Type != "Dockerfile" || created.Paths["Dockerfile"][0] != "source/web/Dockerfile" || created.Paths[artifacts.ServiceDirPathType][0] != serviceDir {
+		t.Fatalf("unexpected artifact created: %+v", created)
+	}
+	if _, ok := created.Configs["NodeApp"]; !ok {
+		t.Fatalf("expected the configs of the input artifact to be carried over. Actual: %+v", created.Configs)
+	}
+}
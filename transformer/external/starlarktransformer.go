@@ -17,6 +17,7 @@
 package external
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -174,13 +175,27 @@ func (t *Starlark) GetConfig() (transformertypes.Transformer, *environment.Envir
 
 // DirectoryDetect runs detect in each sub directory
 func (t *Starlark) DirectoryDetect(dir string) (services map[string][]transformertypes.Artifact, err error) {
-	return t.executeDetect(t.detectFn, dir)
+	return t.DirectoryDetectWithContext(context.Background(), dir)
+}
+
+// DirectoryDetectWithContext runs detect in each sub directory and stops the starlark script when the context is done
+func (t *Starlark) DirectoryDetectWithContext(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error) {
+	return t.executeDetect(ctx, t.detectFn, dir)
 }
 
 // Transform transforms the artifacts
 func (t *Starlark) Transform(
 	newArtifacts []transformertypes.Artifact,
 	alreadySeenArtifacts []transformertypes.Artifact,
+) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	return t.TransformWithContext(context.Background(), newArtifacts, alreadySeenArtifacts)
+}
+
+// TransformWithContext transforms the artifacts and stops the starlark script when the context is done
+func (t *Starlark) TransformWithContext(
+	ctx context.Context,
+	newArtifacts []transformertypes.Artifact,
+	alreadySeenArtifacts []transformertypes.Artifact,
 ) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	naObj, err := common.GetMapInterfaceFromObj(newArtifacts)
 	if err != nil {
@@ -198,7 +213,9 @@ func (t *Starlark) Transform(
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to marshal already seen artifacts %+v to starlark value. Error: %w", alreadySeenArtifacts, err)
 	}
-	val, err := starlark.Call(t.StarThread, t.transformFn, starlark.Tuple{starNewArtifacts, starOldArtifacts}, nil)
+	thread, done := t.newCallThread(ctx)
+	defer done()
+	val, err := starlark.Call(thread, t.transformFn, starlark.Tuple{starNewArtifacts, starOldArtifacts}, nil)
 	if err != nil {
 		switch err := err.(type) {
 		case *starlark.EvalError:
@@ -218,7 +235,7 @@ func (t *Starlark) Transform(
 	return transformOutput.PathMappings, transformOutput.CreatedArtifacts, nil
 }
 
-func (t *Starlark) executeDetect(fn *starlark.Function, dir string) (services map[string][]transformertypes.Artifact, err error) {
+func (t *Starlark) executeDetect(ctx context.Context, fn *starlark.Function, dir string) (services map[string][]transformertypes.Artifact, err error) {
 	if fn == nil {
 		return nil, nil
 	}
@@ -227,7 +244,9 @@ func (t *Starlark) executeDetect(fn *starlark.Function, dir string) (services ma
 		logrus.Errorf("Unable to convert %s to starlark value : %s", dir, err)
 		return nil, err
 	}
-	val, err := starlark.Call(t.StarThread, fn, starlark.Tuple{starDir}, nil)
+	thread, done := t.newCallThread(ctx)
+	defer done()
+	val, err := starlark.Call(thread, fn, starlark.Tuple{starDir}, nil)
 	if err != nil {
 		logrus.Errorf("Unable to execute starlark function : %s", err)
 		return nil, err
@@ -246,8 +265,23 @@ func (t *Starlark) executeDetect(fn *starlark.Function, dir string) (services ma
 	return services, nil
 }
 
+// newCallThread returns a thread for a single call of a starlark function, which is cancelled when the context is done.
+// A cancelled thread can not be used again, so every call gets a new thread. The returned function must be called after the call.
+func (t *Starlark) newCallThread(ctx context.Context) (*starlark.Thread, func()) {
+	thread := &starlark.Thread{Name: t.Config.Name}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+	return thread, func() { close(done) }
+}
+
 func (t *Starlark) getStarlarkQuery() *starlark.Builtin {
-	return starlark.NewBuiltin(qaFnName, func(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	return starlark.NewBuiltin(qaFnName, func(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 		argDictValue := &starlark.Dict{}
 		var validation string
 		if err := starlark.UnpackPositionalArgs(qaFnName, args, kwargs, 1, &argDictValue, &validation); err != nil {
@@ -268,6 +302,12 @@ func (t *Starlark) getStarlarkQuery() *starlark.Builtin {
 		if !strings.HasPrefix(prob.ID, common.BaseKey) {
 			prob.ID = common.JoinQASubKeys(common.BaseKey, prob.ID)
 		}
+		// conditions
+		for i, condition := range prob.Conditions {
+			if !strings.HasPrefix(condition.ProblemID, common.BaseKey) {
+				prob.Conditions[i].ProblemID = common.JoinQASubKeys(common.BaseKey, condition.ProblemID)
+			}
+		}
 		// type
 		if prob.Type == "" {
 			prob.Type = qatypes.InputSolutionFormType
@@ -290,7 +330,7 @@ func (t *Starlark) getStarlarkQuery() *starlark.Builtin {
 				if err != nil {
 					return fmt.Errorf("unable to convert %s to starlark value : %s", ans, err)
 				}
-				val, err := starlark.Call(t.StarThread, fn, starlark.Tuple{answer}, nil)
+				val, err := starlark.Call(thread, fn, starlark.Tuple{answer}, nil)
 				if err != nil {
 					return fmt.Errorf("unable to execute the starlark function: Error : %s", err)
 				}
@@ -0,0 +1,81 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package external
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+)
+
+const testHangingStarlarkScript = `
+def directory_detect(dir):
+    return {}
+
+def transform(new_artifacts, old_artifacts):
+    for i in range(1000000000000):
+        pass
+    return {"pathMappings": [], "createdArtifacts": []}
+`
+
+func TestStarlarkTransformIsCancelled(t *testing.T) {
+	common.TempPath = t.TempDir()
+	contextDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(contextDir, "hang.star"), []byte(testHangingStarlarkScript), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write the starlark script. Error: %q", err)
+	}
+	env, err := environment.NewEnvironment(environment.EnvInfo{
+		Name:              "test",
+		ProjectName:       "myproject",
+		Source:            t.TempDir(),
+		Context:           contextDir,
+		EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create the environment. Error: %q", err)
+	}
+	tc := transformertypes.Transformer{}
+	tc.Name = "test-starlark"
+	tc.Spec.Class = "Starlark"
+	tc.Spec.Config = map[string]interface{}{"starFile": "hang.star"}
+	tr := &Starlark{}
+	if err := tr.Init(tc, env); err != nil {
+		t.Fatalf("failed to initialize the transformer. Error: %q", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	startTime := time.Now()
+	if _, _, err := tr.TransformWithContext(ctx, nil, nil); err == nil {
+		t.Fatalf("expected the cancelled starlark script to fail")
+	}
+	if duration := time.Since(startTime); duration > 10*time.Second {
+		t.Fatalf("expected the starlark script to be stopped when the context is done. Actual duration: %s", duration)
+	}
+	// a cancelled call must not affect the next calls
+	if _, err := tr.DirectoryDetect(t.TempDir()); err != nil {
+		t.Fatalf("failed to detect after a cancelled call. Error: %q", err)
+	}
+}
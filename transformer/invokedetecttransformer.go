@@ -17,6 +17,8 @@
 package transformer
 
 import (
+	"context"
+
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/environment"
 	transformertypes "github.com/konveyor/move2kube/types/transformer"
@@ -47,6 +49,11 @@ func (t *InvokeDetect) DirectoryDetect(dir string) (map[string][]transformertype
 	return nil, nil
 }
 
+// DirectoryDetectWithContext does nothing
+func (t *InvokeDetect) DirectoryDetectWithContext(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
+	return t.DirectoryDetect(dir)
+}
+
 // Transform transforms the artifacts
 func (t *InvokeDetect) Transform(
 	inputArtifacts []transformertypes.Artifact,
@@ -55,6 +62,19 @@ func (t *InvokeDetect) Transform(
 	[]transformertypes.PathMapping,
 	[]transformertypes.Artifact,
 	error,
+) {
+	return t.TransformWithContext(context.Background(), inputArtifacts, inputOldArtifacts)
+}
+
+// TransformWithContext transforms the artifacts and stops the directory detection when the context is done
+func (t *InvokeDetect) TransformWithContext(
+	ctx context.Context,
+	inputArtifacts []transformertypes.Artifact,
+	inputOldArtifacts []transformertypes.Artifact,
+) (
+	[]transformertypes.PathMapping,
+	[]transformertypes.Artifact,
+	error,
 ) {
 	logrus.Trace("InvokeDetect.Transform start")
 	defer logrus.Trace("InvokeDetect.Transform end")
@@ -71,7 +91,7 @@ func (t *InvokeDetect) Transform(
 			logrus.Errorf("failed to load the InvokeDetect type config into struct of type %T . Error: %q", invokeDetectConfig, err)
 			continue
 		}
-		detectedServices, err := GetServices(common.DefaultProjectName, detectDir, &invokeDetectConfig.TransformerSelector)
+		detectedServices, err := GetServices(ctx, common.DefaultProjectName, detectDir, &invokeDetectConfig.TransformerSelector)
 		if err != nil {
 			logrus.Errorf("failed to invoke the directory detect. Error: %q", err)
 			continue
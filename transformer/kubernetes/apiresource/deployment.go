@@ -339,7 +339,7 @@ func (d *Deployment) createArgorollout(service irtypes.Service, cluster collectt
 	}
 
 	// prompt type of rollout
-	qaKey := common.JoinQASubKeys(common.ConfigServicesKey, common.ConfigDeploymentTypeKey, common.ConfigArgoRolloutTypeKey)
+	qaKey := common.ConfigServicesArgoRolloutTypeKey
 	desc := "Which type of Argo rollout should be generated?"
 	def := string(blueGreenRollout)
 	options := []string{string(blueGreenRollout), string(canaryRollout)}
@@ -400,10 +400,8 @@ func (d *Service) createIngress(ir irtypes.EnhancedIR, targetCluster collecttype
 	if _, ok := targetCluster.Labels[collecttypes.ClusterQaLabelKey]; ok {
 		qaLabel = targetCluster.Labels[collecttypes.ClusterQaLabelKey]
 	}
-	// QALabel prefix for cluster
-	qaId := common.JoinQASubKeys(common.ConfigTargetKey, `"`+qaLabel+`"`)
 	// Set the default ingressClass value
-	quesKeyClass := common.JoinQASubKeys(qaId, common.ConfigIngressClassNameKeySuffix)
+	quesKeyClass := fmt.Sprintf(common.ConfigTargetIngressClassNameKey, `"`+qaLabel+`"`)
 	descClass := "Provide the Ingress class name for ingress"
 	ingressClassName := qaengine.FetchStringAnswer(quesKeyClass, descClass, []string{"Leave empty to use the cluster default"}, "", nil)
 
@@ -415,7 +413,7 @@ func (d *Service) createIngress(ir irtypes.EnhancedIR, targetCluster collecttype
 	if host == "" {
 		host = commonqa.IngressHost(d.getHostName(ir.Name), qaLabel)
 	}
-	quesKeyTLS := common.JoinQASubKeys(qaId, common.ConfigIngressTLSKeySuffix)
+	quesKeyTLS := fmt.Sprintf(common.ConfigTargetIngressTLSKey, `"`+qaLabel+`"`)
 	descTLS := "Provide the TLS secret for ingress"
 	secretName = qaengine.FetchStringAnswer(quesKeyTLS, descTLS, []string{"Leave empty to use http"}, defaultSecretName, nil)
 	for hostprefix, httpIngressPaths := range hostHTTPIngressPaths {
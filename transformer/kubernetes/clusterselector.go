@@ -29,8 +29,6 @@ import (
 )
 
 const (
-	// clusterTypeKey is the key for QA ID
-	clusterTypeKey = "clustertype"
 	// defaultStorageClassName defines the default storage class to be used
 	defaultStorageClassName = "default"
 	// defaultQALabel defines the default QA label to be use
@@ -113,7 +111,7 @@ func (t *ClusterSelectorTransformer) Transform(newArtifacts []transformertypes.A
 		def = clusterTypeList[0]
 	}
 	clusterType := qaengine.FetchSelectAnswer(
-		common.JoinQASubKeys(common.ConfigTargetKey, `"`+t.CSConfig.ClusterQaLabel+`"`, clusterTypeKey),
+		fmt.Sprintf(common.ConfigTargetClusterTypeForLabelKey, `"`+t.CSConfig.ClusterQaLabel+`"`),
 		"Choose the cluster type:",
 		[]string{"Choose the cluster type you would like to target"}, def, clusterTypeList,
 		nil,
@@ -47,11 +47,11 @@ func (opt *ingressPreprocessor) preprocess(ir irtypes.IR, targetCluster collecti
 				portForwarding.ServiceType = core.ServiceTypeClusterIP
 			}
 			noneServiceType := "Don't create service"
-			portKeyPart := common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceName+`"`, `"`+cast.ToString(portForwarding.ServicePort.Number)+`"`)
+			quotedPort := `"` + cast.ToString(portForwarding.ServicePort.Number) + `"`
 			options := []string{common.IngressKind, string(core.ServiceTypeLoadBalancer), string(core.ServiceTypeNodePort), string(core.ServiceTypeClusterIP), noneServiceType}
 			desc := fmt.Sprintf("What kind of service/ingress should be created for the service %s's %d port?", serviceName, portForwarding.ServicePort.Number)
 			hints := []string{"Choose " + common.IngressKind + " if you want a ingress/route resource to be created"}
-			quesKey := common.JoinQASubKeys(portKeyPart, "servicetype")
+			quesKey := fmt.Sprintf(common.ConfigServicesServiceTypeKey, `"`+serviceName+`"`, quotedPort)
 			portForwarding.ServiceType = core.ServiceType(qaengine.FetchSelectAnswer(quesKey, desc, hints, common.IngressKind, options, nil))
 			if string(portForwarding.ServiceType) == noneServiceType {
 				portForwarding.ServiceType = ""
@@ -59,7 +59,7 @@ func (opt *ingressPreprocessor) preprocess(ir irtypes.IR, targetCluster collecti
 			if string(portForwarding.ServiceType) == common.IngressKind {
 				desc := fmt.Sprintf("Specify the ingress path to expose the service %s's %d port on?", serviceName, portForwarding.ServicePort.Number)
 				hints := []string{"Leave out leading / to use first part as subdomain"}
-				quesKey := common.JoinQASubKeys(portKeyPart, "urlpath")
+				quesKey := fmt.Sprintf(common.ConfigServicesURLPathKey, `"`+serviceName+`"`, quotedPort)
 				portForwarding.ServiceRelPath = strings.TrimSpace(qaengine.FetchStringAnswer(quesKey, desc, hints, portForwarding.ServiceRelPath, nil))
 				portForwarding.ServiceType = core.ServiceTypeClusterIP
 			} else {
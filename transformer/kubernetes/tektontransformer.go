@@ -320,7 +320,7 @@ func (t *Tekton) createGitSecret(name, gitRepoDomain string) irtypes.Storage {
 		} else {
 			problemDesc := fmt.Sprintf("Unable to find the public key for the domain %s from known_hosts, please enter it. If don't know the public key, just leave this empty and you will be able to add it later: ", gitRepoDomain)
 			hints := []string{"Ex : " + sshkeys.DomainToPublicKeys["github.com"][0]}
-			qaKey := common.JoinQASubKeys(common.ConfigRepoLoadPubDomainsKey, `"`+gitRepoDomain+`"`, "pubkey")
+			qaKey := fmt.Sprintf(common.ConfigRepoPubKeyForDomainKey, `"`+gitRepoDomain+`"`)
 			knownHosts = qaengine.FetchStringAnswer(qaKey, problemDesc, hints, knownHostsPlaceholder, nil)
 		}
 
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/filesystem"
 	transformertypes "github.com/konveyor/move2kube/types/transformer"
 	"github.com/sirupsen/logrus"
@@ -126,3 +127,204 @@ func processPathMappings(pms []transformertypes.PathMapping, sourcePath, outputP
 	}
 	return nil
 }
+
+// incrementalPathMappingProcessor applies path mappings to the output directory across iterations.
+// Only the path mappings that are new since the last call are applied.
+// The output directory is rebuilt from scratch only when a new path mapping might overwrite or delete earlier output.
+type incrementalPathMappingProcessor struct {
+	sourcePath string
+	outputPath string
+	// pathMappings contains all the path mappings that have been processed so far
+	pathMappings []transformertypes.PathMapping
+	// processed contains the keys of all the path mappings that have been processed so far
+	processed map[string]bool
+	// destPaths contains the destination paths of all the path mappings that have been processed so far
+	destPaths []string
+}
+
+func newIncrementalPathMappingProcessor(sourcePath, outputPath string) *incrementalPathMappingProcessor {
+	return &incrementalPathMappingProcessor{sourcePath: sourcePath, outputPath: outputPath, processed: map[string]bool{}}
+}
+
+// process applies the new path mappings to the output directory
+func (p *incrementalPathMappingProcessor) process(newPathMappings []transformertypes.PathMapping) error {
+	rebuild := len(p.pathMappings) == 0
+	pathMappingsToProcess := []transformertypes.PathMapping{}
+	newDestPaths := []string{}
+	for _, pm := range newPathMappings {
+		key := getPathMappingKey(pm)
+		if p.processed[key] {
+			continue
+		}
+		destPath := p.getDestPath(pm)
+		if !rebuild && (strings.EqualFold(string(pm.Type), string(transformertypes.DeletePathMappingType)) || p.overlapsWithProcessed(destPath)) {
+			logrus.Debugf("the path mapping %+v might change earlier output. Rebuilding the output directory.", pm)
+			rebuild = true
+		}
+		p.processed[key] = true
+		pathMappingsToProcess = append(pathMappingsToProcess, pm)
+		newDestPaths = append(newDestPaths, destPath)
+	}
+	p.pathMappings = append(p.pathMappings, pathMappingsToProcess...)
+	p.destPaths = append(p.destPaths, newDestPaths...)
+	if rebuild {
+		if err := os.RemoveAll(p.outputPath); err != nil {
+			return fmt.Errorf("failed to remove the output directory '%s' . Error: %w", p.outputPath, err)
+		}
+		pathMappingsToProcess = p.pathMappings
+	}
+	logrus.Debugf("processing %d out of %d path mappings", len(pathMappingsToProcess), len(p.pathMappings))
+	if err := processPathMappings(pathMappingsToProcess, p.sourcePath, p.outputPath, false); err != nil {
+		return fmt.Errorf("failed to process the path mappings: %+v . Error: %w", pathMappingsToProcess, err)
+	}
+	return nil
+}
+
+func (p *incrementalPathMappingProcessor) getDestPath(pm transformertypes.PathMapping) string {
+	if filepath.IsAbs(pm.DestPath) {
+		return filepath.Clean(pm.DestPath)
+	}
+	return filepath.Join(p.outputPath, pm.DestPath)
+}
+
+// overlapsWithProcessed checks if the destination path is the same as, inside, or a parent of, an already processed destination path
+func (p *incrementalPathMappingProcessor) overlapsWithProcessed(destPath string) bool {
+	for _, processedDestPath := range p.destPaths {
+		if destPath == processedDestPath ||
+			strings.HasPrefix(destPath, processedDestPath+string(os.PathSeparator)) ||
+			strings.HasPrefix(processedDestPath, destPath+string(os.PathSeparator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func getPathMappingKey(pm transformertypes.PathMapping) string {
+	return fmt.Sprintf("%s|%s|%s|%v", strings.ToLower(string(pm.Type)), pm.SrcPath, pm.DestPath, pm.TemplateConfig)
+}
+
+// getPathMappingProvenance returns the provenance for the path mappings produced by a transformer
+func getPathMappingProvenance(transformerName string, consumedArtifacts []transformertypes.Artifact) *transformertypes.PathMappingProvenance {
+	provenance := &transformertypes.PathMappingProvenance{TransformerName: transformerName}
+	for _, artifact := range consumedArtifacts {
+		provenance.Artifacts = append(provenance.Artifacts, transformertypes.ArtifactReference{Name: artifact.Name, Type: artifact.Type})
+	}
+	return provenance
+}
+
+// getProvenanceManifest returns the provenance of the files in the output directory.
+// The path mappings are walked in the same order that processPathMappings applies them.
+func getProvenanceManifest(pms []transformertypes.PathMapping, sourcePath, outputPath string) transformertypes.ProvenanceManifest {
+	manifest := transformertypes.ProvenanceManifest{Files: map[string][]transformertypes.FileProvenance{}}
+	copiedSourceDests := map[pair]bool{}
+	for _, pm := range pms {
+		if !strings.EqualFold(string(pm.Type), string(transformertypes.SourcePathMappingType)) || copiedSourceDests[getpair(pm.SrcPath, pm.DestPath)] {
+			continue
+		}
+		srcPath := pm.SrcPath
+		if !filepath.IsAbs(pm.SrcPath) {
+			srcPath = filepath.Join(sourcePath, pm.SrcPath)
+		}
+		addFileProvenance(manifest, pm, srcPath, filepath.Join(outputPath, pm.DestPath), sourcePath, outputPath)
+		copiedSourceDests[getpair(pm.SrcPath, pm.DestPath)] = true
+	}
+	copiedDefaultDests := map[pair]bool{}
+	for _, pm := range pms {
+		destPath := pm.DestPath
+		if !filepath.IsAbs(pm.DestPath) {
+			destPath = filepath.Join(outputPath, pm.DestPath)
+		}
+		switch strings.ToLower(string(pm.Type)) {
+		case strings.ToLower(string(transformertypes.SourcePathMappingType)): // skip sources
+		case strings.ToLower(string(transformertypes.DeletePathMappingType)): // skip deletes
+		case strings.ToLower(string(transformertypes.ModifiedSourcePathMappingType)),
+			strings.ToLower(string(transformertypes.TemplatePathMappingType)),
+			strings.ToLower(string(transformertypes.SpecialTemplatePathMappingType)):
+			addFileProvenance(manifest, pm, pm.SrcPath, destPath, sourcePath, outputPath)
+		default:
+			if !copiedDefaultDests[getpair(pm.SrcPath, pm.DestPath)] {
+				addFileProvenance(manifest, pm, pm.SrcPath, destPath, sourcePath, outputPath)
+				copiedDefaultDests[getpair(pm.SrcPath, pm.DestPath)] = true
+			}
+		}
+	}
+	for _, pm := range pms {
+		if !strings.EqualFold(string(pm.Type), string(transformertypes.DeletePathMappingType)) {
+			continue
+		}
+		destPath := pm.DestPath
+		if !filepath.IsAbs(pm.DestPath) {
+			destPath = filepath.Join(outputPath, pm.DestPath)
+		}
+		relDestPath, err := filepath.Rel(outputPath, destPath)
+		if err != nil {
+			continue
+		}
+		for outputFilePath := range manifest.Files {
+			if outputFilePath == relDestPath || strings.HasPrefix(outputFilePath, relDestPath+string(os.PathSeparator)) {
+				delete(manifest.Files, outputFilePath)
+			}
+		}
+	}
+	return manifest
+}
+
+// addFileProvenance records the provenance of all the files that the path mapping copies from srcPath to destPath
+func addFileProvenance(manifest transformertypes.ProvenanceManifest, pm transformertypes.PathMapping, srcPath, destPath, sourcePath, outputPath string) {
+	isTemplate := strings.EqualFold(string(pm.Type), string(transformertypes.TemplatePathMappingType)) ||
+		strings.EqualFold(string(pm.Type), string(transformertypes.SpecialTemplatePathMappingType))
+	add := func(srcFilePath, destFilePath string) {
+		if isTemplate {
+			filledDestFilePath, err := common.GetStringFromTemplate(destFilePath, pm.TemplateConfig)
+			if err != nil {
+				logrus.Debugf("failed to fill the template of the file path '%s' . Error: %q", destFilePath, err)
+				return
+			}
+			destFilePath = filledDestFilePath
+		}
+		relDestFilePath, err := filepath.Rel(outputPath, destFilePath)
+		if err != nil || relDestFilePath == ".." || strings.HasPrefix(relDestFilePath, ".."+string(os.PathSeparator)) {
+			return
+		}
+		if relSrcFilePath, err := filepath.Rel(sourcePath, srcFilePath); err == nil && sourcePath != "" && !strings.HasPrefix(relSrcFilePath, "..") {
+			srcFilePath = relSrcFilePath
+		}
+		fileProvenance := transformertypes.FileProvenance{SourcePath: srcFilePath, PathMappingType: pm.Type}
+		if fileProvenance.PathMappingType == "" {
+			fileProvenance.PathMappingType = transformertypes.DefaultPathMappingType
+		}
+		if pm.Provenance != nil {
+			fileProvenance.TransformerName = pm.Provenance.TransformerName
+			fileProvenance.Artifacts = pm.Provenance.Artifacts
+		}
+		manifest.Files[relDestFilePath] = append(manifest.Files[relDestFilePath], fileProvenance)
+	}
+	si, err := os.Stat(srcPath)
+	if err != nil {
+		logrus.Debugf("failed to stat the source path '%s' . Error: %q", srcPath, err)
+		return
+	}
+	if !si.IsDir() {
+		if di, err := os.Stat(destPath); err == nil && di.IsDir() {
+			destPath = filepath.Join(destPath, filepath.Base(srcPath))
+		}
+		add(srcPath, destPath)
+		return
+	}
+	if err := filepath.WalkDir(srcPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(srcPath, path)
+		if err != nil {
+			return err
+		}
+		add(path, filepath.Join(destPath, relPath))
+		return nil
+	}); err != nil {
+		logrus.Debugf("failed to walk the source path '%s' . Error: %q", srcPath, err)
+	}
+}
@@ -250,3 +250,125 @@ func TestProcessPathMappings(t *testing.T) {
 		}
 	})
 }
+
+func TestIncrementalPathMappingProcessor(t *testing.T) {
+	sourcePath := "./testdata"
+	deploymentPathMapping := transformertypes.PathMapping{
+		Type:     transformertypes.DefaultPathMappingType,
+		SrcPath:  filepath.Join(sourcePath, "k8s-yamls"),
+		DestPath: "yamls",
+	}
+	sourcePathMapping := transformertypes.PathMapping{
+		Type:     transformertypes.SourcePathMappingType,
+		SrcPath:  "src",
+		DestPath: "source",
+	}
+	outputPath := t.TempDir()
+	markerPath := filepath.Join(outputPath, "yamls", "marker.txt")
+	p := newIncrementalPathMappingProcessor(sourcePath, outputPath)
+	if err := p.process([]transformertypes.PathMapping{deploymentPathMapping}); err != nil {
+		t.Fatalf("failed to process the path mappings. Error: %q", err)
+	}
+	if err := os.WriteFile(markerPath, []byte("marker"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("Error: %q", err)
+	}
+	t.Run("new non overlapping pathmapping is applied without a rebuild", func(t *testing.T) {
+		if err := p.process([]transformertypes.PathMapping{deploymentPathMapping, sourcePathMapping}); err != nil {
+			t.Fatalf("failed to process the path mappings. Error: %q", err)
+		}
+		if _, err := os.Stat(filepath.Join(outputPath, "source", "index.js")); err != nil {
+			t.Fatalf("expected the new path mapping to be applied. Error: %q", err)
+		}
+		if _, err := os.Stat(markerPath); err != nil {
+			t.Fatalf("expected the output directory to not be rebuilt. Error: %q", err)
+		}
+	})
+	t.Run("overlapping pathmapping causes a rebuild", func(t *testing.T) {
+		overlappingPathMapping := transformertypes.PathMapping{
+			Type:     transformertypes.DefaultPathMappingType,
+			SrcPath:  filepath.Join(sourcePath, "src", "index.js"),
+			DestPath: filepath.Join("yamls", "index.js"),
+		}
+		if err := p.process([]transformertypes.PathMapping{deploymentPathMapping, sourcePathMapping, overlappingPathMapping}); err != nil {
+			t.Fatalf("failed to process the path mappings. Error: %q", err)
+		}
+		if _, err := os.Stat(markerPath); !os.IsNotExist(err) {
+			t.Fatalf("expected the output directory to be rebuilt. Error: %q", err)
+		}
+		for _, path := range []string{
+			filepath.Join(outputPath, "yamls", "deployment.yaml"),
+			filepath.Join(outputPath, "yamls", "index.js"),
+			filepath.Join(outputPath, "source", "index.js"),
+		} {
+			if _, err := os.Stat(path); err != nil {
+				t.Fatalf("expected the path '%s' to exist after the rebuild. Error: %q", path, err)
+			}
+		}
+	})
+	t.Run("delete pathmapping causes a rebuild", func(t *testing.T) {
+		deletePathMapping := transformertypes.PathMapping{
+			Type:     transformertypes.DeletePathMappingType,
+			DestPath: "source",
+		}
+		if err := p.process([]transformertypes.PathMapping{deletePathMapping}); err != nil {
+			t.Fatalf("failed to process the path mappings. Error: %q", err)
+		}
+		if _, err := os.Stat(filepath.Join(outputPath, "source")); !os.IsNotExist(err) {
+			t.Fatalf("expected the path to be deleted. Error: %q", err)
+		}
+		if _, err := os.Stat(filepath.Join(outputPath, "yamls", "deployment.yaml")); err != nil {
+			t.Fatalf("expected the path to exist after the rebuild. Error: %q", err)
+		}
+	})
+}
+
+func TestGetProvenanceManifest(t *testing.T) {
+	sourcePath := "./testdata"
+	provenance := &transformertypes.PathMappingProvenance{
+		TransformerName: "Kubernetes",
+		Artifacts:       []transformertypes.ArtifactReference{{Name: "svc1", Type: "IR"}},
+	}
+	pms := []transformertypes.PathMapping{{
+		Type:     transformertypes.SourcePathMappingType,
+		SrcPath:  "src",
+		DestPath: "source",
+	}, {
+		Type:     transformertypes.TemplatePathMappingType,
+		SrcPath:  filepath.Join(sourcePath, "templates"),
+		DestPath: "yamls",
+		TemplateConfig: map[string]interface{}{
+			"RegistryURL":       "quay.io",
+			"RegistryNamespace": "myaccount",
+			"ImageName":         "nginx",
+			"ImageTag":          "1.14.2",
+		},
+		Provenance: provenance,
+	}, {
+		Type:     transformertypes.DeletePathMappingType,
+		DestPath: filepath.Join("source", "package-lock.json"),
+	}}
+	outputPath := t.TempDir()
+	if err := processPathMappings(pms, sourcePath, outputPath, true); err != nil {
+		t.Fatalf("failed to process the path mappings. Error: %q", err)
+	}
+	want := transformertypes.ProvenanceManifest{Files: map[string][]transformertypes.FileProvenance{
+		filepath.Join("source", "index.js"): {{
+			SourcePath:      filepath.Join("src", "index.js"),
+			PathMappingType: transformertypes.SourcePathMappingType,
+		}},
+		filepath.Join("source", "package.json"): {{
+			SourcePath:      filepath.Join("src", "package.json"),
+			PathMappingType: transformertypes.SourcePathMappingType,
+		}},
+		filepath.Join("yamls", "deployment.yaml"): {{
+			TransformerName: provenance.TransformerName,
+			Artifacts:       provenance.Artifacts,
+			SourcePath:      filepath.Join("templates", "deployment.yaml"),
+			PathMappingType: transformertypes.TemplatePathMappingType,
+		}},
+	}}
+	got := getProvenanceManifest(pms, sourcePath, outputPath)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("wrong provenance manifest. Differences: %s", diff)
+	}
+}
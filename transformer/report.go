@@ -0,0 +1,154 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/events"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+)
+
+var (
+	// transformReport records the transformer invocations of the current transformation
+	transformReport      = transformertypes.TransformReport{}
+	transformReportMutex sync.Mutex
+	// serviceFilter is recorded in the report of a new transformation when only some of the services in the plan are transformed
+	serviceFilter *transformertypes.ServiceFilter
+)
+
+// SetServiceFilter sets the service filter that is recorded in the report of the next transformation.
+// A nil filter means all the services in the plan are transformed.
+func SetServiceFilter(filter *transformertypes.ServiceFilter) {
+	serviceFilter = filter
+}
+
+// resetTransformReport starts a new report, keeping the invocations of the previous transformation if it is being resumed
+func resetTransformReport(report transformertypes.TransformReport) {
+	transformReportMutex.Lock()
+	defer transformReportMutex.Unlock()
+	transformReport = transformertypes.TransformReport{
+		ServiceFilter: report.ServiceFilter,
+		Invocations:   append([]transformertypes.TransformerInvocation{}, report.Invocations...),
+	}
+}
+
+// GetTransformReport returns a copy of the report of the current transformation
+func GetTransformReport() transformertypes.TransformReport {
+	transformReportMutex.Lock()
+	defer transformReportMutex.Unlock()
+	return transformertypes.TransformReport{
+		ServiceFilter: transformReport.ServiceFilter,
+		Invocations:   append([]transformertypes.TransformerInvocation{}, transformReport.Invocations...),
+	}
+}
+
+// recordInvocation adds a transformer invocation to the report
+func recordInvocation(tconfig transformertypes.Transformer, iteration int, startTime time.Time, duration time.Duration, artifactsToProcess []transformertypes.Artifact, newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) {
+	invocation := transformertypes.TransformerInvocation{
+		TransformerName:      tconfig.Name,
+		TransformerClass:     tconfig.Spec.Class,
+		Iteration:            iteration,
+		StartTime:            startTime,
+		DurationSeconds:      duration.Seconds(),
+		InputArtifacts:       getArtifactReferences(artifactsToProcess),
+		ProducedArtifacts:    getArtifactReferences(newArtifacts),
+		ProducedPathMappings: len(newPathMappings),
+	}
+	if err != nil {
+		invocation.Error = err.Error()
+		invocation.TimedOut = errors.Is(err, context.DeadlineExceeded)
+	}
+	transformReportMutex.Lock()
+	transformReport.Invocations = append(transformReport.Invocations, invocation)
+	transformReportMutex.Unlock()
+	emitInvocationEvents(invocation, newPathMappings, newArtifacts)
+}
+
+// emitInvocationEvents emits the events for a finished transformer invocation and the artifacts and path mappings it created
+func emitInvocationEvents(invocation transformertypes.TransformerInvocation, newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact) {
+	if !events.IsEnabled() {
+		return
+	}
+	data := map[string]interface{}{
+		"transformer":          invocation.TransformerName,
+		"class":                invocation.TransformerClass,
+		"iteration":            invocation.Iteration,
+		"durationSeconds":      invocation.DurationSeconds,
+		"producedArtifacts":    len(invocation.ProducedArtifacts),
+		"producedPathMappings": invocation.ProducedPathMappings,
+	}
+	if invocation.Error != "" {
+		data["error"] = invocation.Error
+	}
+	if invocation.TimedOut {
+		data["timedOut"] = true
+	}
+	events.Emit(events.TransformerFinishedEventType, data)
+	for _, artifact := range newArtifacts {
+		events.Emit(events.ArtifactCreatedEventType, map[string]interface{}{
+			"transformer": invocation.TransformerName,
+			"name":        artifact.Name,
+			"type":        artifact.Type,
+		})
+	}
+	for _, pathMapping := range newPathMappings {
+		events.Emit(events.PathMappingCreatedEventType, map[string]interface{}{
+			"transformer":     invocation.TransformerName,
+			"type":            pathMapping.Type,
+			"sourcePath":      pathMapping.SrcPath,
+			"destinationPath": pathMapping.DestPath,
+		})
+	}
+}
+
+// getArtifactReferences returns references to the artifacts
+func getArtifactReferences(artifacts []transformertypes.Artifact) []transformertypes.ArtifactReference {
+	refs := []transformertypes.ArtifactReference{}
+	for _, artifact := range artifacts {
+		refs = append(refs, transformertypes.ArtifactReference{Name: artifact.Name, Type: artifact.Type})
+	}
+	return refs
+}
+
+// writeTransformReport writes the report in json and human readable formats into the directory
+func writeTransformReport(report transformertypes.TransformReport, dir string) error {
+	if err := os.MkdirAll(dir, common.DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the directory '%s' . Error: %w", dir, err)
+	}
+	reportBytes, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the transform report as json. Error: %w", err)
+	}
+	reportPath := filepath.Join(dir, transformertypes.ReportFileName)
+	if err := os.WriteFile(reportPath, reportBytes, common.DefaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write the transform report to the file '%s' . Error: %w", reportPath, err)
+	}
+	reportTextPath := filepath.Join(dir, transformertypes.ReportTextFileName)
+	if err := os.WriteFile(reportTextPath, []byte(report.String()), common.DefaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write the transform report to the file '%s' . Error: %w", reportTextPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+)
+
+func TestTransformReport(t *testing.T) {
+	resetTransformReport(transformertypes.TransformReport{})
+	defer resetTransformReport(transformertypes.TransformReport{})
+	tconfig := transformertypes.Transformer{}
+	tconfig.Name = "Kubernetes"
+	tconfig.Spec.Class = "Kubernetes"
+	input := []transformertypes.Artifact{{Name: "svc1", Type: "Service"}}
+	output := []transformertypes.Artifact{{Name: "svc1", Type: "IR"}}
+	recordInvocation(tconfig, 2, time.Now(), time.Second, input, []transformertypes.PathMapping{{}}, output, nil)
+	recordInvocation(tconfig, 3, time.Now(), time.Second, output, nil, nil, fmt.Errorf("failed to parse the IR"))
+
+	report := GetTransformReport()
+	if len(report.Invocations) != 2 {
+		t.Fatalf("expected 2 invocations. Actual: %+v", report.Invocations)
+	}
+	if report.Invocations[0].ProducedPathMappings != 1 || report.Invocations[0].ProducedArtifacts[0].Type != "IR" {
+		t.Fatalf("the first invocation was not recorded correctly. Actual: %+v", report.Invocations[0])
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Iteration != 3 || failed[0].Error != "failed to parse the IR" {
+		t.Fatalf("expected only the second invocation to fail. Actual: %+v", failed)
+	}
+
+	dir := t.TempDir()
+	if err := writeTransformReport(report, dir); err != nil {
+		t.Fatalf("failed to write the transform report. Error: %q", err)
+	}
+	reportBytes, err := os.ReadFile(filepath.Join(dir, transformertypes.ReportFileName))
+	if err != nil {
+		t.Fatalf("failed to read the json report. Error: %q", err)
+	}
+	got := transformertypes.TransformReport{}
+	if err := json.Unmarshal(reportBytes, &got); err != nil {
+		t.Fatalf("failed to decode the json report. Error: %q", err)
+	}
+	if len(got.Invocations) != 2 {
+		t.Fatalf("expected 2 invocations in the json report. Actual: %+v", got.Invocations)
+	}
+	reportText, err := os.ReadFile(filepath.Join(dir, transformertypes.ReportTextFileName))
+	if err != nil {
+		t.Fatalf("failed to read the text report. Error: %q", err)
+	}
+	if !strings.Contains(string(reportText), "2 (1 failed)") || !strings.Contains(string(reportText), "error:    failed to parse the IR") {
+		t.Fatalf("the text report is missing the failure. Actual:\n%s", reportText)
+	}
+}
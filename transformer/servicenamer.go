@@ -31,7 +31,7 @@ type servicePathInfo struct {
 	pathsuffix string
 }
 
-func nameServices(projectName string, inputServicesMap map[string][]plantypes.PlanArtifact) map[string][]plantypes.PlanArtifact {
+func nameServices(projectName string, sourceDir string, inputServicesMap map[string][]plantypes.PlanArtifact) map[string][]plantypes.PlanArtifact {
 	unnamedServices := inputServicesMap[""]
 	delete(inputServicesMap, "")
 	logrus.Debug("Collate named services by service dir path or shared common base dir")
@@ -72,6 +72,13 @@ func nameServices(projectName string, inputServicesMap map[string][]plantypes.Pl
 			serviceDirToUnnamedServices[commonServiceDir] = append(serviceDirToUnnamedServices[commonServiceDir], unnamedService)
 		}
 	}
+	if serviceNamingConfig.Strategy != "" && serviceNamingConfig.Strategy != plantypes.PathPrefixServiceNamingStrategy {
+		logrus.Debugf("Name the unnamed services using the service naming strategy '%s'", serviceNamingConfig.Strategy)
+		inputServicesMap = plantypes.MergeServices(inputServicesMap, nameServicesUsingStrategy(serviceNamingConfig, sourceDir, inputServicesMap, serviceDirToUnnamedServices))
+		if len(serviceDirToUnnamedServices) == 0 {
+			return inputServicesMap
+		}
+	}
 	logrus.Debug("Find if base dir is a git repo, and has only one service or many services")
 	repoNameToDirs := map[string][]string{} // [repoName][]repoDir
 	repoDirToName := map[string]string{}
@@ -0,0 +1,253 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/konveyor/move2kube/common"
+	plantypes "github.com/konveyor/move2kube/types/plan"
+	"github.com/konveyor/move2kube/types/source/maven"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/modfile"
+)
+
+var (
+	// serviceNamingConfig is the strategy used to name the services that were detected without a name
+	serviceNamingConfig        = plantypes.ServiceNamingConfig{}
+	gradleRootProjectNameRegex = regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`)
+	goModuleMajorVersionRegex  = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// SetServiceNamingConfig sets the strategy used to name the services that were detected without a name
+func SetServiceNamingConfig(config plantypes.ServiceNamingConfig) {
+	serviceNamingConfig = config
+}
+
+// nameServicesUsingStrategy names the unnamed services using the service naming strategy.
+// The service directories that were named are removed from serviceDirToUnnamedServices,
+// the remaining ones are left to be named by the default heuristic.
+// The new names never collide with the names of the existing services.
+func nameServicesUsingStrategy(config plantypes.ServiceNamingConfig, sourceDir string, existingServices map[string][]plantypes.PlanArtifact, serviceDirToUnnamedServices map[string][]plantypes.PlanArtifact) map[string][]plantypes.PlanArtifact {
+	var getName func(string) string
+	switch config.Strategy {
+	case plantypes.BaseNameServiceNamingStrategy:
+		getName = getServiceNameFromBaseName
+	case plantypes.GitServiceNamingStrategy:
+		getName = getServiceNameFromGitRemote
+	case plantypes.BuildMetadataServiceNamingStrategy:
+		getName = func(serviceDir string) string {
+			if name := getServiceNameFromBuildMetadata(serviceDir); name != "" {
+				return name
+			}
+			return getServiceNameFromBaseName(serviceDir)
+		}
+	case plantypes.RegexServiceNamingStrategy:
+		rewriter, err := newServiceNameRewriter(config.Rewrites)
+		if err != nil {
+			logrus.Errorf("failed to use the service name rewrites. Falling back to the default service naming. Error: %q", err)
+			return nil
+		}
+		getName = func(serviceDir string) string {
+			relServiceDir, err := filepath.Rel(sourceDir, serviceDir)
+			if err != nil {
+				logrus.Debugf("failed to make the service directory '%s' relative to the source directory '%s' . Error: %q", serviceDir, sourceDir, err)
+				relServiceDir = serviceDir
+			}
+			return rewriter(filepath.ToSlash(relServiceDir))
+		}
+	default:
+		logrus.Errorf("unknown service naming strategy '%s' . Falling back to the default service naming.", config.Strategy)
+		return nil
+	}
+	serviceDirToName := map[string]string{}
+	for serviceDir := range serviceDirToUnnamedServices {
+		name := getName(serviceDir)
+		if name == "" {
+			logrus.Debugf("the service naming strategy '%s' did not find a name for the directory '%s'", config.Strategy, serviceDir)
+			continue
+		}
+		serviceDirToName[serviceDir] = common.NormalizeForMetadataName(name)
+	}
+	existingServiceNames := map[string]bool{}
+	for serviceName := range existingServices {
+		existingServiceNames[serviceName] = true
+	}
+	namedServices := map[string][]plantypes.PlanArtifact{}
+	for serviceDir, serviceName := range makeServiceNamesUnique(serviceDirToName, existingServiceNames) {
+		namedServices[serviceName] = append(namedServices[serviceName], serviceDirToUnnamedServices[serviceDir]...)
+		delete(serviceDirToUnnamedServices, serviceDir)
+	}
+	return namedServices
+}
+
+// makeServiceNamesUnique prefixes the names shared by multiple service directories, or by an existing service,
+// with the names of their parent directories. Names that are still shared after that get a numeric suffix.
+func makeServiceNamesUnique(serviceDirToName map[string]string, existingNames map[string]bool) map[string]string {
+	nameToServiceDirs := map[string][]string{}
+	for serviceDir, name := range serviceDirToName {
+		nameToServiceDirs[name] = append(nameToServiceDirs[name], serviceDir)
+	}
+	uniqueServiceDirToName := map[string]string{}
+	prefixedNameToServiceDirs := map[string][]string{}
+	for name, serviceDirs := range nameToServiceDirs {
+		if len(serviceDirs) == 1 && !existingNames[name] {
+			uniqueServiceDirToName[serviceDirs[0]] = name
+			continue
+		}
+		for _, serviceDir := range serviceDirs {
+			prefixedName := common.NormalizeForMetadataName(filepath.Base(filepath.Dir(serviceDir)) + "-" + name)
+			prefixedNameToServiceDirs[prefixedName] = append(prefixedNameToServiceDirs[prefixedName], serviceDir)
+		}
+	}
+	takenNames := map[string]bool{}
+	for name := range existingNames {
+		takenNames[name] = true
+	}
+	for _, name := range uniqueServiceDirToName {
+		takenNames[name] = true
+	}
+	prefixedNames := []string{}
+	for prefixedName := range prefixedNameToServiceDirs {
+		prefixedNames = append(prefixedNames, prefixedName)
+	}
+	sort.Strings(prefixedNames)
+	for _, prefixedName := range prefixedNames {
+		serviceDirs := prefixedNameToServiceDirs[prefixedName]
+		sort.Strings(serviceDirs)
+		for _, serviceDir := range serviceDirs {
+			name := prefixedName
+			for i := 2; takenNames[name]; i++ {
+				name = common.NormalizeForMetadataName(fmt.Sprintf("%s-%d", prefixedName, i))
+			}
+			takenNames[name] = true
+			uniqueServiceDirToName[serviceDir] = name
+		}
+	}
+	return uniqueServiceDirToName
+}
+
+func getServiceNameFromBaseName(serviceDir string) string {
+	name := filepath.Base(serviceDir)
+	if name == "." || name == string(filepath.Separator) {
+		return ""
+	}
+	return name
+}
+
+// getServiceNameFromGitRemote uses the repo name for the root directory of the repo
+// and the repo name followed by the directory name for the other directories in the repo.
+func getServiceNameFromGitRemote(serviceDir string) string {
+	repoName, repoDir, _, repoURL, _, err := common.GatherGitInfo(serviceDir)
+	if err != nil {
+		logrus.Debugf("failed to find any git repo for directory '%s' . Error: %q", serviceDir, err)
+		return ""
+	}
+	if repoName == "" {
+		logrus.Debugf("no repo name found for the git repo at '%s' . Skipping", repoURL)
+		return ""
+	}
+	if common.CleanAndFindCommonDirectory([]string{repoDir, serviceDir}) == filepath.Clean(serviceDir) {
+		return repoName
+	}
+	return repoName + "-" + filepath.Base(serviceDir)
+}
+
+// getServiceNameFromBuildMetadata looks for the name of the project in the build files found in the service directory
+func getServiceNameFromBuildMetadata(serviceDir string) string {
+	pomPath := filepath.Join(serviceDir, maven.PomXMLFileName)
+	if _, err := os.Stat(pomPath); err == nil {
+		pom := maven.Pom{}
+		if err := pom.Load(pomPath); err != nil {
+			logrus.Debugf("failed to load the pom.xml file at path '%s' . Error: %q", pomPath, err)
+		} else if pom.ArtifactID != "" {
+			return pom.ArtifactID
+		}
+	}
+	for _, settingsFileName := range []string{"settings.gradle", "settings.gradle.kts"} {
+		if data, err := os.ReadFile(filepath.Join(serviceDir, settingsFileName)); err == nil {
+			if matches := gradleRootProjectNameRegex.FindSubmatch(data); matches != nil {
+				return string(matches[1])
+			}
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(serviceDir, "package.json")); err == nil {
+		packageJSON := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.Unmarshal(data, &packageJSON); err != nil {
+			logrus.Debugf("failed to parse the package.json file in the directory '%s' . Error: %q", serviceDir, err)
+		} else if packageJSON.Name != "" {
+			// strip the scope from names like @org/name
+			parts := strings.Split(packageJSON.Name, "/")
+			return parts[len(parts)-1]
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(serviceDir, "go.mod")); err == nil {
+		if modulePath := modfile.ModulePath(data); modulePath != "" {
+			parts := strings.Split(modulePath, "/")
+			if len(parts) > 1 && goModuleMajorVersionRegex.MatchString(parts[len(parts)-1]) {
+				parts = parts[:len(parts)-1]
+			}
+			return parts[len(parts)-1]
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(serviceDir, "Cargo.toml")); err == nil {
+		cargoToml := struct {
+			Package struct {
+				Name string `toml:"name"`
+			} `toml:"package"`
+		}{}
+		if _, err := toml.Decode(string(data), &cargoToml); err != nil {
+			logrus.Debugf("failed to parse the Cargo.toml file in the directory '%s' . Error: %q", serviceDir, err)
+		} else if cargoToml.Package.Name != "" {
+			return cargoToml.Package.Name
+		}
+	}
+	if csprojPaths, err := filepath.Glob(filepath.Join(serviceDir, "*.csproj")); err == nil && len(csprojPaths) == 1 {
+		return strings.TrimSuffix(filepath.Base(csprojPaths[0]), filepath.Ext(csprojPaths[0]))
+	}
+	return ""
+}
+
+// newServiceNameRewriter returns a function that rewrites a path using the first matching regex.
+// An empty name is returned if none of the regexes match.
+func newServiceNameRewriter(rewrites []plantypes.ServiceNameRewrite) (func(string) string, error) {
+	regexes := []*regexp.Regexp{}
+	for _, rewrite := range rewrites {
+		regex, err := regexp.Compile(rewrite.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile the regex '%s' . Error: %w", rewrite.Regex, err)
+		}
+		regexes = append(regexes, regex)
+	}
+	return func(path string) string {
+		for i, regex := range regexes {
+			if regex.MatchString(path) {
+				return regex.ReplaceAllString(path, rewrites[i].Replacement)
+			}
+		}
+		return ""
+	}, nil
+}
@@ -0,0 +1,139 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	plantypes "github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+)
+
+func getServiceNamingTestServices(t *testing.T, sourceDir string, files map[string]string) map[string][]plantypes.PlanArtifact {
+	t.Helper()
+	services := map[string][]plantypes.PlanArtifact{}
+	for path, contents := range files {
+		fullPath := filepath.Join(sourceDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("failed to create the directory for %s . Error: %q", path, err)
+		}
+		if err := os.WriteFile(fullPath, []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write the file %s . Error: %q", path, err)
+		}
+		a := plantypes.PlanArtifact{TransformerName: "t1"}
+		a.Paths = map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {filepath.Dir(fullPath)}}
+		services[""] = append(services[""], a)
+	}
+	return services
+}
+
+func getServiceNamesAndDirs(services map[string][]plantypes.PlanArtifact) map[string]string {
+	names := map[string]string{}
+	for name, as := range services {
+		for _, a := range as {
+			names[name] = a.Paths[artifacts.ServiceDirPathType][0]
+		}
+	}
+	return names
+}
+
+func TestNameServicesUsingStrategies(t *testing.T) {
+	defer SetServiceNamingConfig(plantypes.ServiceNamingConfig{})
+	files := map[string]string{
+		"src/main/svc/orders/pom.xml":        "<project><artifactId>order-service</artifactId></project>",
+		"src/main/svc/web/package.json":      `{"name": "@shop/storefront"}`,
+		"src/main/svc/inventory/go.mod":      "module github.com/shop/inventory/v2\n\ngo 1.19\n",
+		"src/other/svc/inventory/Dockerfile": "FROM scratch\n",
+	}
+	testCases := []struct {
+		name   string
+		config plantypes.ServiceNamingConfig
+		named  map[string]string
+		want   []string
+	}{
+		{
+			name:   "basename strategy prefixes the names that collide with the parent directory name",
+			config: plantypes.ServiceNamingConfig{Strategy: plantypes.BaseNameServiceNamingStrategy},
+			want:   []string{"main/svc/inventory:svc-inventory", "main/svc/orders:orders", "main/svc/web:web", "other/svc/inventory:svc-inventory-2"},
+		},
+		{
+			name:   "build metadata strategy falls back to the directory name",
+			config: plantypes.ServiceNamingConfig{Strategy: plantypes.BuildMetadataServiceNamingStrategy},
+			want:   []string{"main/svc/inventory:svc-inventory", "main/svc/orders:order-service", "main/svc/web:storefront", "other/svc/inventory:svc-inventory-2"},
+		},
+		{
+			name: "regex strategy uses the first matching rewrite",
+			config: plantypes.ServiceNamingConfig{
+				Strategy: plantypes.RegexServiceNamingStrategy,
+				Rewrites: []plantypes.ServiceNameRewrite{
+					{Regex: `^src/main/svc/(.+)$`, Replacement: "shop-$1"},
+					{Regex: `^src/other/svc/(.+)$`, Replacement: "legacy-$1"},
+				},
+			},
+			want: []string{"main/svc/inventory:shop-inventory", "main/svc/orders:shop-orders", "main/svc/web:shop-web", "other/svc/inventory:legacy-inventory"},
+		},
+		{
+			name:   "basename strategy does not reuse the names of the existing services",
+			config: plantypes.ServiceNamingConfig{Strategy: plantypes.BaseNameServiceNamingStrategy},
+			named:  map[string]string{"web": "named/web", "svc-web": "named/svc-web"},
+			want:   []string{"main/svc/inventory:svc-inventory", "main/svc/orders:orders", "main/svc/web:svc-web-2", "named/svc-web:svc-web", "named/web:web", "other/svc/inventory:svc-inventory-2"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sourceDir := t.TempDir()
+			services := getServiceNamingTestServices(t, sourceDir, files)
+			for name, dir := range tc.named {
+				a := plantypes.PlanArtifact{TransformerName: "t1"}
+				a.Paths = map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {filepath.Join(sourceDir, "src", dir)}}
+				services[name] = append(services[name], a)
+			}
+			SetServiceNamingConfig(tc.config)
+			got := []string{}
+			for name, dir := range getServiceNamesAndDirs(nameServices("myproject", sourceDir, services)) {
+				relDir, _ := filepath.Rel(filepath.Join(sourceDir, "src"), dir)
+				got = append(got, filepath.ToSlash(relDir)+":"+name)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("failed to name the services. Expected: %v Actual: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseServiceNameRewrites(t *testing.T) {
+	rewrites, err := plantypes.ParseServiceNameRewrites("# comment\n^a/(.*)$ => svc-$1\n\n  b => c  \n")
+	if err != nil {
+		t.Fatalf("failed to parse the rewrites. Error: %q", err)
+	}
+	want := []plantypes.ServiceNameRewrite{{Regex: "^a/(.*)$", Replacement: "svc-$1"}, {Regex: "b", Replacement: "c"}}
+	if !reflect.DeepEqual(rewrites, want) {
+		t.Fatalf("failed to parse the rewrites. Expected: %+v Actual: %+v", want, rewrites)
+	}
+	if _, err := plantypes.ParseServiceNameRewrites("no separator"); err == nil {
+		t.Fatal("expected an error for a line without a separator")
+	}
+	if _, err := plantypes.ParseServiceNameRewrites("([ => x"); err == nil {
+		t.Fatal("expected an error for an invalid regex")
+	}
+}
@@ -17,6 +17,7 @@
 package transformer
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,10 +27,13 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/environment"
 	containertypes "github.com/konveyor/move2kube/environment/container"
+	"github.com/konveyor/move2kube/events"
 	"github.com/konveyor/move2kube/filesystem"
 	"github.com/konveyor/move2kube/qaengine"
 	"github.com/konveyor/move2kube/transformer/compose"
@@ -61,6 +65,20 @@ type Transformer interface {
 	Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error)
 }
 
+// TransformerWithContext is implemented by transformers that support cancellation.
+// These methods are called instead of DirectoryDetect and Transform when available.
+type TransformerWithContext interface {
+	Transformer
+	DirectoryDetectWithContext(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error)
+	TransformWithContext(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error)
+}
+
+// TransformerWithDestroy is implemented by transformers that hold resources which must be released at the end of the run
+type TransformerWithDestroy interface {
+	Transformer
+	Destroy() error
+}
+
 type processType int
 
 const (
@@ -86,6 +104,15 @@ var (
 	transformers                 = []Transformer{}
 	invokedByDefaultTransformers = []Transformer{}
 	transformerMap               = map[string]Transformer{}
+	transformerInitErrors        = map[string]error{}
+	transformerSortOrderMap      = map[string]int{}
+	transformerOverrides         = map[string][]string{}
+	// incompatibleTransformers has the transformers whose version requirements are not satisfied. It is not cleared by Reset.
+	incompatibleTransformers = map[string]error{}
+	// detectParallelism is the maximum number of transformers that detect in directories at the same time during planning
+	detectParallelism = 1
+	// detectCacheDir is where the directory detection results are cached. Caching is disabled when it is empty.
+	detectCacheDir = ""
 )
 
 func init() {
@@ -93,6 +120,8 @@ func init() {
 		new(external.WASM),
 		new(external.Starlark),
 		new(external.Executable),
+		new(external.Rules),
+		new(external.GRPCPlugin),
 
 		new(Router),
 
@@ -157,6 +186,22 @@ func RegisterTransformer(tf Transformer) error {
 
 // Init initializes the transformers
 func Init(assetsPath, sourcePath string, selector labels.Selector, outputPath, projName string) (map[string]string, error) {
+	transformerYamlPaths, err := GetTransformerYamlPaths(assetsPath)
+	if err != nil {
+		return nil, err
+	}
+	deselectedTransformers, err := InitTransformers(transformerYamlPaths, selector, sourcePath, outputPath, projName, false, false)
+	if err != nil {
+		return deselectedTransformers, fmt.Errorf(
+			"failed to initialize the transformers using the source path '%s' and the output path '%s' . Error: %w",
+			sourcePath, outputPath, err,
+		)
+	}
+	return deselectedTransformers, nil
+}
+
+// GetTransformerYamlPaths returns the paths of the transformer yamls in the assets directory, keyed by transformer name
+func GetTransformerYamlPaths(assetsPath string) (map[string]string, error) {
 	yamlPaths, err := common.GetFilesByExt(assetsPath, []string{".yml", ".yaml"})
 	if err != nil {
 		return nil, fmt.Errorf("failed to look for yaml files in the directory '%s' . Error: %w", assetsPath, err)
@@ -173,14 +218,7 @@ func Init(assetsPath, sourcePath string, selector labels.Selector, outputPath, p
 		}
 		transformerYamlPaths[tc.Name] = yamlPath
 	}
-	deselectedTransformers, err := InitTransformers(transformerYamlPaths, selector, sourcePath, outputPath, projName, false, false)
-	if err != nil {
-		return deselectedTransformers, fmt.Errorf(
-			"failed to initialize the transformers using the source path '%s' and the output path '%s' . Error: %w",
-			sourcePath, outputPath, err,
-		)
-	}
-	return deselectedTransformers, nil
+	return transformerYamlPaths, nil
 }
 
 // InitTransformers initializes a subset of transformers
@@ -206,7 +244,17 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 			selector = selector.Add(reqs...)
 		}
 	}
-	transformerConfigs := getFilteredTransformers(transformerYamlPaths, selector, logError)
+	transformerConfigs, overrides, incompatible := getFilteredTransformers(transformerYamlPaths, selector, logError)
+	transformerOverrides = overrides
+	incompatibleTransformers = incompatible
+	incompatibleTransformerNames := []string{}
+	for transformerName := range incompatibleTransformers {
+		incompatibleTransformerNames = append(incompatibleTransformerNames, transformerName)
+	}
+	sort.Strings(incompatibleTransformerNames)
+	for _, transformerName := range incompatibleTransformerNames {
+		logrus.Warnf("Disabling the transformer '%s' since its version requirements are not satisfied. Error: %q", transformerName, incompatibleTransformers[transformerName])
+	}
 	deselectedTransformers := map[string]string{}
 	for transformerName, transformerPath := range transformerYamlPaths {
 		if _, ok := transformerConfigs[transformerName]; !ok {
@@ -280,6 +328,7 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 			return deselectedTransformers, fmt.Errorf("failed to create the environment %+v . Error: %w", envInfo, err)
 		}
 		if err := transformer.Init(transformerConfig, env); err != nil {
+			transformerInitErrors[transformerConfig.Name] = err
 			if errors.Is(err, containertypes.ErrNoContainerRuntime) {
 				logrus.Debugf("failed to initialize the transformer '%s' . Error: %q", transformerConfig.Name, err)
 			} else {
@@ -302,6 +351,7 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 			}
 		}
 		transformerSortOrders = append(transformerSortOrders, newSortOrder)
+		transformerSortOrderMap[selectedTransformerName] = newSortOrder
 		// for sorting later on
 		transformerMap[selectedTransformerName] = transformer
 		if transformerConfig.Spec.InvokedByDefault.Enabled {
@@ -322,6 +372,11 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 // Destroy destroys the transformers
 func Destroy() {
 	for _, t := range transformers {
+		if dt, ok := t.(TransformerWithDestroy); ok {
+			if err := dt.Destroy(); err != nil {
+				logrus.Errorf("Unable to destroy transformer : %s", err)
+			}
+		}
 		_, env := t.GetConfig()
 		if err := env.Destroy(); err != nil {
 			logrus.Errorf("Unable to destroy environment : %s", err)
@@ -329,6 +384,47 @@ func Destroy() {
 	}
 }
 
+// Reset destroys the initialized transformers so that they can be initialized again with a different source or output directory
+func Reset() {
+	Destroy()
+	initialized = false
+	transformers = []Transformer{}
+	invokedByDefaultTransformers = []Transformer{}
+	transformerMap = map[string]Transformer{}
+	transformerInitErrors = map[string]error{}
+	transformerSortOrderMap = map[string]int{}
+	transformerOverrides = map[string][]string{}
+}
+
+// RunTransformer runs a single initialized transformer once on the given artifacts, the same way it is run during a transformation
+func RunTransformer(ctx context.Context, name string, artifactsToProcess []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	t, ok := transformerMap[name]
+	if !ok {
+		if err, ok := incompatibleTransformers[name]; ok {
+			return nil, nil, fmt.Errorf("the transformer '%s' was disabled. Error: %w", name, err)
+		}
+		if err, ok := transformerInitErrors[name]; ok {
+			return nil, nil, fmt.Errorf("the transformer '%s' failed to initialize. Error: %w", name, err)
+		}
+		return nil, nil, fmt.Errorf("the transformer '%s' is not initialized", name)
+	}
+	tconfig, env := t.GetConfig()
+	iteration := 1
+	graph := graphtypes.NewGraph()
+	startVertexId := graph.AddVertex("start", iteration, nil)
+	artifacts := []transformertypes.Artifact{}
+	for _, artifact := range artifactsToProcess {
+		configs := map[transformertypes.ConfigType]interface{}{}
+		for k, v := range artifact.Configs {
+			configs[k] = v
+		}
+		configs[graphtypes.GraphSourceVertexKey] = startVertexId
+		artifact.Configs = configs
+		artifacts = append(artifacts, artifact)
+	}
+	return runSingleTransform(ctx, artifacts, artifacts, t, tconfig, env, graph, iteration+1)
+}
+
 // GetInitializedTransformers returns the list of initialized transformers
 func GetInitializedTransformers() []Transformer {
 	return transformers
@@ -342,6 +438,19 @@ func GetTransformerByName(name string) (t Transformer, err error) {
 	return nil, fmt.Errorf("no transformer found")
 }
 
+// GetTransformerSortOrder returns the sort order of an initialized transformer
+func GetTransformerSortOrder(name string) (int, error) {
+	if sortOrder, ok := transformerSortOrderMap[name]; ok {
+		return sortOrder, nil
+	}
+	return 0, fmt.Errorf("no transformer found")
+}
+
+// GetOverriddenTransformers returns the names of the transformers that were removed by the override selector of the transformer
+func GetOverriddenTransformers(name string) []string {
+	return transformerOverrides[name]
+}
+
 // GetInitializedTransformersF returns the list of initialized transformers after filtering
 func GetInitializedTransformersF(filters labels.Selector) []Transformer {
 	filteredTransformers := []Transformer{}
@@ -358,8 +467,15 @@ func GetInitializedTransformersF(filters labels.Selector) []Transformer {
 	return filteredTransformers
 }
 
+// SetDetectOptions sets the maximum number of transformers that detect in directories at the same time
+// and the directory where the detection results are cached. An empty cache directory disables caching.
+func SetDetectOptions(parallelism int, cacheDir string) {
+	detectParallelism = parallelism
+	detectCacheDir = cacheDir
+}
+
 // GetServices returns the list of services detected in a directory
-func GetServices(projectName string, dir string, transformerSelector *metav1.LabelSelector) (map[string][]plantypes.PlanArtifact, error) {
+func GetServices(ctx context.Context, projectName string, dir string, transformerSelector *metav1.LabelSelector) (map[string][]plantypes.PlanArtifact, error) {
 	logrus.Trace("GetServices start")
 	defer logrus.Trace("GetServices end")
 	selectedTransformers := transformers
@@ -370,25 +486,30 @@ func GetServices(projectName string, dir string, transformerSelector *metav1.Lab
 		}
 		selectedTransformers = GetInitializedTransformersF(filters)
 	}
+	cache, err := newDetectCache(detectCacheDir)
+	if err != nil {
+		logrus.Warnf("Planning without the detection cache. Error: %q", err)
+		cache = nil
+	}
 	planServices := map[string][]plantypes.PlanArtifact{}
 	logrus.Infof("Planning started on the base directory: '%s'", dir)
 	logrus.Debugf("selectedTransformers: %+v", selectedTransformers)
+	baseDirTransformers := []Transformer{}
 	for _, transformer := range selectedTransformers {
-		config, env := transformer.GetConfig()
-		if err := env.Reset(); err != nil {
-			logrus.Errorf("failed to reset the environment for the transformer named '%s' . Error: %q", config.Name, err)
-			continue
-		}
-		if config.Spec.DirectoryDetect.Levels != 1 {
-			continue
+		config, _ := transformer.GetConfig()
+		if config.Spec.DirectoryDetect.Levels == 1 {
+			baseDirTransformers = append(baseDirTransformers, transformer)
 		}
+	}
+	outputs := detectInDirectoryConcurrently(ctx, baseDirTransformers, dir, cache)
+	for i, transformer := range baseDirTransformers {
+		config, _ := transformer.GetConfig()
 		logrus.Infof("[%s] Planning", config.Name)
-		newServices, err := transformer.DirectoryDetect(env.Encode(dir).(string))
-		if err != nil {
-			logrus.Errorf("failed to look for services in the directory '%s' using the transformer named '%s' . Error: %q", dir, config.Name, err)
+		if outputs[i].err != nil {
+			logrus.Errorf("failed to look for services in the directory '%s' using the transformer named '%s' . Error: %q", dir, config.Name, outputs[i].err)
 			continue
 		}
-		newPlanServices := getPlanArtifactsFromArtifacts(*env.Decode(&newServices).(*map[string][]transformertypes.Artifact), config)
+		newPlanServices := getPlanArtifactsFromArtifacts(outputs[i].services, config)
 		planServices = plantypes.MergeServices(planServices, newPlanServices)
 		if len(newPlanServices) > 0 {
 			logrus.Infof(getNamedAndUnNamedServicesLogMessage(newPlanServices))
@@ -399,7 +520,7 @@ func GetServices(projectName string, dir string, transformerSelector *metav1.Lab
 	logrus.Infof("[Base Directory] %s", getNamedAndUnNamedServicesLogMessage(planServices))
 	logrus.Infof("Planning finished on the base directory: '%s'", dir)
 	logrus.Info("Planning started on its sub directories")
-	nservices, err := walkForServices(dir, planServices)
+	nservices, err := walkForServices(ctx, dir, planServices, cache)
 	if err != nil {
 		logrus.Errorf("Transformation planning - Directory Walk failed. Error: %q", err)
 	} else {
@@ -407,60 +528,56 @@ func GetServices(projectName string, dir string, transformerSelector *metav1.Lab
 		logrus.Infoln("Planning finished on its sub directories")
 	}
 	logrus.Infof("[Directory Walk] %s", getNamedAndUnNamedServicesLogMessage(planServices))
-	planServices = nameServices(projectName, planServices)
+	planServices = nameServices(projectName, dir, planServices)
 	logrus.Infof("[Named Services] Identified %d named services", len(planServices))
 	return planServices, nil
 }
 
-func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArtifact) (map[string][]plantypes.PlanArtifact, error) {
+// walkForServices walks the sub directories of the input path in order and detects services in them.
+// The directory detection of the transformers runs ahead of the walk when the detect parallelism is more than 1.
+func walkForServices(ctx context.Context, inputPath string, bservices map[string][]plantypes.PlanArtifact, cache *detectCache) (map[string][]plantypes.PlanArtifact, error) {
 	services := bservices
 	ignoreDirectories, ignoreContents := getIgnorePaths(inputPath)
-	knownServiceDirPaths := []string{}
-
-	err := filepath.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
-		if err != nil {
-			logrus.Warnf("Skipping path %q due to error. Error: %q", path, err)
-			return nil
-		}
-		if !info.IsDir() {
-			return nil
-		}
-		for _, dirRegExp := range common.DefaultIgnoreDirRegexps {
-			if dirRegExp.Match([]byte(filepath.Base(path))) {
-				return filepath.SkipDir
-			}
+	directories, err := getDirectoriesToWalk(inputPath, ignoreDirectories, ignoreContents)
+	if err != nil {
+		return services, fmt.Errorf("failed to walk through the directory at path %s . Error: %q", inputPath, err)
+	}
+	walkTransformers := []Transformer{}
+	for _, transformer := range transformers {
+		config, _ := transformer.GetConfig()
+		if config.Spec.DirectoryDetect.Levels != 1 && config.Spec.DirectoryDetect.Levels != 0 {
+			walkTransformers = append(walkTransformers, transformer)
 		}
-		if common.IsPresent(knownServiceDirPaths, path) {
-			return filepath.SkipDir // TODO: Should we go inside the directory in this case?
+	}
+	walker := newDirectoryWalker(ctx, directories, walkTransformers, cache, detectParallelism)
+	defer walker.stop()
+	for i, directory := range directories {
+		path := directory.path
+		if walker.isSkipped(path) {
+			walker.advance(i, true, nil) // TODO: Should we go inside the directory in this case?
+			continue
 		}
-		if common.IsPresent(ignoreDirectories, path) {
-			if common.IsPresent(ignoreContents, path) {
-				return filepath.SkipDir
-			}
-			return nil
+		if !directory.detect {
+			walker.advance(i, false, nil)
+			continue
 		}
 		common.PlanProgressNumDirectories++
 		logrus.Debugf("Planning in directory %s", path)
 		numfound := 0
 		skipThisDir := false
-		for _, transformer := range transformers {
-			config, env := transformer.GetConfig()
+		serviceDirPaths := []string{}
+		outputs := walker.getOutputs(i)
+		for ti, transformer := range walkTransformers {
+			config, _ := transformer.GetConfig()
 			logrus.Debugf("[%s] Planning in directory %s", config.Name, path)
-			if err := env.Reset(); err != nil {
-				logrus.Errorf("failed to reset the environment for the transformer %s . Error: %q", config.Name, err)
-				continue
-			}
-			if config.Spec.DirectoryDetect.Levels == 1 || config.Spec.DirectoryDetect.Levels == 0 {
-				continue
-			}
-			newServicesToArtifacts, err := transformer.DirectoryDetect(env.Encode(path).(string))
-			if err != nil {
-				logrus.Warnf("[%s] directory detect failed. Error: %q", config.Name, err)
+			if outputs[ti].err != nil {
+				logrus.Warnf("[%s] directory detect failed. Error: %q", config.Name, outputs[ti].err)
 				continue
 			}
+			newServicesToArtifacts := outputs[ti].services
 			for _, newServiceArtifacts := range newServicesToArtifacts {
 				for _, newServiceArtifact := range newServiceArtifacts {
-					knownServiceDirPaths = append(knownServiceDirPaths, newServiceArtifact.Paths[artifacts.ServiceDirPathType]...)
+					serviceDirPaths = append(serviceDirPaths, newServiceArtifact.Paths[artifacts.ServiceDirPathType]...)
 					for _, serviceDirPath := range newServiceArtifact.Paths[artifacts.ServiceDirPathType] {
 						if serviceDirPath == path {
 							skipThisDir = true
@@ -469,7 +586,7 @@ func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArti
 					}
 				}
 			}
-			newPlanServices := getPlanArtifactsFromArtifacts(*env.Decode(&newServicesToArtifacts).(*map[string][]transformertypes.Artifact), config)
+			newPlanServices := getPlanArtifactsFromArtifacts(newServicesToArtifacts, config)
 			services = plantypes.MergeServices(services, newPlanServices)
 			logrus.Debugf("[%s] Done", config.Name)
 			numfound += len(newPlanServices)
@@ -485,13 +602,7 @@ func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArti
 			}
 		}
 		logrus.Debugf("planning finished for the directory %s and %d services were detected", path, numfound)
-		if skipThisDir || common.IsPresent(ignoreContents, path) {
-			return filepath.SkipDir
-		}
-		return nil
-	})
-	if err != nil {
-		return services, fmt.Errorf("failed to walk through the directory at path %s . Error: %q", inputPath, err)
+		walker.advance(i, skipThisDir || common.IsPresent(ignoreContents, path), serviceDirPaths)
 	}
 	return services, nil
 }
@@ -534,55 +645,102 @@ func preprocessArtifact(planArtifact plantypes.PlanArtifact) plantypes.PlanArtif
 	return planArtifact
 }
 
-// Transform transforms as per the plan
-func Transform(planArtifacts []plantypes.PlanArtifact, sourceDir, outputPath string, maxIterations int) error {
+// Transform transforms as per the plan.
+// In dry run mode the path mappings are written to the scratch copy of the output directory instead of the output directory,
+// so the transformers must have been initialized with the scratch path as their output directory.
+// Nothing is written to the output directory, including the graph, provenance and report files,
+// and a summary of the differences between the scratch copy and the output directory is returned instead.
+// A checkpoint is written after every iteration. In resume mode the transformation continues from the last checkpoint.
+func Transform(ctx context.Context, planArtifacts []plantypes.PlanArtifact, sourceDir, outputPath string, maxIterations int, parallelism int, dryRun *DryRunOutput, resume bool) (transformertypes.DryRunSummary, error) {
 	logrus.Trace("transformer.Transform start")
 	defer logrus.Trace("transformer.Transform end")
+	if dryRun != nil {
+		if resume {
+			return nil, fmt.Errorf("a dry run cannot be resumed from a checkpoint")
+		}
+		outputPath = dryRun.ScratchPath
+	}
 	var allArtifacts []transformertypes.Artifact
 	newArtifactsToProcess := []transformertypes.Artifact{}
 	pathMappings := []transformertypes.PathMapping{}
-	defaultNewArtifactsToProcess := []transformertypes.Artifact{}
 	iteration := 1
-	// transform default transformers
-	graph := graphtypes.NewGraph()
-	startVertexId := graph.AddVertex("start", iteration, nil)
-	for _, invokedByDefaultTransformer := range invokedByDefaultTransformers {
-		tDefaultConfig, defaultEnv := invokedByDefaultTransformer.GetConfig()
-		newPathMappings, defaultArtifacts, err := runSingleTransform(nil, nil, invokedByDefaultTransformer, tDefaultConfig, defaultEnv, graph, iteration)
+	var graph *graphtypes.Graph
+	pathMappingProcessor := newIncrementalPathMappingProcessor(sourceDir, outputPath)
+	checkpointer := newCheckpointer(sourceDir, outputPath)
+	if resume {
+		cp, err := checkpointer.read()
 		if err != nil {
-			logrus.Errorf("failed to transform using the transformer %s. Error: %q", tDefaultConfig.Name, err)
+			return nil, fmt.Errorf("failed to resume the transformation. Error: %w", err)
+		}
+		iteration = cp.Iteration
+		graph = cp.Graph
+		allArtifacts = cp.AllArtifacts
+		newArtifactsToProcess = cp.NewArtifactsToProcess
+		pathMappings = cp.PathMappings
+		resetTransformReport(cp.Report)
+		if err := pathMappingProcessor.process(pathMappings); err != nil {
+			return nil, fmt.Errorf("failed to restore the output directory '%s' from the checkpoint. Error: %w", outputPath, err)
+		}
+		logrus.Infof("Resuming the transformation from iteration %d with %d artifacts to process", iteration, len(newArtifactsToProcess))
+	} else {
+		if dryRun == nil {
+			if err := checkpointer.reset(); err != nil {
+				return nil, fmt.Errorf("failed to remove the checkpoint of the previous transformation. Error: %w", err)
+			}
+		}
+		resetTransformReport(transformertypes.TransformReport{ServiceFilter: serviceFilter})
+		defaultNewArtifactsToProcess := []transformertypes.Artifact{}
+		// transform default transformers
+		graph = graphtypes.NewGraph()
+		startVertexId := graph.AddVertex("start", iteration, nil)
+		for _, invokedByDefaultTransformer := range invokedByDefaultTransformers {
+			tDefaultConfig, defaultEnv := invokedByDefaultTransformer.GetConfig()
+			newPathMappings, defaultArtifacts, err := runSingleTransform(ctx, nil, nil, invokedByDefaultTransformer, tDefaultConfig, defaultEnv, graph, iteration)
+			if err != nil {
+				logrus.Errorf("failed to transform using the transformer %s. Error: %q", tDefaultConfig.Name, err)
+			}
+			defaultNewArtifactsToProcess = append(defaultNewArtifactsToProcess, defaultArtifacts...)
+			pathMappings = append(pathMappings, newPathMappings...)
+		}
+		logrus.Infof("Iteration %d", iteration)
+		for _, planArtifact := range planArtifacts {
+			planArtifact = preprocessArtifact(planArtifact)
+			newArtifactsToProcess = append(newArtifactsToProcess, planArtifact.Artifact)
 		}
-		defaultNewArtifactsToProcess = append(defaultNewArtifactsToProcess, defaultArtifacts...)
-		pathMappings = append(pathMappings, newPathMappings...)
-	}
-	logrus.Infof("Iteration %d", iteration)
-	for _, planArtifact := range planArtifacts {
-		planArtifact = preprocessArtifact(planArtifact)
-		newArtifactsToProcess = append(newArtifactsToProcess, planArtifact.Artifact)
-	}
 
-	// logging
-	for _, artifact := range newArtifactsToProcess {
-		artifact.Configs[graphtypes.GraphSourceVertexKey] = startVertexId
+		// logging
+		for _, artifact := range newArtifactsToProcess {
+			artifact.Configs[graphtypes.GraphSourceVertexKey] = startVertexId
+		}
+		newArtifactsToProcess = append(newArtifactsToProcess, defaultNewArtifactsToProcess...)
+		allArtifacts = newArtifactsToProcess
+		// logging
+		if dryRun == nil {
+			if err := checkpointer.write(iteration, allArtifacts, pathMappings, graph); err != nil {
+				return nil, fmt.Errorf("failed to write the checkpoint for iteration %d . Error: %w", iteration, err)
+			}
+		}
 	}
-	newArtifactsToProcess = append(newArtifactsToProcess, defaultNewArtifactsToProcess...)
-	allArtifacts = newArtifactsToProcess
-	// logging
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("the transformation was cancelled. Error: %w", err)
+		}
 		iteration++
 		if maxIterations >= 0 && iteration > maxIterations {
 			logrus.Errorf("exceeded the max number of iterations: %d . stopping.", maxIterations)
 			break
 		}
 		logrus.Infof("Iteration %d - %d artifacts to process", iteration, len(newArtifactsToProcess))
-		newPathMappings, newArtifacts, _ := transform(newArtifactsToProcess, allArtifacts, consume, nil, graph, iteration)
-		pathMappings = append(pathMappings, newPathMappings...)
-		if err := os.RemoveAll(outputPath); err != nil {
-			return fmt.Errorf("failed to remove the output directory '%s' . Error: %w", outputPath, err)
+		events.Emit(events.IterationStartedEventType, map[string]interface{}{"iteration": iteration, "artifacts": len(newArtifactsToProcess)})
+		newPathMappings, newArtifacts, _ := transform(ctx, newArtifactsToProcess, allArtifacts, consume, nil, graph, iteration, parallelism)
+		if err := ctx.Err(); err != nil {
+			// the iteration is incomplete, so it is not checkpointed and is run again when the transformation is resumed
+			return nil, fmt.Errorf("the transformation was cancelled. Error: %w", err)
 		}
-		if err := processPathMappings(pathMappings, sourceDir, outputPath, false); err != nil {
-			return fmt.Errorf("failed to process the path mappings: %+v . Error: %w", pathMappings, err)
+		pathMappings = append(pathMappings, newPathMappings...)
+		if err := pathMappingProcessor.process(pathMappings); err != nil {
+			return nil, fmt.Errorf("failed to update the output directory '%s' . Error: %w", outputPath, err)
 		}
 		if len(newArtifacts) == 0 {
 			break
@@ -593,9 +751,43 @@ func Transform(planArtifacts []plantypes.PlanArtifact, sourceDir, outputPath str
 		)
 		allArtifacts = append(allArtifacts, newArtifacts...)
 		newArtifactsToProcess = newArtifacts
+		if dryRun == nil {
+			if err := checkpointer.write(iteration, newArtifacts, newPathMappings, graph); err != nil {
+				return nil, fmt.Errorf("failed to write the checkpoint for iteration %d . Error: %w", iteration, err)
+			}
+		}
+	}
+
+	if dryRun != nil {
+		summary, err := getDryRunSummary(pathMappings, sourceDir, dryRun.OutputPath, dryRun.ScratchPath)
+		if err != nil {
+			return summary, fmt.Errorf("failed to summarize the changes to the output directory '%s' . Error: %w", dryRun.OutputPath, err)
+		}
+		return summary, nil
 	}
 
 	// logging
+	{
+		provenanceManifest := getProvenanceManifest(pathMappings, sourceDir, outputPath)
+		provenanceFilePath := transformertypes.ProvenanceFileName
+		provenanceFile, err := os.Create(provenanceFilePath)
+		if err != nil {
+			logrus.Errorf("failed to create a %s file to write the provenance of the output files. Error: %q", provenanceFilePath, err)
+		} else {
+			defer provenanceFile.Close()
+			enc := json.NewEncoder(provenanceFile)
+			enc.SetIndent("", "    ")
+			if err := enc.Encode(provenanceManifest); err != nil {
+				logrus.Errorf("failed to encode the provenance manifest as json. Error: %q", err)
+			}
+		}
+	}
+	{
+		reportDir := filepath.Dir(outputPath)
+		if err := writeTransformReport(GetTransformReport(), reportDir); err != nil {
+			logrus.Errorf("failed to write the transform report to the directory '%s' . Error: %q", reportDir, err)
+		}
+	}
 	{
 		graphFilePath := graphtypes.GraphFileName
 		graphFile, err := os.Create(graphFilePath)
@@ -611,94 +803,428 @@ func Transform(planArtifacts []plantypes.PlanArtifact, sourceDir, outputPath str
 	}
 	// logging
 
-	return nil
+	if err := checkpointer.remove(); err != nil {
+		logrus.Warnf("the transformation completed but the checkpoint could not be removed. Error: %q", err)
+	}
+	return nil, nil
+}
+
+// transformerRun holds the state of a single transformer invocation while it is being scheduled
+type transformerRun struct {
+	transformer                   Transformer
+	tConfig                       transformertypes.Transformer
+	env                           *environment.Environment
+	artifactsToConsume            []transformertypes.Artifact
+	artifactsToNotProcess         []transformertypes.Artifact
+	dependencyCreatedNewArtifacts []transformertypes.Artifact
+	producedNewPathMappings       []transformertypes.PathMapping
+	producedNewArtifacts          []transformertypes.Artifact
+	startTime                     time.Time
+	duration                      time.Duration
+	resetFailed                   bool
+	err                           error
 }
 
-func transform(newArtifactsToProcess, allArtifacts []transformertypes.Artifact, pt processType, depSel labels.Selector, graph *graphtypes.Graph, iteration int) (pathMappings []transformertypes.PathMapping, newArtifactsCreated, updatedArtifacts []transformertypes.Artifact) {
+func transform(ctx context.Context, newArtifactsToProcess, allArtifacts []transformertypes.Artifact, pt processType, depSel labels.Selector, graph *graphtypes.Graph, iteration int, parallelism int) (pathMappings []transformertypes.PathMapping, newArtifactsCreated, updatedArtifacts []transformertypes.Artifact) {
 	logrus.Trace("transform start")
 	defer logrus.Trace("transform end")
 	if pt == dependency && (depSel == nil || depSel.String() == "") {
 		return nil, nil, newArtifactsToProcess
 	}
+	if pt == consume && parallelism > 1 {
+		return transformConcurrently(ctx, newArtifactsToProcess, allArtifacts, graph, iteration, parallelism)
+	}
 	for _, transformer := range transformers {
-		tConfig, env := transformer.GetConfig()
-		if pt == dependency && !depSel.Matches(labels.Set(tConfig.Labels)) {
-			logrus.Debugf("currently in dependency mode and the dependency selector does not match the transformer named '%s'", tConfig.Name)
+		transformerPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts, artifactsToProcessNext, ok := transformSingle(ctx, transformer, newArtifactsToProcess, allArtifacts, pt, depSel, graph, iteration)
+		pathMappings = append(pathMappings, transformerPathMappings...)
+		if !ok {
 			continue
 		}
-		artifactsToProcess, artifactsToNotProcess := getArtifactsToProcess(newArtifactsToProcess, allArtifacts, tConfig, pt)
-		if len(artifactsToProcess) == 0 {
-			logrus.Debugf("did not find any artifacts for the transformer named '%s' to process", tConfig.Name)
-			continue
+		newArtifactsCreated = append(newArtifactsCreated, passedThroughNewArtifactsCreated...)
+		updatedArtifacts = append(updatedArtifacts, passedThroughUpdatedArtifacts...)
+		if pt == passthrough || pt == dependency {
+			newArtifactsToProcess = artifactsToProcessNext
 		}
+	}
+	if pt == passthrough || pt == dependency {
+		logrus.Debugf("Created %d pathMappings, %d artifacts, %d updated artifacts from transform while passing through/dependency.", len(pathMappings), len(newArtifactsCreated), len(newArtifactsToProcess))
+		return pathMappings, newArtifactsCreated, newArtifactsToProcess
+	}
+	logrus.Debugf("Created %d pathMappings and %d artifacts from transform.", len(pathMappings), len(newArtifactsCreated))
+	return pathMappings, newArtifactsCreated, nil
+}
 
-		logrus.Debugf("Transformer '%s' will be processing %d artifacts in %d mode", tConfig.Name, len(artifactsToProcess), pt)
-		// Dependency processing
-		dependencyCreatedNewPathMappings, dependencyCreatedNewArtifacts, dependencyUpdatedArtifacts := transform(artifactsToProcess, allArtifacts, dependency, tConfig.Spec.DependencySelector, graph, iteration)
-		pathMappings = append(pathMappings, dependencyCreatedNewPathMappings...)
-		// Dependency processing
+// transformSingle does the dependency processing, runs the transformer and does the pass through processing for its output.
+// The path mappings are returned even when ok is false, since the dependency processing may have created some.
+func transformSingle(ctx context.Context, transformer Transformer, newArtifactsToProcess, allArtifacts []transformertypes.Artifact, pt processType, depSel labels.Selector, graph *graphtypes.Graph, iteration int) (
+	pathMappings []transformertypes.PathMapping,
+	newArtifactsCreated, updatedArtifacts, newArtifactsToProcessNext []transformertypes.Artifact,
+	ok bool,
+) {
+	run, dependencyCreatedNewPathMappings, ok := prepareTransformerRun(ctx, transformer, newArtifactsToProcess, allArtifacts, pt, depSel, graph, iteration)
+	if !ok {
+		return dependencyCreatedNewPathMappings, nil, nil, nil, false
+	}
+	run.producedNewPathMappings, run.producedNewArtifacts, run.err = runSingleTransform(ctx, run.artifactsToConsume, allArtifacts, run.transformer, run.tConfig, run.env, graph, iteration)
+	passedThroughPathMappings, newArtifactsCreated, updatedArtifacts, newArtifactsToProcessNext, ok := finishTransformerRun(ctx, run, allArtifacts, pt, graph, iteration)
+	if !ok {
+		return dependencyCreatedNewPathMappings, nil, nil, nil, false
+	}
+	return append(dependencyCreatedNewPathMappings, passedThroughPathMappings...), newArtifactsCreated, updatedArtifacts, newArtifactsToProcessNext, true
+}
 
-		artifactsToConsume, artifactsToNotConsume := getArtifactsToProcess(dependencyUpdatedArtifacts, allArtifacts, tConfig, pt)
-		if len(artifactsToNotConsume) != 0 {
-			logrus.Errorf("Artifacts to not consume: %d. This should have been 0.", len(artifactsToNotConsume))
-		}
+// hasDependencySelector returns true if the transformer asks other transformers to process the artifacts before it consumes them
+func hasDependencySelector(tConfig transformertypes.Transformer) bool {
+	return tConfig.Spec.DependencySelector != nil && tConfig.Spec.DependencySelector.String() != ""
+}
 
-		logrus.Infof("Transformer '%s' processing %d artifacts", tConfig.Name, len(artifactsToConsume))
-		producedNewPathMappings, producedNewArtifacts, err := runSingleTransform(artifactsToConsume, allArtifacts, transformer, tConfig, env, graph, iteration)
-		if err != nil {
-			logrus.Errorf("failed to run a single transformation using the transformer %+v on the artifacts: %+v", tConfig, artifactsToConsume)
-			logrus.Error(err.Error())
-			continue
+// transformConcurrently runs the transformers in the consume mode.
+// Consecutive transformers whose consumed artifacts are disjoint are run in parallel (at most parallelism at a time).
+// Only the transformers themselves run in parallel. The graph, the path mappings and the artifacts are updated after the batch
+// completes, in the order of the transformers, so the output is the same as the sequential run.
+// The questions asked by the transformers of a batch are asked in the order of their IDs, see qaengine.QuestionOrder.
+// Transformers with a dependency selector are run on their own, since their dependency processing updates the graph.
+func transformConcurrently(ctx context.Context, newArtifactsToProcess, allArtifacts []transformertypes.Artifact, graph *graphtypes.Graph, iteration int, parallelism int) (pathMappings []transformertypes.PathMapping, newArtifactsCreated, updatedArtifacts []transformertypes.Artifact) {
+	logrus.Trace("transformConcurrently start")
+	defer logrus.Trace("transformConcurrently end")
+	batch := []*transformerRun{}
+	batchArtifactKeys := map[string]bool{}
+	flush := func() {
+		if len(batch) == 0 {
+			return
 		}
-		pathMappings = append(pathMappings, producedNewPathMappings...)
-		artifactsToPassThrough := []transformertypes.Artifact{}
-		artifactsAlreadyPassedThrough := []transformertypes.Artifact{}
-		if pt == consume {
-			artifactsToPassThrough = append(dependencyCreatedNewArtifacts, producedNewArtifacts...)
-		} else if pt == passthrough || pt == dependency {
-			for _, a := range producedNewArtifacts {
-				if c, ok := tConfig.Spec.ConsumedArtifacts[a.Type]; ok &&
-					(c.Mode != transformertypes.MandatoryPassThrough && c.Mode != transformertypes.OnDemandPassThrough) {
-					artifactsToPassThrough = append(artifactsToPassThrough, a)
-				} else {
-					artifactsAlreadyPassedThrough = append(artifactsAlreadyPassedThrough, a)
+		logrus.Debugf("running a batch of %d transformers with parallelism %d", len(batch), parallelism)
+		// the questions are asked in a fixed order, independent of which transformer runs faster
+		questionOrder := qaengine.StartQuestionOrder(len(batch), parallelism)
+		wg := sync.WaitGroup{}
+		for _, run := range batch {
+			wg.Add(1)
+			questionOrder.StartCaller()
+			go func(run *transformerRun) {
+				defer func() {
+					run.duration = time.Since(run.startTime)
+					questionOrder.CallerDone()
+					wg.Done()
+				}()
+				run.startTime = time.Now()
+				if err := run.env.Reset(); err != nil {
+					run.err = fmt.Errorf("failed to reset the environment: %+v Error: %q", run.env, err)
+					run.resetFailed = true
+					return
 				}
+				run.producedNewPathMappings, run.producedNewArtifacts, run.err = executeSingleTransform(ctx, run.artifactsToConsume, allArtifacts, run.transformer, run.tConfig, run.env)
+			}(run)
+		}
+		wg.Wait()
+		questionOrder.Stop()
+		for _, run := range batch {
+			if !run.resetFailed {
+				run.producedNewPathMappings, run.producedNewArtifacts, run.err = finalizeSingleTransform(
+					run.artifactsToConsume, run.tConfig, run.env, graph, iteration,
+					run.producedNewPathMappings, run.producedNewArtifacts, run.err,
+				)
+			}
+			recordInvocation(run.tConfig, iteration, run.startTime, run.duration, run.artifactsToConsume, run.producedNewPathMappings, run.producedNewArtifacts, run.err)
+			passedThroughPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts, _, ok := finishTransformerRun(ctx, *run, allArtifacts, consume, graph, iteration)
+			if !ok {
+				continue
+			}
+			pathMappings = append(pathMappings, passedThroughPathMappings...)
+			newArtifactsCreated = append(newArtifactsCreated, passedThroughNewArtifactsCreated...)
+			updatedArtifacts = append(updatedArtifacts, passedThroughUpdatedArtifacts...)
+		}
+		batch = []*transformerRun{}
+		batchArtifactKeys = map[string]bool{}
+	}
+	for _, transformer := range transformers {
+		if tConfig, _ := transformer.GetConfig(); hasDependencySelector(tConfig) {
+			flush()
+			transformerPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts, _, ok := transformSingle(ctx, transformer, newArtifactsToProcess, allArtifacts, consume, nil, graph, iteration)
+			pathMappings = append(pathMappings, transformerPathMappings...)
+			if ok {
+				newArtifactsCreated = append(newArtifactsCreated, passedThroughNewArtifactsCreated...)
+				updatedArtifacts = append(updatedArtifacts, passedThroughUpdatedArtifacts...)
 			}
+			continue
 		}
+		// without a dependency selector, preparing the run does not change the graph or create any path mappings
+		run, _, ok := prepareTransformerRun(ctx, transformer, newArtifactsToProcess, allArtifacts, consume, nil, graph, iteration)
+		if !ok {
+			continue
+		}
+		artifactKeys := []string{}
+		for _, artifact := range run.artifactsToConsume {
+			artifactKeys = append(artifactKeys, getArtifactKey(artifact))
+		}
+		for _, artifactKey := range artifactKeys {
+			if batchArtifactKeys[artifactKey] {
+				flush()
+				break
+			}
+		}
+		for _, artifactKey := range artifactKeys {
+			batchArtifactKeys[artifactKey] = true
+		}
+		batch = append(batch, &run)
+	}
+	flush()
+	logrus.Debugf("Created %d pathMappings and %d artifacts from transform.", len(pathMappings), len(newArtifactsCreated))
+	return pathMappings, newArtifactsCreated, nil
+}
 
-		passedThroughPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts := transform(artifactsToPassThrough, allArtifacts, passthrough, nil, graph, iteration)
+// prepareTransformerRun finds the artifacts that the transformer should process and does the dependency processing for them
+func prepareTransformerRun(ctx context.Context, transformer Transformer, newArtifactsToProcess, allArtifacts []transformertypes.Artifact, pt processType, depSel labels.Selector, graph *graphtypes.Graph, iteration int) (transformerRun, []transformertypes.PathMapping, bool) {
+	tConfig, env := transformer.GetConfig()
+	run := transformerRun{transformer: transformer, tConfig: tConfig, env: env}
+	if pt == dependency && !depSel.Matches(labels.Set(tConfig.Labels)) {
+		logrus.Debugf("currently in dependency mode and the dependency selector does not match the transformer named '%s'", tConfig.Name)
+		return run, nil, false
+	}
+	artifactsToProcess, artifactsToNotProcess := getArtifactsToProcess(newArtifactsToProcess, allArtifacts, tConfig, pt)
+	if len(artifactsToProcess) == 0 {
+		logrus.Debugf("did not find any artifacts for the transformer named '%s' to process", tConfig.Name)
+		return run, nil, false
+	}
+	run.artifactsToNotProcess = artifactsToNotProcess
 
-		pathMappings = append(pathMappings, passedThroughPathMappings...)
-		newArtifactsCreated = append(newArtifactsCreated, passedThroughNewArtifactsCreated...)
-		if pt == consume {
-			newArtifactsCreated = append(newArtifactsCreated, passedThroughUpdatedArtifacts...)
-		}
-		updatedArtifacts = append(updatedArtifacts, passedThroughUpdatedArtifacts...)
-		if pt == passthrough || pt == dependency {
-			newArtifactsToProcess = artifactsToNotProcess
-			newArtifactsToProcess = append(newArtifactsToProcess, passedThroughUpdatedArtifacts...)
-			newArtifactsToProcess = append(newArtifactsToProcess, artifactsAlreadyPassedThrough...)
+	logrus.Debugf("Transformer '%s' will be processing %d artifacts in %d mode", tConfig.Name, len(artifactsToProcess), pt)
+	// Dependency processing
+	dependencyCreatedNewPathMappings, dependencyCreatedNewArtifacts, dependencyUpdatedArtifacts := transform(ctx, artifactsToProcess, allArtifacts, dependency, tConfig.Spec.DependencySelector, graph, iteration, 1)
+	run.dependencyCreatedNewArtifacts = dependencyCreatedNewArtifacts
+	// Dependency processing
+
+	artifactsToConsume, artifactsToNotConsume := getArtifactsToProcess(dependencyUpdatedArtifacts, allArtifacts, tConfig, pt)
+	if len(artifactsToNotConsume) != 0 {
+		logrus.Errorf("Artifacts to not consume: %d. This should have been 0.", len(artifactsToNotConsume))
+	}
+	run.artifactsToConsume = artifactsToConsume
+	logrus.Infof("Transformer '%s' processing %d artifacts", tConfig.Name, len(artifactsToConsume))
+	return run, dependencyCreatedNewPathMappings, true
+}
+
+// finishTransformerRun does the pass through processing for the artifacts produced by a transformer run
+func finishTransformerRun(ctx context.Context, run transformerRun, allArtifacts []transformertypes.Artifact, pt processType, graph *graphtypes.Graph, iteration int) (
+	pathMappings []transformertypes.PathMapping,
+	newArtifactsCreated, updatedArtifacts, newArtifactsToProcess []transformertypes.Artifact,
+	ok bool,
+) {
+	tConfig := run.tConfig
+	if run.err != nil {
+		logrus.Errorf("failed to run a single transformation using the transformer %+v on the artifacts: %+v", tConfig, run.artifactsToConsume)
+		logrus.Error(run.err.Error())
+		return nil, nil, nil, nil, false
+	}
+	pathMappings = append(pathMappings, run.producedNewPathMappings...)
+	artifactsToPassThrough := []transformertypes.Artifact{}
+	artifactsAlreadyPassedThrough := []transformertypes.Artifact{}
+	if pt == consume {
+		artifactsToPassThrough = append(run.dependencyCreatedNewArtifacts, run.producedNewArtifacts...)
+	} else if pt == passthrough || pt == dependency {
+		for _, a := range run.producedNewArtifacts {
+			if c, ok := tConfig.Spec.ConsumedArtifacts[a.Type]; ok &&
+				(c.Mode != transformertypes.MandatoryPassThrough && c.Mode != transformertypes.OnDemandPassThrough) {
+				artifactsToPassThrough = append(artifactsToPassThrough, a)
+			} else {
+				artifactsAlreadyPassedThrough = append(artifactsAlreadyPassedThrough, a)
+			}
 		}
-		logrus.Infof("Transformer %s Done", tConfig.Name)
 	}
+
+	passedThroughPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts := transform(ctx, artifactsToPassThrough, allArtifacts, passthrough, nil, graph, iteration, 1)
+
+	pathMappings = append(pathMappings, passedThroughPathMappings...)
+	newArtifactsCreated = append(newArtifactsCreated, passedThroughNewArtifactsCreated...)
+	if pt == consume {
+		newArtifactsCreated = append(newArtifactsCreated, passedThroughUpdatedArtifacts...)
+	}
+	updatedArtifacts = passedThroughUpdatedArtifacts
 	if pt == passthrough || pt == dependency {
-		logrus.Debugf("Created %d pathMappings, %d artifacts, %d updated artifacts from transform while passing through/dependency.", len(pathMappings), len(newArtifactsCreated), len(newArtifactsToProcess))
-		return pathMappings, newArtifactsCreated, newArtifactsToProcess
+		newArtifactsToProcess = run.artifactsToNotProcess
+		newArtifactsToProcess = append(newArtifactsToProcess, passedThroughUpdatedArtifacts...)
+		newArtifactsToProcess = append(newArtifactsToProcess, artifactsAlreadyPassedThrough...)
 	}
-	logrus.Debugf("Created %d pathMappings and %d artifacts from transform.", len(pathMappings), len(newArtifactsCreated))
-	return pathMappings, newArtifactsCreated, nil
+	logrus.Infof("Transformer %s Done", tConfig.Name)
+	return pathMappings, newArtifactsCreated, updatedArtifacts, newArtifactsToProcess, true
 }
 
-func runSingleTransform(artifactsToProcess, allArtifacts []transformertypes.Artifact, transformer Transformer, tconfig transformertypes.Transformer, env *environment.Environment, graph *graphtypes.Graph, iteration int) (newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) {
+func runSingleTransform(ctx context.Context, artifactsToProcess, allArtifacts []transformertypes.Artifact, transformer Transformer, tconfig transformertypes.Transformer, env *environment.Environment, graph *graphtypes.Graph, iteration int) (newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) {
 	logrus.Trace("runSingleTransform start")
 	defer logrus.Trace("runSingleTransform end")
+	startTime := time.Now()
+	defer func() {
+		recordInvocation(tconfig, iteration, startTime, time.Since(startTime), artifactsToProcess, newPathMappings, newArtifacts, err)
+	}()
 	if err := env.Reset(); err != nil {
 		return nil, nil, fmt.Errorf("failed to reset the environment: %+v Error: %q", env, err)
 	}
-	newPathMappings, newArtifacts, err = transformer.Transform(
-		*env.Encode(&artifactsToProcess).(*[]transformertypes.Artifact),
-		*env.Encode(&allArtifacts).(*[]transformertypes.Artifact),
-	)
+	newPathMappings, newArtifacts, err = executeSingleTransform(ctx, artifactsToProcess, allArtifacts, transformer, tconfig, env)
+	return finalizeSingleTransform(artifactsToProcess, tconfig, env, graph, iteration, newPathMappings, newArtifacts, err)
+}
+
+// executeSingleTransform runs the transformer on the artifacts and skips it when the context is done or the transformer times out.
+// It only touches the environment of the transformer, so different transformers can be executed in parallel.
+func executeSingleTransform(ctx context.Context, artifactsToProcess, allArtifacts []transformertypes.Artifact, transformer Transformer, tconfig transformertypes.Transformer, env *environment.Environment) (newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) {
+	events.Emit(events.TransformerStartedEventType, map[string]interface{}{
+		"transformer":    tconfig.Name,
+		"class":          tconfig.Spec.Class,
+		"inputArtifacts": len(artifactsToProcess),
+	})
+	ctx, cancel := getTransformerContext(ctx, tconfig)
+	defer cancel()
+	type result struct {
+		pathMappings []transformertypes.PathMapping
+		artifacts    []transformertypes.Artifact
+		err          error
+	}
+	artifactsToProcess = *env.Encode(&artifactsToProcess).(*[]transformertypes.Artifact)
+	allArtifacts = *env.Encode(&allArtifacts).(*[]transformertypes.Artifact)
+	resultChan := make(chan result, 1)
+	go func() {
+		r := result{}
+		if t, ok := transformer.(TransformerWithContext); ok {
+			r.pathMappings, r.artifacts, r.err = t.TransformWithContext(ctx, artifactsToProcess, allArtifacts)
+		} else {
+			r.pathMappings, r.artifacts, r.err = transformer.Transform(artifactsToProcess, allArtifacts)
+		}
+		resultChan <- r
+	}()
+	select {
+	case r := <-resultChan:
+		if r.err != nil && ctx.Err() != nil {
+			// the transformer stopped because the context is done
+			return nil, nil, getCancellationError(tconfig, ctx.Err())
+		}
+		return r.pathMappings, r.artifacts, r.err
+	case <-ctx.Done():
+		waitForCancelledTransformer(transformer, tconfig, resultChan)
+		return nil, nil, getCancellationError(tconfig, ctx.Err())
+	}
+}
+
+// waitForCancelledTransformer waits for a cancelled transformer to return, so that it can not modify
+// the environment, the artifacts or the QA answers after it has been skipped.
+// Transformers that take a context kill their commands when it is done and return promptly.
+// Other transformers can not be interrupted, so they are left running in the background and their results are discarded.
+func waitForCancelledTransformer[T any](transformer Transformer, tconfig transformertypes.Transformer, resultChan <-chan T) {
+	if _, ok := transformer.(TransformerWithContext); !ok {
+		logrus.Warnf("the transformer '%s' can not be interrupted. Skipping it while it is still running", tconfig.Name)
+		return
+	}
+	<-resultChan
+}
+
+// getTransformerContext returns a context that is cancelled when the timeout of the transformer expires
+func getTransformerContext(ctx context.Context, tconfig transformertypes.Transformer) (context.Context, context.CancelFunc) {
+	if tconfig.Spec.TimeoutDuration > 0 {
+		return context.WithTimeout(ctx, tconfig.Spec.TimeoutDuration)
+	}
+	return context.WithCancel(ctx)
+}
+
+func getCancellationError(tconfig transformertypes.Transformer, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("the transformer '%s' did not finish within the timeout of %s and was skipped. Error: %w", tconfig.Name, tconfig.Spec.TimeoutDuration, err)
+	}
+	return fmt.Errorf("the transformer '%s' was cancelled. Error: %w", tconfig.Name, err)
+}
+
+// directoryDetect runs the directory detect of the transformer and skips it when the context is done or the transformer times out
+func directoryDetect(ctx context.Context, transformer Transformer, tconfig transformertypes.Transformer, dir string) (map[string][]transformertypes.Artifact, error) {
+	ctx, cancel := getTransformerContext(ctx, tconfig)
+	defer cancel()
+	type result struct {
+		services map[string][]transformertypes.Artifact
+		err      error
+	}
+	resultChan := make(chan result, 1)
+	go func() {
+		r := result{}
+		if t, ok := transformer.(TransformerWithContext); ok {
+			r.services, r.err = t.DirectoryDetectWithContext(ctx, dir)
+		} else {
+			r.services, r.err = transformer.DirectoryDetect(dir)
+		}
+		resultChan <- r
+	}()
+	select {
+	case r := <-resultChan:
+		if r.err != nil && ctx.Err() != nil {
+			// the transformer stopped because the context is done
+			return nil, getCancellationError(tconfig, ctx.Err())
+		}
+		return r.services, r.err
+	case <-ctx.Done():
+		waitForCancelledTransformer(transformer, tconfig, resultChan)
+		return nil, getCancellationError(tconfig, ctx.Err())
+	}
+}
+
+// detectInDirectory runs the directory detection of the transformer, using the cached results when the directory and the transformer have not changed
+func detectInDirectory(ctx context.Context, transformer Transformer, dir string, cache *detectCache) (map[string][]transformertypes.Artifact, error) {
+	config, env := transformer.GetConfig()
+	cachePath := cache.getCachePath(dir, config)
+	if services, ok := cache.get(cachePath); ok {
+		logrus.Debugf("[%s] using the cached detection results for the directory %s", config.Name, dir)
+		emitDirectoryDetectedEvent(config, dir, services, true)
+		return services, nil
+	}
+	if err := env.Reset(); err != nil {
+		return nil, fmt.Errorf("failed to reset the environment for the transformer %s . Error: %w", config.Name, err)
+	}
+	services, err := directoryDetect(ctx, transformer, config, env.Encode(dir).(string))
+	if err != nil {
+		return nil, err
+	}
+	services = *env.Decode(&services).(*map[string][]transformertypes.Artifact)
+	cache.put(cachePath, services)
+	emitDirectoryDetectedEvent(config, dir, services, false)
+	return services, nil
+}
+
+// emitDirectoryDetectedEvent emits an event with the number of services a transformer detected in a directory
+func emitDirectoryDetectedEvent(config transformertypes.Transformer, dir string, services map[string][]transformertypes.Artifact, cached bool) {
+	numServices := 0
+	for _, artifacts := range services {
+		numServices += len(artifacts)
+	}
+	events.Emit(events.DirectoryDetectedEventType, map[string]interface{}{
+		"transformer": config.Name,
+		"directory":   dir,
+		"services":    numServices,
+		"cached":      cached,
+	})
+}
+
+// detectInDirectoryConcurrently runs the directory detection of the transformers with at most detectParallelism running at the same time.
+// The results are in the same order as the transformers.
+func detectInDirectoryConcurrently(ctx context.Context, transformers []Transformer, dir string, cache *detectCache) []detectOutput {
+	outputs := make([]detectOutput, len(transformers))
+	if detectParallelism <= 1 {
+		for i, transformer := range transformers {
+			outputs[i].services, outputs[i].err = detectInDirectory(ctx, transformer, dir, cache)
+		}
+		return outputs
+	}
+	semaphore := make(chan struct{}, detectParallelism)
+	wg := sync.WaitGroup{}
+	for i, transformer := range transformers {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, transformer Transformer) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			outputs[i].services, outputs[i].err = detectInDirectory(ctx, transformer, dir, cache)
+		}(i, transformer)
+	}
+	wg.Wait()
+	return outputs
+}
+
+// finalizeSingleTransform updates the graph and processes the output of a transformer run.
+// It must be called in the same order as the transformers, since it updates the shared graph and the output directory.
+func finalizeSingleTransform(artifactsToProcess []transformertypes.Artifact, tconfig transformertypes.Transformer, env *environment.Environment, graph *graphtypes.Graph, iteration int, newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	// logging
 	{
 		vertexName := fmt.Sprintf("iteration: %d\nclass: %s\nname: %s", iteration, tconfig.Spec.Class, tconfig.Name)
@@ -761,8 +1287,17 @@ func runSingleTransform(artifactsToProcess, allArtifacts []transformertypes.Arti
 		}
 	}
 	newArtifacts = filteredArtifacts
+	for _, newArtifact := range newArtifacts {
+		if err := newArtifact.ValidateConfigs(); err != nil {
+			return newPathMappings, newArtifacts, fmt.Errorf("the transformer '%s' produced an artifact with invalid configs. Error: %w", tconfig.Name, err)
+		}
+	}
 	newPathMappings = env.ProcessPathMappings(newPathMappings)
 	newPathMappings = *env.DownloadAndDecode(&newPathMappings, true).(*[]transformertypes.PathMapping)
+	provenance := getPathMappingProvenance(tconfig.Name, artifactsToProcess)
+	for i := range newPathMappings {
+		newPathMappings[i].Provenance = provenance
+	}
 	if err := processPathMappings(newPathMappings, env.Source, env.Output, false); err != nil {
 		return newPathMappings, newArtifacts, fmt.Errorf("failed to process the path mappings: %+v . Error: %q", newPathMappings, err)
 	}
@@ -771,6 +1306,11 @@ func runSingleTransform(artifactsToProcess, allArtifacts []transformertypes.Arti
 	return newPathMappings, newArtifacts, nil
 }
 
+// getArtifactKey returns a key that identifies the artifact, used to check if two transformers consume the same artifact
+func getArtifactKey(artifact transformertypes.Artifact) string {
+	return fmt.Sprintf("%s|%s|%v", artifact.Type, artifact.Name, artifact.Paths)
+}
+
 func getArtifactsToProcess(
 	newArtifactsToProcess,
 	allArtifacts []transformertypes.Artifact,
@@ -0,0 +1,332 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/environment"
+	environmenttypes "github.com/konveyor/move2kube/types/environment"
+	graphtypes "github.com/konveyor/move2kube/types/graph"
+	plantypes "github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// copyTransformer copies a file for every artifact it consumes and produces an artifact for each of them.
+// It sleeps before returning so that the transformers finish in a different order than they started.
+// With passThrough it returns the consumed artifacts as they are.
+type copyTransformer struct {
+	config      transformertypes.Transformer
+	env         *environment.Environment
+	sleep       time.Duration
+	passThrough bool
+}
+
+func (t *copyTransformer) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+	t.config = tc
+	t.env = env
+	return nil
+}
+
+func (t *copyTransformer) GetConfig() (transformertypes.Transformer, *environment.Environment) {
+	return t.config, t.env
+}
+
+func (t *copyTransformer) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+	return nil, nil
+}
+
+func (t *copyTransformer) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	time.Sleep(t.sleep)
+	if t.passThrough {
+		return nil, newArtifacts, nil
+	}
+	pathMappings := []transformertypes.PathMapping{}
+	artifacts := []transformertypes.Artifact{}
+	for _, artifact := range newArtifacts {
+		pathMappings = append(pathMappings, transformertypes.PathMapping{
+			Type:     transformertypes.DefaultPathMappingType,
+			SrcPath:  filepath.Join(t.env.Source, artifact.Name),
+			DestPath: filepath.Join(t.config.Name, artifact.Name),
+		})
+		artifacts = append(artifacts, transformertypes.Artifact{Name: t.config.Name + "-" + artifact.Name, Type: "Output"})
+	}
+	return pathMappings, artifacts, nil
+}
+
+func TestTransformConcurrently(t *testing.T) {
+	common.TempPath = t.TempDir()
+	sourceDir := t.TempDir()
+	inputArtifacts := []transformertypes.Artifact{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if err := os.WriteFile(filepath.Join(sourceDir, name), []byte(name), common.DefaultFilePermission); err != nil {
+			t.Fatalf("failed to write the source file %s . Error: %q", name, err)
+		}
+		inputArtifacts = append(inputArtifacts, transformertypes.Artifact{Name: name, Type: transformertypes.ArtifactType("Input-" + name)})
+	}
+	// the last transformer consumes the same artifact as the first one, so the transformers are run in two batches.
+	// T5 has a dependency selector that selects D, so the dependency processing adds D to the graph before T5.
+	transformerInputs := [][2]string{{"T1", "a"}, {"T5", "d"}, {"T2", "b"}, {"T3", "c"}, {"T4", "a"}, {"D", "d"}}
+
+	run := func(parallelism int) ([]transformertypes.PathMapping, []transformertypes.Artifact, *graphtypes.Graph) {
+		defer Reset()
+		outputDir := t.TempDir()
+		for i, transformerInput := range transformerInputs {
+			tc := transformertypes.Transformer{}
+			tc.Name = transformerInput[0]
+			tc.Spec.Class = "Copy"
+			tc.Spec.ConsumedArtifacts = map[transformertypes.ArtifactType]transformertypes.ArtifactProcessConfig{
+				transformertypes.ArtifactType("Input-" + transformerInput[1]): {},
+			}
+			tc.Spec.ProducedArtifacts = map[transformertypes.ArtifactType]transformertypes.ProducedArtifact{"Output": {}}
+			switch tc.Name {
+			case "T5":
+				tc.Spec.DependencySelector = labels.SelectorFromSet(labels.Set{"dependency": "true"})
+			case "D":
+				tc.Labels = map[string]string{"dependency": "true"}
+				tc.Spec.ConsumedArtifacts["Input-d"] = transformertypes.ArtifactProcessConfig{Mode: transformertypes.OnDemandPassThrough}
+				tc.Spec.ProducedArtifacts["Input-d"] = transformertypes.ProducedArtifact{}
+			}
+			env, err := environment.NewEnvironment(environment.EnvInfo{
+				Name:              tc.Name,
+				ProjectName:       "myproject",
+				Source:            sourceDir,
+				Output:            outputDir,
+				Context:           t.TempDir(),
+				EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+			}, nil)
+			if err != nil {
+				t.Fatalf("failed to create the environment. Error: %q", err)
+			}
+			tr := &copyTransformer{sleep: time.Duration(len(transformerInputs)-i) * 20 * time.Millisecond, passThrough: tc.Name == "D"}
+			if err := tr.Init(tc, env); err != nil {
+				t.Fatalf("failed to initialize the transformer. Error: %q", err)
+			}
+			transformers = append(transformers, tr)
+		}
+		graph := graphtypes.NewGraph()
+		graph.AddVertex("start", 0, nil)
+		artifacts := []transformertypes.Artifact{}
+		for _, artifact := range inputArtifacts {
+			artifact.Configs = map[transformertypes.ConfigType]interface{}{graphtypes.GraphSourceVertexKey: graph.SourceVertexId}
+			artifacts = append(artifacts, artifact)
+		}
+		pathMappings, newArtifacts, _ := transform(context.Background(), artifacts, artifacts, consume, nil, graph, 1, parallelism)
+		for i := range pathMappings {
+			// the same source files are copied to different output directories
+			pathMappings[i].SrcPath = filepath.Base(pathMappings[i].SrcPath)
+		}
+		return pathMappings, newArtifacts, graph
+	}
+
+	expectedPathMappings, expectedArtifacts, expectedGraph := run(1)
+	if len(expectedPathMappings) != len(transformerInputs)-1 || len(expectedArtifacts) != len(transformerInputs)-1 {
+		t.Fatalf("expected every transformer to produce a path mapping and an artifact. Actual: %+v %+v", expectedPathMappings, expectedArtifacts)
+	}
+	actualPathMappings, actualArtifacts, actualGraph := run(len(transformerInputs))
+	if !reflect.DeepEqual(actualPathMappings, expectedPathMappings) {
+		t.Errorf("the path mappings are different from the sequential run. Expected: %+v Actual: %+v", expectedPathMappings, actualPathMappings)
+	}
+	if !reflect.DeepEqual(actualArtifacts, expectedArtifacts) {
+		t.Errorf("the artifacts are different from the sequential run. Expected: %+v Actual: %+v", expectedArtifacts, actualArtifacts)
+	}
+	if !reflect.DeepEqual(actualGraph.Vertices, expectedGraph.Vertices) || !reflect.DeepEqual(actualGraph.Edges, expectedGraph.Edges) {
+		t.Errorf("the graph is different from the sequential run. Expected: %+v Actual: %+v", expectedGraph, actualGraph)
+	}
+}
+
+func TestTransformDryRun(t *testing.T) {
+	common.TempPath = t.TempDir()
+	workingDir := t.TempDir()
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get the working directory. Error: %q", err)
+	}
+	if err := os.Chdir(workingDir); err != nil {
+		t.Fatalf("failed to change the working directory. Error: %q", err)
+	}
+	defer os.Chdir(currentDir)
+	defer Reset()
+	sourceDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sourceDir, "a"), []byte("a"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write the source file. Error: %q", err)
+	}
+	outputDir := filepath.Join(workingDir, "myproject")
+	dryRunOutput, err := NewDryRunOutput(outputDir)
+	if err != nil {
+		t.Fatalf("failed to create the scratch output directory. Error: %q", err)
+	}
+	defer dryRunOutput.Remove()
+	tc := transformertypes.Transformer{}
+	tc.Name = "T1"
+	tc.Labels = map[string]string{transformertypes.LabelName: tc.Name}
+	tc.Spec.Class = "Copy"
+	tc.Spec.ConsumedArtifacts = map[transformertypes.ArtifactType]transformertypes.ArtifactProcessConfig{"Input-a": {}}
+	tc.Spec.ProducedArtifacts = map[transformertypes.ArtifactType]transformertypes.ProducedArtifact{"Output": {}}
+	env, err := environment.NewEnvironment(environment.EnvInfo{
+		Name:              tc.Name,
+		ProjectName:       "myproject",
+		Source:            sourceDir,
+		Output:            dryRunOutput.ScratchPath,
+		Context:           t.TempDir(),
+		EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create the environment. Error: %q", err)
+	}
+	tr := &copyTransformer{}
+	if err := tr.Init(tc, env); err != nil {
+		t.Fatalf("failed to initialize the transformer. Error: %q", err)
+	}
+	transformers = append(transformers, tr)
+	planArtifacts := []plantypes.PlanArtifact{{ServiceName: "a", TransformerName: tc.Name, Artifact: transformertypes.Artifact{Name: "a", Type: "Input-a"}}}
+
+	summary, err := Transform(context.Background(), planArtifacts, sourceDir, outputDir, -1, 1, dryRunOutput, false)
+	if err != nil {
+		t.Fatalf("failed to do a dry run. Error: %q", err)
+	}
+	if changes, ok := summary[tc.Name]; !ok || len(changes.Created) != 1 {
+		t.Fatalf("expected the dry run summary to have the file created by the transformer. Actual: %+v", summary)
+	}
+	entries, err := os.ReadDir(workingDir)
+	if err != nil {
+		t.Fatalf("failed to read the working directory. Error: %q", err)
+	}
+	if len(entries) != 0 {
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Fatalf("expected the dry run to not write anything. Actual: %+v", names)
+	}
+}
+
+// hangingTransformer runs a command that does not finish within the timeout of the transformer.
+// Without a context it sleeps instead, since it can not be interrupted.
+type hangingTransformer struct {
+	copyTransformer
+	hang     time.Duration
+	finished int32
+}
+
+func (t *hangingTransformer) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	defer atomic.StoreInt32(&t.finished, 1)
+	time.Sleep(t.hang)
+	return t.copyTransformer.Transform(newArtifacts, alreadySeenArtifacts)
+}
+
+func (t *hangingTransformer) isFinished() bool {
+	return atomic.LoadInt32(&t.finished) == 1
+}
+
+// hangingContextTransformer is a hangingTransformer that takes a context
+type hangingContextTransformer struct {
+	hangingTransformer
+}
+
+func (t *hangingContextTransformer) DirectoryDetectWithContext(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
+	return nil, nil
+}
+
+func (t *hangingContextTransformer) TransformWithContext(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	defer atomic.StoreInt32(&t.finished, 1)
+	if _, _, _, err := t.env.ExecWithContext(ctx, environmenttypes.Command{"sleep", "30"}, nil); err != nil {
+		return nil, nil, err
+	}
+	return t.copyTransformer.Transform(newArtifacts, alreadySeenArtifacts)
+}
+
+func TestTransformTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test uses the sleep command")
+	}
+	common.TempPath = t.TempDir()
+	sourceDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sourceDir, "a"), []byte("a"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write the source file. Error: %q", err)
+	}
+	testCases := []struct {
+		name        string
+		transformer interface {
+			Transformer
+			isFinished() bool
+		}
+		maxDuration  time.Duration
+		wantFinished bool
+	}{
+		{name: "command is killed", transformer: &hangingContextTransformer{}, maxDuration: 10 * time.Second, wantFinished: true},
+		{name: "transformer without context is skipped", transformer: &hangingTransformer{hang: 30 * time.Second}, maxDuration: 10 * time.Second, wantFinished: false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer Reset()
+			resetTransformReport(transformertypes.TransformReport{})
+			tc := transformertypes.Transformer{}
+			tc.Name = "T1"
+			tc.Spec.Class = "Hanging"
+			tc.Spec.TimeoutDuration = 100 * time.Millisecond
+			tc.Spec.ConsumedArtifacts = map[transformertypes.ArtifactType]transformertypes.ArtifactProcessConfig{"Input-a": {}}
+			tc.Spec.ProducedArtifacts = map[transformertypes.ArtifactType]transformertypes.ProducedArtifact{"Output": {}}
+			env, err := environment.NewEnvironment(environment.EnvInfo{
+				Name:              tc.Name,
+				ProjectName:       "myproject",
+				Source:            sourceDir,
+				Output:            t.TempDir(),
+				Context:           t.TempDir(),
+				EnvPlatformConfig: environmenttypes.EnvPlatformConfig{Platforms: []string{runtime.GOOS}},
+			}, nil)
+			if err != nil {
+				t.Fatalf("failed to create the environment. Error: %q", err)
+			}
+			if err := testCase.transformer.Init(tc, env); err != nil {
+				t.Fatalf("failed to initialize the transformer. Error: %q", err)
+			}
+			transformers = append(transformers, testCase.transformer)
+			graph := graphtypes.NewGraph()
+			graph.AddVertex("start", 0, nil)
+			artifacts := []transformertypes.Artifact{{
+				Name:    "a",
+				Type:    "Input-a",
+				Configs: map[transformertypes.ConfigType]interface{}{graphtypes.GraphSourceVertexKey: graph.SourceVertexId},
+			}}
+
+			startTime := time.Now()
+			pathMappings, newArtifacts, _ := transform(context.Background(), artifacts, artifacts, consume, nil, graph, 1, 1)
+			if duration := time.Since(startTime); duration > testCase.maxDuration {
+				t.Fatalf("expected the transformer to be stopped after the timeout. Actual duration: %s", duration)
+			}
+			if finished := testCase.transformer.isFinished(); finished != testCase.wantFinished {
+				t.Fatalf("expected the transformer to be finished when it was skipped: %t . Actual: %t", testCase.wantFinished, finished)
+			}
+			if len(pathMappings) != 0 || len(newArtifacts) != 0 {
+				t.Fatalf("expected the timed out transformer to be skipped. Actual: %+v %+v", pathMappings, newArtifacts)
+			}
+			if invocations := GetTransformReport().Invocations; len(invocations) != 1 || !invocations[0].TimedOut {
+				t.Fatalf("expected the transformer to be marked as timed out in the report. Actual: %+v", invocations)
+			}
+		})
+	}
+}
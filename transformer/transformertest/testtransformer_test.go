@@ -0,0 +1,122 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformertest_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/move2kube/assets"
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/lib"
+	"github.com/konveyor/move2kube/qaengine"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTestTransformer(t *testing.T) {
+	assetsFilePermissions := map[string]int{}
+	if err := yaml.Unmarshal([]byte(assets.AssetFilePermissions), &assetsFilePermissions); err != nil {
+		t.Fatalf("failed to unmarshal the assets permissions file as YAML. Error: %q", err)
+	}
+	assetsPath, tempPath, remoteTempPath, err := common.CreateAssetsData(assets.AssetsDir, assetsFilePermissions)
+	if err != nil {
+		t.Fatalf("failed to create the assets directory. Error: %q", err)
+	}
+	defer os.RemoveAll(tempPath)
+	defer os.RemoveAll(remoteTempPath)
+	common.AssetsPath, common.TempPath, common.RemoteTempPath = assetsPath, tempPath, remoteTempPath
+	qaengine.StartEngine(true, 0, true)
+
+	const transformerName = "DockerfileParser"
+	testCaseDir := filepath.Join("testdata", "dockerfileparser", "expose")
+
+	t.Run("output matches the golden files", func(t *testing.T) {
+		report, err := lib.TestTransformer(context.Background(), "", transformerName, []string{filepath.Dir(testCaseDir)}, false)
+		if err != nil {
+			t.Fatalf("failed to test the transformer. Error: %q", err)
+		}
+		if len(report.Results) != 1 {
+			t.Fatalf("expected 1 test case result. Actual: %+v", report.Results)
+		}
+		if result := report.Results[0]; result.Status != transformertypes.TransformerTestPassed {
+			t.Fatalf("expected the test case to pass. Actual: %+v", result)
+		}
+	})
+
+	t.Run("output differs from the golden files", func(t *testing.T) {
+		mutatedTestCaseDir := filepath.Join(t.TempDir(), "expose")
+		if err := copyDir(testCaseDir, mutatedTestCaseDir); err != nil {
+			t.Fatalf("failed to copy the test case. Error: %q", err)
+		}
+		dockerfilePath := filepath.Join(mutatedTestCaseDir, transformertypes.TransformerTestSourceDirName, "Dockerfile")
+		dockerfile, err := os.ReadFile(dockerfilePath)
+		if err != nil {
+			t.Fatalf("failed to read the Dockerfile. Error: %q", err)
+		}
+		if err := os.WriteFile(dockerfilePath, []byte(strings.ReplaceAll(string(dockerfile), "8080", "9090")), 0o644); err != nil {
+			t.Fatalf("failed to write the Dockerfile. Error: %q", err)
+		}
+		report, err := lib.TestTransformer(context.Background(), "", transformerName, []string{mutatedTestCaseDir}, false)
+		if err != nil {
+			t.Fatalf("failed to test the transformer. Error: %q", err)
+		}
+		if len(report.Results) != 1 || report.Results[0].Status != transformertypes.TransformerTestFailed || report.Results[0].Diff == "" {
+			t.Fatalf("expected the test case to fail with a diff. Actual: %+v", report.Results)
+		}
+		report, err = lib.TestTransformer(context.Background(), "", transformerName, []string{mutatedTestCaseDir}, true)
+		if err != nil {
+			t.Fatalf("failed to update the golden files. Error: %q", err)
+		}
+		if len(report.Results) != 1 || report.Results[0].Status != transformertypes.TransformerTestUpdated {
+			t.Fatalf("expected the golden files to be updated. Actual: %+v", report.Results)
+		}
+		report, err = lib.TestTransformer(context.Background(), "", transformerName, []string{mutatedTestCaseDir}, false)
+		if err != nil {
+			t.Fatalf("failed to test the transformer. Error: %q", err)
+		}
+		if len(report.Results) != 1 || report.Results[0].Status != transformertypes.TransformerTestPassed {
+			t.Fatalf("expected the test case to pass after the update. Actual: %+v", report.Results)
+		}
+	})
+
+	t.Run("unknown transformer", func(t *testing.T) {
+		if _, err := lib.TestTransformer(context.Background(), "", "NoSuchTransformer", []string{testCaseDir}, false); err == nil {
+			t.Fatalf("expected an error for a transformer that does not exist")
+		}
+	})
+}
+
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, relPath), common.DefaultDirectoryPermission)
+		}
+		return common.CopyFile(filepath.Join(dst, relPath), path)
+	})
+}
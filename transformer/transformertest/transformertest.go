@@ -0,0 +1,274 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformertest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/transformer"
+	graphtypes "github.com/konveyor/move2kube/types/graph"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	sourcePlaceholder  = "$SOURCE"
+	outputPlaceholder  = "$OUTPUT"
+	contextPlaceholder = "$CONTEXT"
+	tempPlaceholder    = "$TEMP"
+)
+
+// output is the normalized output of a transformer run, encoded as yaml
+type output struct {
+	pathMappings []byte
+	artifacts    []byte
+}
+
+// Run runs the transformer against each of the test case directories and compares the output with the golden files.
+// If update is true the golden files are overwritten with the output instead.
+// The transformers are initialized again for each test case, so any previously initialized transformers are destroyed.
+func Run(ctx context.Context, transformerName, transformerYamlPath string, testCaseDirs []string, update bool) transformertypes.TransformerTestReport {
+	report := transformertypes.TransformerTestReport{Transformer: transformerName}
+	for _, testCaseDir := range testCaseDirs {
+		report.Results = append(report.Results, runTestCase(ctx, transformerName, transformerYamlPath, testCaseDir, update))
+	}
+	return report
+}
+
+// FindTestCases returns the test case directories. A directory that does not contain an input artifacts file
+// is treated as a suite and its immediate sub directories that contain one are returned instead.
+func FindTestCases(dirs []string) ([]string, error) {
+	testCaseDirs := []string{}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, transformertypes.TransformerTestInputArtifactsFileName)); err == nil {
+			testCaseDirs = append(testCaseDirs, dir)
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return testCaseDirs, fmt.Errorf("failed to read the test case directory '%s' . Error: %w", dir, err)
+		}
+		found := false
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			subDir := filepath.Join(dir, entry.Name())
+			if _, err := os.Stat(filepath.Join(subDir, transformertypes.TransformerTestInputArtifactsFileName)); err == nil {
+				testCaseDirs = append(testCaseDirs, subDir)
+				found = true
+			}
+		}
+		if !found {
+			return testCaseDirs, fmt.Errorf("the directory '%s' does not contain a '%s' file or any test case directories", dir, transformertypes.TransformerTestInputArtifactsFileName)
+		}
+	}
+	return testCaseDirs, nil
+}
+
+func runTestCase(ctx context.Context, transformerName, transformerYamlPath, testCaseDir string, update bool) transformertypes.TransformerTestResult {
+	result := transformertypes.TransformerTestResult{TestCase: testCaseDir}
+	actual, err := runTransformer(ctx, transformerName, transformerYamlPath, testCaseDir)
+	if err != nil {
+		result.Status = transformertypes.TransformerTestError
+		result.Error = err.Error()
+		return result
+	}
+	goldenDir := filepath.Join(testCaseDir, transformertypes.TransformerTestGoldenDirName)
+	if update {
+		if err := writeGolden(goldenDir, actual); err != nil {
+			result.Status = transformertypes.TransformerTestError
+			result.Error = err.Error()
+			return result
+		}
+		result.Status = transformertypes.TransformerTestUpdated
+		return result
+	}
+	diff, err := compareWithGolden(goldenDir, actual)
+	if err != nil {
+		result.Status = transformertypes.TransformerTestError
+		result.Error = err.Error()
+		return result
+	}
+	if diff != "" {
+		result.Status = transformertypes.TransformerTestFailed
+		result.Diff = diff
+		return result
+	}
+	result.Status = transformertypes.TransformerTestPassed
+	return result
+}
+
+// runTransformer initializes only the transformer under test using the source directory of the test case and runs it once on the input artifacts
+func runTransformer(ctx context.Context, transformerName, transformerYamlPath, testCaseDir string) (output, error) {
+	transformer.Reset()
+	defer transformer.Reset()
+	testCaseDir, err := filepath.Abs(testCaseDir)
+	if err != nil {
+		return output{}, fmt.Errorf("failed to make the test case directory path '%s' absolute. Error: %w", testCaseDir, err)
+	}
+	outputPath, err := os.MkdirTemp(common.TempPath, "transformertest-output-*")
+	if err != nil {
+		return output{}, fmt.Errorf("failed to create a temporary output directory. Error: %w", err)
+	}
+	defer os.RemoveAll(outputPath)
+	sourcePath := filepath.Join(testCaseDir, transformertypes.TransformerTestSourceDirName)
+	if _, err := os.Stat(sourcePath); err != nil {
+		if !os.IsNotExist(err) {
+			return output{}, fmt.Errorf("failed to stat the source directory '%s' . Error: %w", sourcePath, err)
+		}
+		logrus.Debugf("the test case '%s' has no source directory. Using an empty one.", testCaseDir)
+		if sourcePath, err = os.MkdirTemp(common.TempPath, "transformertest-source-*"); err != nil {
+			return output{}, fmt.Errorf("failed to create a temporary source directory. Error: %w", err)
+		}
+		defer os.RemoveAll(sourcePath)
+	}
+	contextPath := filepath.Dir(transformerYamlPath)
+	inputArtifactsPath := filepath.Join(testCaseDir, transformertypes.TransformerTestInputArtifactsFileName)
+	inputArtifacts, err := readInputArtifacts(inputArtifactsPath, map[string]string{sourcePlaceholder: sourcePath, contextPlaceholder: contextPath})
+	if err != nil {
+		return output{}, fmt.Errorf("failed to read the input artifacts from the file '%s' . Error: %w", inputArtifactsPath, err)
+	}
+	projectName := filepath.Base(testCaseDir)
+	if _, err := transformer.InitTransformers(map[string]string{transformerName: transformerYamlPath}, labels.Everything(), sourcePath, outputPath, projectName, true, false); err != nil {
+		return output{}, fmt.Errorf("failed to initialize the transformer '%s' . Error: %w", transformerName, err)
+	}
+	pathMappings, artifacts, err := transformer.RunTransformer(ctx, transformerName, inputArtifacts)
+	if err != nil {
+		return output{}, fmt.Errorf("failed to run the transformer '%s' . Error: %w", transformerName, err)
+	}
+	replacements := map[string]string{
+		sourcePath:      sourcePlaceholder,
+		outputPath:      outputPlaceholder,
+		contextPath:     contextPlaceholder,
+		common.TempPath: tempPlaceholder,
+	}
+	for i := range pathMappings {
+		pathMappings[i].Provenance = nil
+	}
+	for i, artifact := range artifacts {
+		configs := map[transformertypes.ConfigType]interface{}{}
+		for k, v := range artifact.Configs {
+			if k == graphtypes.GraphSourceVertexKey || k == graphtypes.GraphProcessVertexKey {
+				continue
+			}
+			configs[k] = v
+		}
+		artifacts[i].Configs = configs
+	}
+	normalizedPathMappings, err := normalize(pathMappings, replacements)
+	if err != nil {
+		return output{}, fmt.Errorf("failed to normalize the path mappings. Error: %w", err)
+	}
+	normalizedArtifacts, err := normalize(artifacts, replacements)
+	if err != nil {
+		return output{}, fmt.Errorf("failed to normalize the artifacts. Error: %w", err)
+	}
+	return output{pathMappings: normalizedPathMappings, artifacts: normalizedArtifacts}, nil
+}
+
+// readInputArtifacts reads the input artifacts after replacing the placeholders with the actual paths
+func readInputArtifacts(inputArtifactsPath string, placeholders map[string]string) ([]transformertypes.Artifact, error) {
+	inputArtifactsBytes, err := os.ReadFile(inputArtifactsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the file. Error: %w", err)
+	}
+	inputArtifactsStr := string(inputArtifactsBytes)
+	for placeholder, path := range placeholders {
+		inputArtifactsStr = strings.ReplaceAll(inputArtifactsStr, placeholder, path)
+	}
+	inputArtifacts := []transformertypes.Artifact{}
+	if err := yaml.Unmarshal([]byte(inputArtifactsStr), &inputArtifacts); err != nil {
+		return nil, fmt.Errorf("failed to parse the file as yaml. Error: %w", err)
+	}
+	return inputArtifacts, nil
+}
+
+// normalize encodes the object as yaml and replaces the absolute paths that change between runs with placeholders
+func normalize(obj interface{}, replacements map[string]string) ([]byte, error) {
+	yamlBytes, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the object as yaml. Error: %w", err)
+	}
+	paths := []string{}
+	for path := range replacements {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	// replace the longest paths first since the other paths can be prefixes of them
+	sort.Slice(paths, func(i, j int) bool { return len(paths[i]) > len(paths[j]) })
+	yamlStr := string(yamlBytes)
+	for _, path := range paths {
+		yamlStr = strings.ReplaceAll(yamlStr, path, replacements[path])
+	}
+	return []byte(yamlStr), nil
+}
+
+func writeGolden(goldenDir string, actual output) error {
+	if err := os.MkdirAll(goldenDir, common.DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the golden directory '%s' . Error: %w", goldenDir, err)
+	}
+	goldenPathMappingsPath := filepath.Join(goldenDir, transformertypes.TransformerTestGoldenPathMappingsFileName)
+	if err := os.WriteFile(goldenPathMappingsPath, actual.pathMappings, common.DefaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write the golden file '%s' . Error: %w", goldenPathMappingsPath, err)
+	}
+	goldenArtifactsPath := filepath.Join(goldenDir, transformertypes.TransformerTestGoldenArtifactsFileName)
+	if err := os.WriteFile(goldenArtifactsPath, actual.artifacts, common.DefaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write the golden file '%s' . Error: %w", goldenArtifactsPath, err)
+	}
+	return nil
+}
+
+// compareWithGolden returns a diff between the golden files and the actual output. The diff is empty if they match.
+func compareWithGolden(goldenDir string, actual output) (string, error) {
+	diffs := []string{}
+	for _, golden := range []struct {
+		fileName string
+		actual   []byte
+	}{
+		{fileName: transformertypes.TransformerTestGoldenPathMappingsFileName, actual: actual.pathMappings},
+		{fileName: transformertypes.TransformerTestGoldenArtifactsFileName, actual: actual.artifacts},
+	} {
+		goldenPath := filepath.Join(goldenDir, golden.fileName)
+		goldenBytes, err := os.ReadFile(goldenPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the golden file '%s' . Run with --update to create it. Error: %w", goldenPath, err)
+		}
+		var expectedObj, actualObj interface{}
+		if err := yaml.Unmarshal(goldenBytes, &expectedObj); err != nil {
+			return "", fmt.Errorf("failed to parse the golden file '%s' as yaml. Error: %w", goldenPath, err)
+		}
+		if err := yaml.Unmarshal(golden.actual, &actualObj); err != nil {
+			return "", fmt.Errorf("failed to parse the output of the transformer as yaml. Error: %w", err)
+		}
+		if diff := cmp.Diff(expectedObj, actualObj, cmpopts.EquateEmpty()); diff != "" {
+			diffs = append(diffs, fmt.Sprintf("%s (-expected +actual):\n%s", golden.fileName, diff))
+		}
+	}
+	return strings.Join(diffs, "\n"), nil
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformertest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+)
+
+func TestGolden(t *testing.T) {
+	pathMappings := []transformertypes.PathMapping{{Type: transformertypes.DefaultPathMappingType, SrcPath: "/src/dir/a.txt", DestPath: "out/a.txt"}}
+	artifacts := []transformertypes.Artifact{{Name: "a", Type: "A", Paths: map[transformertypes.PathType][]string{"ServiceDirPath": {"/src/dir"}}}}
+	replacements := map[string]string{"/src": "$TEMP", "/src/dir": sourcePlaceholder}
+	actual := output{}
+	var err error
+	if actual.pathMappings, err = normalize(pathMappings, replacements); err != nil {
+		t.Fatalf("failed to normalize the path mappings. Error: %q", err)
+	}
+	if actual.artifacts, err = normalize(artifacts, replacements); err != nil {
+		t.Fatalf("failed to normalize the artifacts. Error: %q", err)
+	}
+	goldenDir := filepath.Join(t.TempDir(), transformertypes.TransformerTestGoldenDirName)
+	if _, err := compareWithGolden(goldenDir, actual); err == nil {
+		t.Fatalf("expected an error when the golden files are missing")
+	}
+	if err := writeGolden(goldenDir, actual); err != nil {
+		t.Fatalf("failed to write the golden files. Error: %q", err)
+	}
+	goldenPathMappings, err := os.ReadFile(filepath.Join(goldenDir, transformertypes.TransformerTestGoldenPathMappingsFileName))
+	if err != nil {
+		t.Fatalf("failed to read the golden path mappings. Error: %q", err)
+	}
+	expected := "- type: Default\n  sourcePath: $SOURCE/a.txt\n  destinationPath: out/a.txt\n  templateConfig: null\n"
+	if string(goldenPathMappings) != expected {
+		t.Fatalf("the paths were not normalized. Expected:\n%s\nActual:\n%s", expected, goldenPathMappings)
+	}
+	if diff, err := compareWithGolden(goldenDir, actual); err != nil || diff != "" {
+		t.Fatalf("expected the output to match the golden files. Diff: %s Error: %v", diff, err)
+	}
+	artifacts[0].Name = "b"
+	if actual.artifacts, err = normalize(artifacts, replacements); err != nil {
+		t.Fatalf("failed to normalize the artifacts. Error: %q", err)
+	}
+	if diff, err := compareWithGolden(goldenDir, actual); err != nil || diff == "" {
+		t.Fatalf("expected a diff after the artifacts changed. Error: %v", err)
+	}
+}
+
+func TestFindTestCases(t *testing.T) {
+	suiteDir := t.TempDir()
+	for _, name := range []string{"case2", "case1"} {
+		if err := os.MkdirAll(filepath.Join(suiteDir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(suiteDir, name, transformertypes.TransformerTestInputArtifactsFileName), []byte("[]"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(suiteDir, "notacase"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	testCaseDirs, err := FindTestCases([]string{suiteDir, filepath.Join(suiteDir, "case2")})
+	if err != nil {
+		t.Fatalf("failed to find the test cases. Error: %q", err)
+	}
+	expected := []string{filepath.Join(suiteDir, "case1"), filepath.Join(suiteDir, "case2"), filepath.Join(suiteDir, "case2")}
+	if len(testCaseDirs) != len(expected) {
+		t.Fatalf("expected the test cases %+v . Actual: %+v", expected, testCaseDirs)
+	}
+	for i := range expected {
+		if testCaseDirs[i] != expected[i] {
+			t.Fatalf("expected the test cases %+v . Actual: %+v", expected, testCaseDirs)
+		}
+	}
+	if _, err := FindTestCases([]string{filepath.Join(suiteDir, "notacase")}); err == nil {
+		t.Fatalf("expected an error for a directory without test cases")
+	}
+}
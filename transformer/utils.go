@@ -22,7 +22,9 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/konveyor/move2kube/common"
 	"github.com/konveyor/move2kube/common/deepcopy"
@@ -59,6 +61,12 @@ func getTransformerConfig(transformerYamlPath string) (transformertypes.Transfor
 		logrus.Errorf("failed to parse the dependency selector for the transformer '%s' , Ignoring selector: %+v . Error: %q", tc.Name, tc.Spec.Dependency, err)
 		tc.Spec.DependencySelector = nil
 	}
+	if tc.Spec.Timeout != "" {
+		if tc.Spec.TimeoutDuration, err = time.ParseDuration(tc.Spec.Timeout); err != nil || tc.Spec.TimeoutDuration < 0 {
+			logrus.Errorf("failed to parse the timeout for the transformer '%s' , Ignoring timeout: %s . Error: %q", tc.Name, tc.Spec.Timeout, err)
+			tc.Spec.TimeoutDuration = 0
+		}
+	}
 	// TODO: Add check for consistency between consumes and produces
 	return tc, nil
 }
@@ -230,9 +238,12 @@ func getNamedAndUnNamedServicesLogMessage(services map[string][]plantypes.PlanAr
 	return fmt.Sprintf("Identified %d named services and %d to-be-named services", nnservices, nuntransformers)
 }
 
-func getFilteredTransformers(transformerYamlPaths map[string]string, selector labels.Selector, logError bool) map[string]transformertypes.Transformer {
+// getFilteredTransformers returns the transformers that match the selector and are not overridden by other transformers.
+// It also returns the names of the transformers that each transformer overrides.
+func getFilteredTransformers(transformerYamlPaths map[string]string, selector labels.Selector, logError bool) (map[string]transformertypes.Transformer, map[string][]string, map[string]error) {
 	filteredTransformerConfigs := map[string]transformertypes.Transformer{}
-	overrideSelectors := []labels.Selector{}
+	incompatibleTransformers := map[string]error{}
+	overrideSelectors := map[string]labels.Selector{}
 	for transformerName, transformerYamlPath := range transformerYamlPaths {
 		tc, err := getTransformerConfig(transformerYamlPath)
 		if err != nil {
@@ -253,8 +264,13 @@ func getFilteredTransformers(transformerYamlPaths map[string]string, selector la
 			logrus.Debugf("Ignoring the transformer '%s' because its labels don't match the selector", transformerName)
 			continue
 		}
+		if err := checkTransformerRequirements(tc); err != nil {
+			logrus.Debugf("Ignoring the transformer '%s' since its requirements are not satisfied. Error: %q", transformerName, err)
+			incompatibleTransformers[tc.Name] = err
+			continue
+		}
 		if tc.Spec.OverrideSelector != nil {
-			overrideSelectors = append(overrideSelectors, tc.Spec.OverrideSelector)
+			overrideSelectors[tc.Name] = tc.Spec.OverrideSelector
 		}
 		if _, ok := transformerTypes[tc.Spec.Class]; ok {
 			filteredTransformerConfigs[tc.Name] = tc
@@ -262,6 +278,7 @@ func getFilteredTransformers(transformerYamlPaths map[string]string, selector la
 		}
 		logrus.Errorf("Ignoring the transformer '%s' since the transformer class '%s' was not found", transformerName, tc.Spec.Class)
 	}
+	overrides := map[string][]string{}
 	transformerConfigs := map[string]transformertypes.Transformer{}
 	for transformerName, tc := range filteredTransformerConfigs {
 		if ot, ok := transformerConfigs[tc.Name]; ok {
@@ -271,17 +288,20 @@ func getFilteredTransformers(transformerYamlPaths map[string]string, selector la
 			)
 		}
 		ignore := false
-		for _, overrideSelector := range overrideSelectors {
+		for overridingTransformerName, overrideSelector := range overrideSelectors {
 			if overrideSelector.Matches(labels.Set(tc.Labels)) {
 				ignore = true
-				break
+				overrides[overridingTransformerName] = append(overrides[overridingTransformerName], transformerName)
 			}
 		}
 		if !ignore {
 			transformerConfigs[transformerName] = tc
 		}
 	}
-	return transformerConfigs
+	for overridingTransformerName := range overrides {
+		sort.Strings(overrides[overridingTransformerName])
+	}
+	return transformerConfigs, overrides, incompatibleTransformers
 }
 
 func postProcessArtifacts(artifacts []transformertypes.Artifact, t transformertypes.Transformer) []transformertypes.Artifact {
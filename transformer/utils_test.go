@@ -0,0 +1,64 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/konveyor/move2kube/common"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestGetFilteredTransformers(t *testing.T) {
+	dir := t.TempDir()
+	transformerYamlPaths := map[string]string{}
+	for name, override := range map[string]string{
+		"Kubernetes":       "",
+		"Knative":          "",
+		"CustomKubernetes": "Kubernetes",
+	} {
+		transformerYaml := fmt.Sprintf(`apiVersion: move2kube.konveyor.io/v1alpha1
+kind: Transformer
+metadata:
+  name: %s
+  labels:
+    name: %s
+spec:
+  class: Kubernetes
+`, name, name)
+		if override != "" {
+			transformerYaml += fmt.Sprintf("  override:\n    matchLabels:\n      name: %s\n", override)
+		}
+		transformerYamlPath := filepath.Join(dir, name+".yaml")
+		if err := os.WriteFile(transformerYamlPath, []byte(transformerYaml), common.DefaultFilePermission); err != nil {
+			t.Fatalf("Error: %q", err)
+		}
+		transformerYamlPaths[name] = transformerYamlPath
+	}
+	transformerConfigs, overrides, _ := getFilteredTransformers(transformerYamlPaths, labels.Everything(), true)
+	names := getSortedTransformerNames(transformerConfigs)
+	if diff := cmp.Diff([]string{"CustomKubernetes", "Knative"}, names); diff != "" {
+		t.Fatalf("the overridden transformer was not removed. Differences:\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string][]string{"CustomKubernetes": {"Kubernetes"}}, overrides); diff != "" {
+		t.Fatalf("the overrides are different. Differences:\n%s", diff)
+	}
+}
@@ -0,0 +1,352 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	containertypes "github.com/konveyor/move2kube/environment/container"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Validate initializes the transformers found in the assets directory and statically checks the graph they form.
+// Nothing is detected or transformed.
+func Validate(assetsPath string, selector labels.Selector) (transformertypes.ValidationReport, error) {
+	transformerYamlPaths, err := GetTransformerYamlPaths(assetsPath)
+	if err != nil {
+		return transformertypes.ValidationReport{}, err
+	}
+	deselectedTransformers, err := InitTransformers(transformerYamlPaths, selector, "", "", "", true, false)
+	if err != nil {
+		return transformertypes.ValidationReport{}, fmt.Errorf("failed to initialize the transformers. Error: %w", err)
+	}
+	loadedConfigs := map[string]transformertypes.Transformer{}
+	for _, transformerYamlPath := range transformerYamlPaths {
+		tc, err := getTransformerConfig(transformerYamlPath)
+		if err != nil {
+			logrus.Debugf("failed to load the transformer config file at path '%s' . Error: %q", transformerYamlPath, err)
+			continue
+		}
+		if selector.Matches(labels.Set(tc.Labels)) {
+			loadedConfigs[tc.Name] = tc
+		}
+	}
+	selectedConfigs, _, _ := getFilteredTransformers(transformerYamlPaths, selector, false)
+	for name := range deselectedTransformers {
+		delete(selectedConfigs, name)
+	}
+	initializedConfigs := map[string]transformertypes.Transformer{}
+	for _, transformer := range transformers {
+		tc, _ := transformer.GetConfig()
+		initializedConfigs[tc.Name] = tc
+	}
+	return validateTransformerConfigs(loadedConfigs, selectedConfigs, initializedConfigs, incompatibleTransformers, transformerInitErrors), nil
+}
+
+// validateTransformerConfigs checks the graph formed by the consumed and produced artifact types and the selectors of the transformers.
+// loaded contains all the transformers, selected contains the ones that were not overridden and initialized contains the ones that could be initialized.
+func validateTransformerConfigs(loaded, selected, initialized map[string]transformertypes.Transformer, incompatible, initErrors map[string]error) transformertypes.ValidationReport {
+	report := transformertypes.ValidationReport{}
+	addIssue := func(severity transformertypes.ValidationSeverity, kind transformertypes.ValidationIssueKind, transformerNames []string, artifactType transformertypes.ArtifactType, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, transformertypes.ValidationIssue{
+			Severity:     severity,
+			Kind:         kind,
+			Transformers: transformerNames,
+			ArtifactType: artifactType,
+			Message:      fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, name := range getSortedTransformerNames(loaded) {
+		if _, ok := selected[name]; ok {
+			continue
+		}
+		if err, ok := incompatible[name]; ok {
+			addIssue(transformertypes.ValidationWarning, transformertypes.IncompatibleVersionIssue, []string{name}, "", "the transformer '%s' was disabled. Error: %s", name, err)
+		}
+	}
+	for _, name := range getSortedTransformerNames(selected) {
+		if _, ok := initialized[name]; ok {
+			continue
+		}
+		err, ok := initErrors[name]
+		if !ok {
+			err = fmt.Errorf("the transformer class '%s' was not found", selected[name].Spec.Class)
+		}
+		if errors.Is(err, containertypes.ErrNoContainerRuntime) {
+			addIssue(transformertypes.ValidationWarning, transformertypes.InitFailedIssue, []string{name}, "", "the transformer '%s' needs a container runtime and none was found", name)
+			continue
+		}
+		addIssue(transformertypes.ValidationError, transformertypes.InitFailedIssue, []string{name}, "", "the transformer '%s' could not be initialized. Error: %s", name, err)
+	}
+	names := getSortedTransformerNames(initialized)
+
+	// selectors
+	for _, name := range getSortedTransformerNames(loaded) {
+		tc := loaded[name]
+		if tc.Spec.OverrideSelector == nil || tc.Spec.OverrideSelector.Empty() {
+			continue
+		}
+		overridden := []string{}
+		for _, otherName := range getSortedTransformerNames(loaded) {
+			other := loaded[otherName]
+			if !tc.Spec.OverrideSelector.Matches(labels.Set(other.Labels)) {
+				continue
+			}
+			if otherName == name {
+				addIssue(transformertypes.ValidationError, transformertypes.SelectorConflictIssue, []string{name}, "", "the override selector of the transformer '%s' matches the transformer itself, so it will never run", name)
+				continue
+			}
+			overridden = append(overridden, otherName)
+			if other.Spec.OverrideSelector != nil && !other.Spec.OverrideSelector.Empty() && other.Spec.OverrideSelector.Matches(labels.Set(tc.Labels)) && name < otherName {
+				addIssue(transformertypes.ValidationError, transformertypes.SelectorConflictIssue, []string{name, otherName}, "", "the transformers '%s' and '%s' override each other, so neither of them will run", name, otherName)
+			}
+		}
+		if len(overridden) == 0 {
+			addIssue(transformertypes.ValidationWarning, transformertypes.SelectorConflictIssue, []string{name}, "", "the override selector '%s' of the transformer '%s' does not match any transformer", tc.Spec.OverrideSelector, name)
+		}
+	}
+	for _, name := range names {
+		tc := initialized[name]
+		if tc.Spec.DependencySelector == nil || tc.Spec.DependencySelector.Empty() {
+			continue
+		}
+		dependencies := []string{}
+		for _, otherName := range names {
+			if tc.Spec.DependencySelector.Matches(labels.Set(initialized[otherName].Labels)) {
+				dependencies = append(dependencies, otherName)
+			}
+		}
+		if notRunning := getSortedOverriddenDependencies(tc.Spec.DependencySelector, loaded, initialized); len(notRunning) > 0 {
+			addIssue(transformertypes.ValidationWarning, transformertypes.SelectorConflictIssue, append([]string{name}, notRunning...), "", "the dependency selector '%s' of the transformer '%s' matches the transformers %v which will not run", tc.Spec.DependencySelector, name, notRunning)
+		}
+		if len(dependencies) == 0 {
+			addIssue(transformertypes.ValidationError, transformertypes.SelectorConflictIssue, []string{name}, "", "the dependency selector '%s' of the transformer '%s' does not match any transformer", tc.Spec.DependencySelector, name)
+			continue
+		}
+		for _, dependency := range dependencies {
+			if dependency == name {
+				addIssue(transformertypes.ValidationError, transformertypes.SelectorConflictIssue, []string{name}, "", "the dependency selector of the transformer '%s' matches the transformer itself", name)
+			}
+		}
+	}
+
+	// consumed and produced artifact types
+	producers := map[transformertypes.ArtifactType][]string{}
+	consumers := map[transformertypes.ArtifactType][]string{}
+	wildcardProducers := []string{}
+	for _, name := range names {
+		for _, artifactType := range getProducedArtifactTypes(initialized[name]) {
+			if artifactType == ALLOW_ALL_ARTIFACT_TYPES {
+				wildcardProducers = append(wildcardProducers, name)
+				continue
+			}
+			producers[artifactType] = append(producers[artifactType], name)
+		}
+		for _, artifactType := range getConsumedArtifactTypes(initialized[name]) {
+			consumers[artifactType] = append(consumers[artifactType], name)
+		}
+	}
+	for _, name := range names {
+		tc := initialized[name]
+		if tc.Spec.DirectoryDetect.Levels != 0 {
+			// the artifacts found during planning are given to the transformer that found them
+			continue
+		}
+		for _, artifactType := range getConsumedArtifactTypes(tc) {
+			if len(producers[artifactType]) == 0 && len(wildcardProducers) == 0 {
+				addIssue(transformertypes.ValidationWarning, transformertypes.UnproducedArtifactTypeIssue, []string{name}, artifactType, "the transformer '%s' consumes the artifact type '%s' but no transformer produces it", name, artifactType)
+			}
+		}
+	}
+	for _, name := range names {
+		for _, artifactType := range getProducedArtifactTypes(initialized[name]) {
+			if artifactType != ALLOW_ALL_ARTIFACT_TYPES && len(consumers[artifactType]) == 0 {
+				addIssue(transformertypes.ValidationInfo, transformertypes.UnconsumedArtifactTypeIssue, []string{name}, artifactType, "the transformer '%s' produces the artifact type '%s' but no transformer consumes it", name, artifactType)
+			}
+		}
+	}
+
+	// reachability
+	reachable := map[string]bool{}
+	queue := []string{}
+	for _, name := range names {
+		tc := initialized[name]
+		if tc.Spec.InvokedByDefault.Enabled || tc.Spec.DirectoryDetect.Levels != 0 {
+			reachable[name] = true
+			queue = append(queue, name)
+		}
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		tc := initialized[name]
+		next := []string{}
+		for _, artifactType := range getProducedArtifactTypes(tc) {
+			if artifactType == ALLOW_ALL_ARTIFACT_TYPES {
+				next = append(next, names...)
+				continue
+			}
+			next = append(next, consumers[artifactType]...)
+		}
+		if tc.Spec.DependencySelector != nil && !tc.Spec.DependencySelector.Empty() {
+			for _, otherName := range names {
+				if tc.Spec.DependencySelector.Matches(labels.Set(initialized[otherName].Labels)) {
+					next = append(next, otherName)
+				}
+			}
+		}
+		for _, otherName := range next {
+			if !reachable[otherName] {
+				reachable[otherName] = true
+				queue = append(queue, otherName)
+			}
+		}
+	}
+	for _, name := range names {
+		if !reachable[name] {
+			addIssue(transformertypes.ValidationWarning, transformertypes.UnreachableTransformerIssue, []string{name}, "", "the transformer '%s' is not invoked by default, does not detect services and consumes no artifact type that a reachable transformer produces", name)
+		}
+	}
+
+	// cycles
+	edges := map[string][]string{}
+	for _, name := range names {
+		for _, artifactType := range getProducedArtifactTypes(initialized[name]) {
+			if artifactType == ALLOW_ALL_ARTIFACT_TYPES {
+				continue
+			}
+			edges[name] = append(edges[name], consumers[artifactType]...)
+		}
+	}
+	for _, cycle := range getCycles(names, edges) {
+		addIssue(transformertypes.ValidationWarning, transformertypes.CycleIssue, cycle, "", "the transformers [%s] produce artifacts that they consume in a cycle. Make sure the cycle ends or the transformation will stop at --max-iterations", strings.Join(cycle, ", "))
+	}
+	return report
+}
+
+// getSortedOverriddenDependencies returns the transformers that match the dependency selector but were not initialized
+func getSortedOverriddenDependencies(dependencySelector labels.Selector, loaded, initialized map[string]transformertypes.Transformer) []string {
+	notInitialized := []string{}
+	for _, name := range getSortedTransformerNames(loaded) {
+		if _, ok := initialized[name]; ok {
+			continue
+		}
+		if dependencySelector.Matches(labels.Set(loaded[name].Labels)) {
+			notInitialized = append(notInitialized, name)
+		}
+	}
+	return notInitialized
+}
+
+func getSortedTransformerNames(tcs map[string]transformertypes.Transformer) []string {
+	names := []string{}
+	for name := range tcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getProducedArtifactTypes returns the artifact types that the transformer produces after applying changeTypeTo
+func getProducedArtifactTypes(tc transformertypes.Transformer) []transformertypes.ArtifactType {
+	artifactTypes := []transformertypes.ArtifactType{}
+	for artifactType, producedArtifact := range tc.Spec.ProducedArtifacts {
+		if producedArtifact.Disabled {
+			continue
+		}
+		if producedArtifact.ChangeTypeTo != "" {
+			artifactType = producedArtifact.ChangeTypeTo
+		}
+		artifactTypes = append(artifactTypes, artifactType)
+	}
+	sort.Slice(artifactTypes, func(i, j int) bool { return artifactTypes[i] < artifactTypes[j] })
+	return artifactTypes
+}
+
+// getConsumedArtifactTypes returns the artifact types that the transformer consumes
+func getConsumedArtifactTypes(tc transformertypes.Transformer) []transformertypes.ArtifactType {
+	artifactTypes := []transformertypes.ArtifactType{}
+	for artifactType, consumedArtifact := range tc.Spec.ConsumedArtifacts {
+		if !consumedArtifact.Disabled {
+			artifactTypes = append(artifactTypes, artifactType)
+		}
+	}
+	sort.Slice(artifactTypes, func(i, j int) bool { return artifactTypes[i] < artifactTypes[j] })
+	return artifactTypes
+}
+
+// getCycles returns the strongly connected components of the graph that contain a cycle, using Tarjan's algorithm
+func getCycles(names []string, edges map[string][]string) [][]string {
+	index := 0
+	indices := map[string]int{}
+	lowLinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	cycles := [][]string{}
+	var strongConnect func(string)
+	strongConnect = func(name string) {
+		indices[name] = index
+		lowLinks[name] = index
+		index++
+		stack = append(stack, name)
+		onStack[name] = true
+		selfLoop := false
+		for _, next := range edges[name] {
+			if next == name {
+				selfLoop = true
+			}
+			if _, ok := indices[next]; !ok {
+				strongConnect(next)
+				if lowLinks[next] < lowLinks[name] {
+					lowLinks[name] = lowLinks[next]
+				}
+			} else if onStack[next] && indices[next] < lowLinks[name] {
+				lowLinks[name] = indices[next]
+			}
+		}
+		if lowLinks[name] != indices[name] {
+			return
+		}
+		component := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == name {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, name := range names {
+		if _, ok := indices[name]; !ok {
+			strongConnect(name)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
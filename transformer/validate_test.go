@@ -0,0 +1,100 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestValidateTransformerConfigs(t *testing.T) {
+	newConfig := func(name string, detect bool, consumes, produces []transformertypes.ArtifactType) transformertypes.Transformer {
+		tc := transformertypes.NewTransformer()
+		tc.Name = name
+		tc.Labels["name"] = name
+		if detect {
+			tc.Spec.DirectoryDetect.Levels = -1
+		}
+		tc.Spec.ConsumedArtifacts = map[transformertypes.ArtifactType]transformertypes.ArtifactProcessConfig{}
+		for _, artifactType := range consumes {
+			tc.Spec.ConsumedArtifacts[artifactType] = transformertypes.ArtifactProcessConfig{}
+		}
+		tc.Spec.ProducedArtifacts = map[transformertypes.ArtifactType]transformertypes.ProducedArtifact{}
+		for _, artifactType := range produces {
+			tc.Spec.ProducedArtifacts[artifactType] = transformertypes.ProducedArtifact{}
+		}
+		return tc
+	}
+	detector := newConfig("Detector", true, []transformertypes.ArtifactType{"Service"}, []transformertypes.ArtifactType{"IR"})
+	generator := newConfig("Generator", false, []transformertypes.ArtifactType{"IR"}, []transformertypes.ArtifactType{"Yamls"})
+	orphan := newConfig("Orphan", false, []transformertypes.ArtifactType{"Missing"}, nil)
+	pingA := newConfig("PingA", false, []transformertypes.ArtifactType{"IR", "Pong"}, []transformertypes.ArtifactType{"Ping"})
+	pingB := newConfig("PingB", false, []transformertypes.ArtifactType{"Ping"}, []transformertypes.ArtifactType{"Pong"})
+	dangling := newConfig("Dangling", false, []transformertypes.ArtifactType{"IR"}, nil)
+	dangling.Spec.DependencySelector = labels.SelectorFromSet(labels.Set{"name": "DoesNotExist"})
+	broken := newConfig("Broken", false, []transformertypes.ArtifactType{"IR"}, nil)
+	incompatible := newConfig("Incompatible", false, []transformertypes.ArtifactType{"IR"}, nil)
+
+	loaded := map[string]transformertypes.Transformer{}
+	for _, tc := range []transformertypes.Transformer{detector, generator, orphan, pingA, pingB, dangling, broken, incompatible} {
+		loaded[tc.Name] = tc
+	}
+	selected := map[string]transformertypes.Transformer{}
+	initialized := map[string]transformertypes.Transformer{}
+	for name, tc := range loaded {
+		if name == incompatible.Name {
+			continue
+		}
+		selected[name] = tc
+		if name != broken.Name {
+			initialized[name] = tc
+		}
+	}
+	incompatibleErrors := map[string]error{incompatible.Name: fmt.Errorf("%w : it requires move2kube '>= 9.0.0'", ErrIncompatibleTransformer)}
+	initErrors := map[string]error{broken.Name: fmt.Errorf("bad config")}
+	report := validateTransformerConfigs(loaded, selected, initialized, incompatibleErrors, initErrors)
+
+	type issue struct {
+		Kind         transformertypes.ValidationIssueKind
+		Severity     transformertypes.ValidationSeverity
+		Transformers []string
+		ArtifactType transformertypes.ArtifactType
+	}
+	got := []issue{}
+	for _, i := range report.Issues {
+		got = append(got, issue{Kind: i.Kind, Severity: i.Severity, Transformers: i.Transformers, ArtifactType: i.ArtifactType})
+	}
+	want := []issue{
+		{Kind: transformertypes.IncompatibleVersionIssue, Severity: transformertypes.ValidationWarning, Transformers: []string{"Incompatible"}},
+		{Kind: transformertypes.InitFailedIssue, Severity: transformertypes.ValidationError, Transformers: []string{"Broken"}},
+		{Kind: transformertypes.SelectorConflictIssue, Severity: transformertypes.ValidationError, Transformers: []string{"Dangling"}},
+		{Kind: transformertypes.UnproducedArtifactTypeIssue, Severity: transformertypes.ValidationWarning, Transformers: []string{"Orphan"}, ArtifactType: "Missing"},
+		{Kind: transformertypes.UnconsumedArtifactTypeIssue, Severity: transformertypes.ValidationInfo, Transformers: []string{"Generator"}, ArtifactType: "Yamls"},
+		{Kind: transformertypes.UnreachableTransformerIssue, Severity: transformertypes.ValidationWarning, Transformers: []string{"Orphan"}},
+		{Kind: transformertypes.CycleIssue, Severity: transformertypes.ValidationWarning, Transformers: []string{"PingA", "PingB"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("the validation issues are different. Differences:\n%s", diff)
+	}
+	if !report.HasErrors() {
+		t.Fatalf("expected the report to have errors")
+	}
+}
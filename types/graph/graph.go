@@ -127,3 +127,19 @@ func (g *Graph) AddEdge(from, to int, name string, data map[string]interface{})
 	g.Edges[g.edgeId] = Edge{Id: g.edgeId, From: from, To: to, Name: name, Data: data}
 	return g.edgeId
 }
+
+// ResetIds sets the ids used for new vertices and edges. It should be called after the graph is loaded from a file.
+func (g *Graph) ResetIds() {
+	g.vertexId = -1
+	for id := range g.Vertices {
+		if id > g.vertexId {
+			g.vertexId = id
+		}
+	}
+	g.edgeId = -1
+	for id := range g.Edges {
+		if id > g.edgeId {
+			g.edgeId = id
+		}
+	}
+}
@@ -44,6 +44,8 @@ type Spec struct {
 	Transformers                 map[string]string    `yaml:"transformers,omitempty" m2kpath:"normal"` //[name]filepath
 	InvokedByDefaultTransformers []string             `yaml:"invokedByDefaultTransformers,omitempty"`
 	DisabledTransformers         map[string]string    `yaml:"disabledTransformers,omitempty" m2kpath:"normal"` //[name]filepath
+
+	ServiceNaming ServiceNamingConfig `yaml:"serviceNaming,omitempty"`
 }
 
 // PlanArtifact stores the artifact with the transformerName
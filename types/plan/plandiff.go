@@ -0,0 +1,256 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+)
+
+// PlanDiff stores the differences between two plans
+type PlanDiff struct {
+	AddedServices       []string          `yaml:"addedServices,omitempty" json:"addedServices,omitempty"`
+	RemovedServices     []string          `yaml:"removedServices,omitempty" json:"removedServices,omitempty"`
+	RenamedServices     map[string]string `yaml:"renamedServices,omitempty" json:"renamedServices,omitempty"`
+	ChangedServices     []ServiceDiff     `yaml:"changedServices,omitempty" json:"changedServices,omitempty"`
+	AddedTransformers   []string          `yaml:"addedTransformers,omitempty" json:"addedTransformers,omitempty"`
+	RemovedTransformers []string          `yaml:"removedTransformers,omitempty" json:"removedTransformers,omitempty"`
+}
+
+// ServiceDiff stores the differences in the artifacts of a service that is present in both the plans
+type ServiceDiff struct {
+	Name             string         `yaml:"name" json:"name"`
+	AddedArtifacts   []string       `yaml:"addedArtifacts,omitempty" json:"addedArtifacts,omitempty"`
+	RemovedArtifacts []string       `yaml:"removedArtifacts,omitempty" json:"removedArtifacts,omitempty"`
+	ChangedArtifacts []ArtifactDiff `yaml:"changedArtifacts,omitempty" json:"changedArtifacts,omitempty"`
+}
+
+// ArtifactDiff stores the fields that changed in an artifact
+type ArtifactDiff struct {
+	Artifact      string   `yaml:"artifact" json:"artifact"`
+	ChangedFields []string `yaml:"changedFields" json:"changedFields"`
+}
+
+// IsEmpty returns true if the plans have no differences
+func (d PlanDiff) IsEmpty() bool {
+	return len(d.AddedServices) == 0 && len(d.RemovedServices) == 0 && len(d.RenamedServices) == 0 &&
+		len(d.ChangedServices) == 0 && len(d.AddedTransformers) == 0 && len(d.RemovedTransformers) == 0
+}
+
+// String returns a human readable form of the differences
+func (d PlanDiff) String() string {
+	if d.IsEmpty() {
+		return "The plans are the same.\n"
+	}
+	sb := strings.Builder{}
+	for _, s := range d.AddedServices {
+		sb.WriteString(fmt.Sprintf("+ service %s\n", s))
+	}
+	for _, s := range d.RemovedServices {
+		sb.WriteString(fmt.Sprintf("- service %s\n", s))
+	}
+	renamed := []string{}
+	for s := range d.RenamedServices {
+		renamed = append(renamed, s)
+	}
+	sort.Strings(renamed)
+	for _, s := range renamed {
+		sb.WriteString(fmt.Sprintf("~ service %s renamed to %s\n", s, d.RenamedServices[s]))
+	}
+	for _, s := range d.ChangedServices {
+		sb.WriteString(fmt.Sprintf("~ service %s\n", s.Name))
+		for _, a := range s.AddedArtifacts {
+			sb.WriteString(fmt.Sprintf("    + artifact %s\n", a))
+		}
+		for _, a := range s.RemovedArtifacts {
+			sb.WriteString(fmt.Sprintf("    - artifact %s\n", a))
+		}
+		for _, a := range s.ChangedArtifacts {
+			sb.WriteString(fmt.Sprintf("    ~ artifact %s: %s\n", a.Artifact, strings.Join(a.ChangedFields, ", ")))
+		}
+	}
+	for _, t := range d.AddedTransformers {
+		sb.WriteString(fmt.Sprintf("+ transformer %s\n", t))
+	}
+	for _, t := range d.RemovedTransformers {
+		sb.WriteString(fmt.Sprintf("- transformer %s\n", t))
+	}
+	return sb.String()
+}
+
+// getPlanArtifactDescription returns a short human readable description of an artifact
+func getPlanArtifactDescription(a PlanArtifact) string {
+	desc := "from " + a.TransformerName
+	if a.Name != "" {
+		desc = a.Name + " " + desc
+	}
+	if a.Type != "" {
+		desc += " of type " + string(a.Type)
+	}
+	if paths := a.Paths[artifacts.ServiceDirPathType]; len(paths) > 0 {
+		desc += " in " + strings.Join(paths, ", ")
+	}
+	return desc
+}
+
+// DiffPlans returns the services, artifacts and transformers that differ between the two plans
+func DiffPlans(p1, p2 Plan) PlanDiff {
+	diff := PlanDiff{}
+	locs1 := getPlanArtifactLocations(p1.Spec.Services)
+	locs2 := getPlanArtifactLocations(p2.Spec.Services)
+	renames := getServiceRenames(locs1, locs2)
+	for s1, s2 := range renames {
+		if _, ok := p2.Spec.Services[s1]; ok {
+			continue
+		}
+		if _, ok := p1.Spec.Services[s2]; ok {
+			continue
+		}
+		if diff.RenamedServices == nil {
+			diff.RenamedServices = map[string]string{}
+		}
+		diff.RenamedServices[s1] = s2
+	}
+	renamedTo := map[string]bool{}
+	for _, s2 := range diff.RenamedServices {
+		renamedTo[s2] = true
+	}
+	for s := range p2.Spec.Services {
+		if _, ok := p1.Spec.Services[s]; !ok && !renamedTo[s] {
+			diff.AddedServices = append(diff.AddedServices, s)
+		}
+	}
+	for s := range p1.Spec.Services {
+		if _, ok := p2.Spec.Services[s]; !ok && diff.RenamedServices[s] == "" {
+			diff.RemovedServices = append(diff.RemovedServices, s)
+		}
+	}
+	sort.Strings(diff.AddedServices)
+	sort.Strings(diff.RemovedServices)
+	serviceDiffs := map[string]*ServiceDiff{}
+	getServiceDiff := func(serviceName string) *ServiceDiff {
+		if serviceDiffs[serviceName] == nil {
+			serviceDiffs[serviceName] = &ServiceDiff{Name: serviceName}
+		}
+		return serviceDiffs[serviceName]
+	}
+	keys := []string{}
+	for key := range locs1 {
+		keys = append(keys, key)
+	}
+	for key := range locs2 {
+		if _, ok := locs1[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		loc1, in1 := locs1[key]
+		loc2, in2 := locs2[key]
+		switch {
+		case in1 && in2:
+			if fields := getChangedArtifactFields(loc1.artifact, loc2.artifact); len(fields) > 0 {
+				sd := getServiceDiff(loc2.serviceName)
+				sd.ChangedArtifacts = append(sd.ChangedArtifacts, ArtifactDiff{Artifact: getPlanArtifactDescription(loc2.artifact), ChangedFields: fields})
+			}
+		case in1:
+			if common.IsPresent(diff.RemovedServices, loc1.serviceName) {
+				continue
+			}
+			serviceName := loc1.serviceName
+			if newName, ok := diff.RenamedServices[serviceName]; ok {
+				serviceName = newName
+			}
+			sd := getServiceDiff(serviceName)
+			sd.RemovedArtifacts = append(sd.RemovedArtifacts, getPlanArtifactDescription(loc1.artifact))
+		default:
+			if common.IsPresent(diff.AddedServices, loc2.serviceName) {
+				continue
+			}
+			sd := getServiceDiff(loc2.serviceName)
+			sd.AddedArtifacts = append(sd.AddedArtifacts, getPlanArtifactDescription(loc2.artifact))
+		}
+	}
+	serviceNames := []string{}
+	for serviceName := range serviceDiffs {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+	for _, serviceName := range serviceNames {
+		diff.ChangedServices = append(diff.ChangedServices, *serviceDiffs[serviceName])
+	}
+	for t := range p2.Spec.Transformers {
+		if _, ok := p1.Spec.Transformers[t]; !ok {
+			diff.AddedTransformers = append(diff.AddedTransformers, t)
+		}
+	}
+	for t := range p1.Spec.Transformers {
+		if _, ok := p2.Spec.Transformers[t]; !ok {
+			diff.RemovedTransformers = append(diff.RemovedTransformers, t)
+		}
+	}
+	sort.Strings(diff.AddedTransformers)
+	sort.Strings(diff.RemovedTransformers)
+	return diff
+}
+
+// getChangedArtifactFields returns the fields like name, paths.<path type> and configs.<config type> that differ between the artifacts
+func getChangedArtifactFields(a1, a2 PlanArtifact) []string {
+	m1, err1 := common.GetMapInterfaceFromObj(a1)
+	m2, err2 := common.GetMapInterfaceFromObj(a2)
+	if err1 != nil || err2 != nil {
+		if reflect.DeepEqual(a1, a2) {
+			return nil
+		}
+		return []string{"artifact"}
+	}
+	return getChangedMapFields("", m1.(map[string]interface{}), m2.(map[string]interface{}), 2)
+}
+
+// getChangedMapFields returns the dot separated keys of the values that differ up to the given depth
+func getChangedMapFields(prefix string, m1, m2 map[string]interface{}, depth int) []string {
+	keys := []string{}
+	for k := range m1 {
+		keys = append(keys, k)
+	}
+	for k := range m2 {
+		if _, ok := m1[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	fields := []string{}
+	for _, k := range keys {
+		v1, v2 := m1[k], m2[k]
+		if reflect.DeepEqual(v1, v2) {
+			continue
+		}
+		subM1, ok1 := v1.(map[string]interface{})
+		subM2, ok2 := v2.(map[string]interface{})
+		if depth > 1 && ok1 && ok2 {
+			fields = append(fields, getChangedMapFields(prefix+k+".", subM1, subM2, depth-1)...)
+			continue
+		}
+		fields = append(fields, prefix+k)
+	}
+	return fields
+}
@@ -0,0 +1,230 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/common/deepcopy"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+	"github.com/sirupsen/logrus"
+)
+
+// PlanBaseFileSuffix is appended to the path of a plan file to get the path where
+// the plan, as it was originally generated, is stored for future three way merges.
+const PlanBaseFileSuffix = ".base"
+
+// GetPlanBasePath returns the path of the base plan for the plan file at the given path
+func GetPlanBasePath(planPath string) string {
+	return filepath.Join(filepath.Dir(planPath), filepath.Base(planPath)+PlanBaseFileSuffix)
+}
+
+// planArtifactLocation is where an artifact is found in a plan
+type planArtifactLocation struct {
+	serviceName string
+	artifact    PlanArtifact
+}
+
+// GetPlanArtifactKey returns a key that identifies the same artifact across different runs of the planner.
+// Artifacts are identified by the transformer that detected them, their type and their service directories.
+func GetPlanArtifactKey(a PlanArtifact) string {
+	paths := a.Paths[artifacts.ServiceDirPathType]
+	if len(paths) == 0 {
+		for pathType, ps := range a.Paths {
+			for _, p := range ps {
+				paths = append(paths, string(pathType)+"="+p)
+			}
+		}
+	}
+	paths = append([]string{}, paths...)
+	sort.Strings(paths)
+	return a.TransformerName + "|" + string(a.Type) + "|" + strings.Join(paths, ",")
+}
+
+// getPlanArtifactLocations indexes all the artifacts in the services by their keys.
+// Artifacts with the same key are disambiguated by the order in which they appear.
+func getPlanArtifactLocations(services map[string][]PlanArtifact) map[string]planArtifactLocation {
+	locations := map[string]planArtifactLocation{}
+	serviceNames := []string{}
+	for serviceName := range services {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+	for _, serviceName := range serviceNames {
+		for _, a := range services[serviceName] {
+			key := GetPlanArtifactKey(a)
+			uniqueKey := key
+			for i := 1; ; i++ {
+				if _, ok := locations[uniqueKey]; !ok {
+					break
+				}
+				uniqueKey = fmt.Sprintf("%s|%d", key, i)
+			}
+			locations[uniqueKey] = planArtifactLocation{serviceName: serviceName, artifact: a}
+		}
+	}
+	return locations
+}
+
+// getServiceRenames returns the services from the base plan that were renamed in the current plan.
+// A service is considered renamed when all of its artifacts that are still present moved to a single other service.
+func getServiceRenames(base, current map[string]planArtifactLocation) map[string]string {
+	targets := map[string]map[string]bool{}
+	for key, baseLoc := range base {
+		currLoc, ok := current[key]
+		if !ok {
+			continue
+		}
+		if targets[baseLoc.serviceName] == nil {
+			targets[baseLoc.serviceName] = map[string]bool{}
+		}
+		targets[baseLoc.serviceName][currLoc.serviceName] = true
+	}
+	renames := map[string]string{}
+	for serviceName, ts := range targets {
+		if len(ts) != 1 {
+			continue
+		}
+		for target := range ts {
+			if target != serviceName {
+				renames[serviceName] = target
+			}
+		}
+	}
+	return renames
+}
+
+// isPlanArtifactEqual compares the serialized forms of two artifacts so that typed configs
+// compare equal to configs that were read back from a plan file
+func isPlanArtifactEqual(a1, a2 PlanArtifact) bool {
+	m1, err1 := common.GetMapInterfaceFromObj(a1)
+	m2, err2 := common.GetMapInterfaceFromObj(a2)
+	if err1 != nil || err2 != nil {
+		return reflect.DeepEqual(a1, a2)
+	}
+	return reflect.DeepEqual(m1, m2)
+}
+
+// MergePlans does a three way merge of a freshly detected plan into the current plan
+// using the plan that the current plan was originally generated from as the base.
+// Services and artifacts removed by the user stay removed, renamed services keep their
+// new names and artifacts edited by the user are preserved. Everything else follows the detected plan.
+func MergePlans(base, current, detected Plan) Plan {
+	merged := deepcopy.DeepCopy(detected).(Plan)
+	merged.ObjectMeta = current.ObjectMeta
+	merged.Spec.Services = map[string][]PlanArtifact{}
+	baseLocs := getPlanArtifactLocations(base.Spec.Services)
+	currLocs := getPlanArtifactLocations(current.Spec.Services)
+	detectedLocs := getPlanArtifactLocations(detected.Spec.Services)
+	renames := getServiceRenames(baseLocs, currLocs)
+	removedServices := map[string]bool{}
+	for serviceName := range base.Spec.Services {
+		if _, ok := current.Spec.Services[serviceName]; !ok && renames[serviceName] == "" {
+			removedServices[serviceName] = true
+		}
+	}
+	keys := []string{}
+	for key := range currLocs {
+		keys = append(keys, key)
+	}
+	for key := range detectedLocs {
+		if _, ok := currLocs[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		baseLoc, inBase := baseLocs[key]
+		currLoc, inCurr := currLocs[key]
+		detectedLoc, inDetected := detectedLocs[key]
+		switch {
+		case inCurr && inDetected:
+			a := currLoc.artifact
+			if inBase && isPlanArtifactEqual(baseLoc.artifact, currLoc.artifact) {
+				a = detectedLoc.artifact
+			}
+			merged.Spec.Services[currLoc.serviceName] = append(merged.Spec.Services[currLoc.serviceName], a)
+		case inCurr && !inBase:
+			logrus.Debugf("keeping the artifact %s that was added to the service %s by the user", key, currLoc.serviceName)
+			merged.Spec.Services[currLoc.serviceName] = append(merged.Spec.Services[currLoc.serviceName], currLoc.artifact)
+		case inCurr:
+			logrus.Debugf("removing the artifact %s from the service %s since it is no longer detected", key, currLoc.serviceName)
+		case inBase:
+			logrus.Debugf("skipping the artifact %s since it was removed by the user", key)
+		default:
+			serviceName := detectedLoc.serviceName
+			if removedServices[serviceName] {
+				logrus.Debugf("skipping the artifact %s since the service %s was removed by the user", key, serviceName)
+				continue
+			}
+			if newName, ok := renames[serviceName]; ok {
+				serviceName = newName
+			}
+			merged.Spec.Services[serviceName] = append(merged.Spec.Services[serviceName], detectedLoc.artifact)
+		}
+	}
+	merged.Spec.Transformers = mergeStringMaps(base.Spec.Transformers, current.Spec.Transformers, detected.Spec.Transformers)
+	merged.Spec.DisabledTransformers = mergeStringMaps(base.Spec.DisabledTransformers, current.Spec.DisabledTransformers, detected.Spec.DisabledTransformers)
+	merged.Spec.InvokedByDefaultTransformers = mergeStringSlices(base.Spec.InvokedByDefaultTransformers, current.Spec.InvokedByDefaultTransformers, detected.Spec.InvokedByDefaultTransformers)
+	return merged
+}
+
+// mergeStringMaps does a three way merge of the keys of string maps. The values are taken from the current map when the user changed them.
+func mergeStringMaps(base, current, detected map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range detected {
+		if _, inBase := base[k]; inBase {
+			if _, inCurr := current[k]; !inCurr {
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	for k, v := range current {
+		if baseV, inBase := base[k]; !inBase || baseV != v {
+			if _, inDetected := detected[k]; inDetected || !inBase {
+				merged[k] = v
+			}
+		}
+	}
+	if len(merged) == 0 && detected == nil {
+		return nil
+	}
+	return merged
+}
+
+// mergeStringSlices does a three way merge of string slices treated as sets
+func mergeStringSlices(base, current, detected []string) []string {
+	merged := []string{}
+	for _, v := range detected {
+		if common.IsPresent(base, v) && !common.IsPresent(current, v) {
+			continue
+		}
+		merged = common.AppendIfNotPresent(merged, v)
+	}
+	for _, v := range current {
+		if !common.IsPresent(base, v) {
+			merged = common.AppendIfNotPresent(merged, v)
+		}
+	}
+	return merged
+}
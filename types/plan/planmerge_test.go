@@ -0,0 +1,130 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+)
+
+func newTestPlanArtifact(transformerName, dir, originalName string) plan.PlanArtifact {
+	return plan.PlanArtifact{
+		TransformerName: transformerName,
+		Artifact: transformertypes.Artifact{
+			Paths: map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {dir}},
+			Configs: map[transformertypes.ConfigType]interface{}{
+				artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: originalName},
+			},
+		},
+	}
+}
+
+func TestMergePlans(t *testing.T) {
+	base := plan.NewPlan()
+	base.Spec.Services = map[string][]plan.PlanArtifact{
+		"api":    {newTestPlanArtifact("Golang-Dockerfile", "/src/api", "api")},
+		"web":    {newTestPlanArtifact("Nodejs-Dockerfile", "/src/web", "web"), newTestPlanArtifact("DockerfileDetector", "/src/web", "web")},
+		"worker": {newTestPlanArtifact("Golang-Dockerfile", "/src/worker", "worker")},
+		"db":     {newTestPlanArtifact("DockerfileDetector", "/src/db", "db")},
+	}
+	base.Spec.Transformers = map[string]string{"Golang-Dockerfile": "golang.yaml", "Nodejs-Dockerfile": "nodejs.yaml", "DockerfileDetector": "dockerfile.yaml"}
+
+	// the user renamed api, pinned the Nodejs-Dockerfile transformer for web, removed worker and edited db
+	current := plan.NewPlan()
+	current.Name = "edited"
+	current.Spec.Services = map[string][]plan.PlanArtifact{
+		"backend": {newTestPlanArtifact("Golang-Dockerfile", "/src/api", "api")},
+		"web":     {newTestPlanArtifact("Nodejs-Dockerfile", "/src/web", "web")},
+		"db":      {newTestPlanArtifact("DockerfileDetector", "/src/db", "database")},
+	}
+	current.Spec.Transformers = map[string]string{"Golang-Dockerfile": "golang.yaml", "Nodejs-Dockerfile": "nodejs.yaml"}
+
+	// the source changed: api has a new config, worker got a new artifact and a new service was added
+	detected := plan.NewPlan()
+	detected.Spec.Services = map[string][]plan.PlanArtifact{
+		"api":    {newTestPlanArtifact("Golang-Dockerfile", "/src/api", "api-v2"), newTestPlanArtifact("DockerfileDetector", "/src/api", "api")},
+		"web":    {newTestPlanArtifact("Nodejs-Dockerfile", "/src/web", "web"), newTestPlanArtifact("DockerfileDetector", "/src/web", "web")},
+		"worker": {newTestPlanArtifact("Golang-Dockerfile", "/src/worker", "worker"), newTestPlanArtifact("DockerfileDetector", "/src/worker", "worker")},
+		"db":     {newTestPlanArtifact("DockerfileDetector", "/src/db", "db")},
+		"new":    {newTestPlanArtifact("Golang-Dockerfile", "/src/new", "new")},
+	}
+	detected.Spec.Transformers = map[string]string{"Golang-Dockerfile": "golang.yaml", "Nodejs-Dockerfile": "nodejs.yaml", "DockerfileDetector": "dockerfile.yaml", "Rust-Dockerfile": "rust.yaml"}
+
+	merged := plan.MergePlans(base, current, detected)
+	expectedServices := map[string][]plan.PlanArtifact{
+		"backend": {newTestPlanArtifact("DockerfileDetector", "/src/api", "api"), newTestPlanArtifact("Golang-Dockerfile", "/src/api", "api-v2")},
+		"web":     {newTestPlanArtifact("Nodejs-Dockerfile", "/src/web", "web")},
+		"db":      {newTestPlanArtifact("DockerfileDetector", "/src/db", "database")},
+		"new":     {newTestPlanArtifact("Golang-Dockerfile", "/src/new", "new")},
+	}
+	if !reflect.DeepEqual(merged.Spec.Services, expectedServices) {
+		t.Fatalf("the merged services are incorrect. Expected: %+v Actual: %+v", expectedServices, merged.Spec.Services)
+	}
+	expectedTransformers := map[string]string{"Golang-Dockerfile": "golang.yaml", "Nodejs-Dockerfile": "nodejs.yaml", "Rust-Dockerfile": "rust.yaml"}
+	if !reflect.DeepEqual(merged.Spec.Transformers, expectedTransformers) {
+		t.Fatalf("the merged transformers are incorrect. Expected: %+v Actual: %+v", expectedTransformers, merged.Spec.Transformers)
+	}
+	if merged.Name != "edited" {
+		t.Fatalf("expected the name of the current plan to be preserved. Actual: %s", merged.Name)
+	}
+
+	diff := plan.DiffPlans(current, merged)
+	if !reflect.DeepEqual(diff.AddedServices, []string{"new"}) || len(diff.RemovedServices) != 0 || len(diff.RenamedServices) != 0 {
+		t.Fatalf("unexpected service differences: %+v", diff)
+	}
+	if len(diff.ChangedServices) != 1 || diff.ChangedServices[0].Name != "backend" ||
+		len(diff.ChangedServices[0].AddedArtifacts) != 1 || len(diff.ChangedServices[0].ChangedArtifacts) != 1 ||
+		!reflect.DeepEqual(diff.ChangedServices[0].ChangedArtifacts[0].ChangedFields, []string{"configs.OriginalName"}) {
+		t.Fatalf("unexpected artifact differences: %+v", diff.ChangedServices)
+	}
+	if !reflect.DeepEqual(diff.AddedTransformers, []string{"Rust-Dockerfile"}) {
+		t.Fatalf("unexpected transformer differences: %+v", diff)
+	}
+	diff = plan.DiffPlans(base, current)
+	if !reflect.DeepEqual(diff.RenamedServices, map[string]string{"api": "backend"}) || !reflect.DeepEqual(diff.RemovedServices, []string{"worker"}) {
+		t.Fatalf("expected the rename and the removal to be detected. Actual: %+v", diff)
+	}
+	if plan.DiffPlans(merged, merged).String() != "The plans are the same.\n" {
+		t.Fatalf("expected no differences between identical plans")
+	}
+}
+
+func TestMergePlansWithoutBase(t *testing.T) {
+	current := plan.NewPlan()
+	current.Spec.Services = map[string][]plan.PlanArtifact{
+		"web": {newTestPlanArtifact("Nodejs-Dockerfile", "/src/web", "frontend")},
+		"db":  {newTestPlanArtifact("DockerfileDetector", "/src/db", "db")},
+	}
+	detected := plan.NewPlan()
+	detected.Spec.Services = map[string][]plan.PlanArtifact{
+		"web": {newTestPlanArtifact("Nodejs-Dockerfile", "/src/web", "web"), newTestPlanArtifact("DockerfileDetector", "/src/web", "web")},
+		"new": {newTestPlanArtifact("Golang-Dockerfile", "/src/new", "new")},
+	}
+	merged := plan.MergePlans(plan.NewPlan(), current, detected)
+	expectedServices := map[string][]plan.PlanArtifact{
+		"web": {newTestPlanArtifact("DockerfileDetector", "/src/web", "web"), newTestPlanArtifact("Nodejs-Dockerfile", "/src/web", "frontend")},
+		"db":  {newTestPlanArtifact("DockerfileDetector", "/src/db", "db")},
+		"new": {newTestPlanArtifact("Golang-Dockerfile", "/src/new", "new")},
+	}
+	if !reflect.DeepEqual(merged.Spec.Services, expectedServices) {
+		t.Fatalf("expected the current plan to be kept and the new artifacts to be added. Expected: %+v Actual: %+v", expectedServices, merged.Spec.Services)
+	}
+}
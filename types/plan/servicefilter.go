@@ -0,0 +1,53 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// FilterServices returns the services whose names match at least one of the include glob patterns
+// and none of the exclude glob patterns, along with the sorted names of the services that were skipped.
+// All the services are included when there are no include patterns.
+func FilterServices(services map[string][]PlanArtifact, include, exclude []string) (map[string][]PlanArtifact, []string, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, nil, fmt.Errorf("the service name pattern '%s' is invalid. Error: %w", pattern, err)
+		}
+	}
+	matchesAny := func(serviceName string, patterns []string) bool {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, serviceName); matched {
+				return true
+			}
+		}
+		return false
+	}
+	selected := map[string][]PlanArtifact{}
+	skipped := []string{}
+	for serviceName, planArtifacts := range services {
+		if (len(include) > 0 && !matchesAny(serviceName, include)) || matchesAny(serviceName, exclude) {
+			skipped = append(skipped, serviceName)
+			continue
+		}
+		selected[serviceName] = planArtifacts
+	}
+	sort.Strings(skipped)
+	return selected, skipped, nil
+}
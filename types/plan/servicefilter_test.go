@@ -0,0 +1,66 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFilterServices(t *testing.T) {
+	services := map[string][]PlanArtifact{
+		"api":        {{TransformerName: "Golang-Dockerfile"}},
+		"api-gw":     {{TransformerName: "Nodejs-Dockerfile"}},
+		"web":        {{TransformerName: "Nodejs-Dockerfile"}},
+		"web-legacy": {{TransformerName: "Nodejs-Dockerfile"}},
+	}
+	testCases := []struct {
+		name         string
+		include      []string
+		exclude      []string
+		wantSelected []string
+		wantSkipped  []string
+	}{
+		{name: "no patterns selects all the services", wantSelected: []string{"api", "api-gw", "web", "web-legacy"}, wantSkipped: []string{}},
+		{name: "include patterns", include: []string{"api*", "web"}, wantSelected: []string{"api", "api-gw", "web"}, wantSkipped: []string{"web-legacy"}},
+		{name: "exclude patterns", exclude: []string{"*-legacy"}, wantSelected: []string{"api", "api-gw", "web"}, wantSkipped: []string{"web-legacy"}},
+		{name: "exclude patterns take precedence", include: []string{"api*"}, exclude: []string{"api-?w"}, wantSelected: []string{"api"}, wantSkipped: []string{"api-gw", "web", "web-legacy"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, skipped, err := FilterServices(services, tc.include, tc.exclude)
+			if err != nil {
+				t.Fatalf("failed to filter the services. Error: %q", err)
+			}
+			selectedNames := []string{}
+			for serviceName := range selected {
+				selectedNames = append(selectedNames, serviceName)
+			}
+			sort.Strings(selectedNames)
+			if !reflect.DeepEqual(selectedNames, tc.wantSelected) {
+				t.Fatalf("wrong selected services. Expected: %v Actual: %v", tc.wantSelected, selectedNames)
+			}
+			if !reflect.DeepEqual(skipped, tc.wantSkipped) {
+				t.Fatalf("wrong skipped services. Expected: %v Actual: %v", tc.wantSkipped, skipped)
+			}
+		})
+	}
+	if _, _, err := FilterServices(services, []string{"[a-"}, nil); err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+}
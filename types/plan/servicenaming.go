@@ -0,0 +1,99 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ServiceNamingStrategy is the strategy used to name the services detected during planning
+type ServiceNamingStrategy string
+
+const (
+	// PathPrefixServiceNamingStrategy names the services using the git repo name and the common prefixes of the service directories
+	PathPrefixServiceNamingStrategy ServiceNamingStrategy = "pathprefix"
+	// BaseNameServiceNamingStrategy names the services using the name of the service directory
+	BaseNameServiceNamingStrategy ServiceNamingStrategy = "basename"
+	// GitServiceNamingStrategy names the services using the name of the git remote of the service directory
+	GitServiceNamingStrategy ServiceNamingStrategy = "git"
+	// BuildMetadataServiceNamingStrategy names the services using the build metadata (Maven artifactId, package.json name, go module, etc.)
+	BuildMetadataServiceNamingStrategy ServiceNamingStrategy = "buildmetadata"
+	// RegexServiceNamingStrategy names the services by rewriting the service directory paths using a table of regexes
+	RegexServiceNamingStrategy ServiceNamingStrategy = "regex"
+)
+
+// ServiceNameRewriteSeparator separates the regex and the replacement of a service name rewrite
+const ServiceNameRewriteSeparator = "=>"
+
+// ServiceNamingStrategies contains all the service naming strategies
+var ServiceNamingStrategies = []ServiceNamingStrategy{
+	PathPrefixServiceNamingStrategy,
+	BaseNameServiceNamingStrategy,
+	GitServiceNamingStrategy,
+	BuildMetadataServiceNamingStrategy,
+	RegexServiceNamingStrategy,
+}
+
+// ServiceNamingConfig stores the strategy used to name the services
+type ServiceNamingConfig struct {
+	Strategy ServiceNamingStrategy `yaml:"strategy,omitempty"`
+	Rewrites []ServiceNameRewrite  `yaml:"rewrites,omitempty"`
+}
+
+// ServiceNameRewrite rewrites the service directory paths that match the regex into service names.
+// The replacement can refer to the capture groups of the regex using $1, ${name}, etc.
+type ServiceNameRewrite struct {
+	Regex       string `yaml:"regex"`
+	Replacement string `yaml:"replacement"`
+}
+
+// IsValidServiceNamingStrategy checks if the service naming strategy is known
+func IsValidServiceNamingStrategy(strategy ServiceNamingStrategy) bool {
+	for _, s := range ServiceNamingStrategies {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseServiceNameRewrites parses the lines of the form "<regex> => <replacement>" into service name rewrites.
+// Empty lines and lines starting with # are ignored.
+func ParseServiceNameRewrites(lines string) ([]ServiceNameRewrite, error) {
+	rewrites := []ServiceNameRewrite{}
+	for i, line := range strings.Split(lines, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.LastIndex(line, ServiceNameRewriteSeparator)
+		if idx < 0 {
+			return rewrites, fmt.Errorf("the line %d '%s' is not of the form '<regex> %s <replacement>'", i+1, line, ServiceNameRewriteSeparator)
+		}
+		rewrite := ServiceNameRewrite{
+			Regex:       strings.TrimSpace(line[:idx]),
+			Replacement: strings.TrimSpace(line[idx+len(ServiceNameRewriteSeparator):]),
+		}
+		if _, err := regexp.Compile(rewrite.Regex); err != nil {
+			return rewrites, fmt.Errorf("the regex '%s' on line %d is invalid. Error: %w", rewrite.Regex, i+1, err)
+		}
+		rewrites = append(rewrites, rewrite)
+	}
+	return rewrites, nil
+}
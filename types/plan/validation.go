@@ -0,0 +1,194 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube/common"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// PlanValidationIssueKind is the kind of problem found while validating a plan
+type PlanValidationIssueKind string
+
+const (
+	// InvalidSelectorIssue means the transformer selector in the plan cannot be parsed
+	InvalidSelectorIssue PlanValidationIssueKind = "InvalidSelector"
+	// TransformerNotFoundIssue means a transformer referenced in the plan cannot be found
+	TransformerNotFoundIssue PlanValidationIssueKind = "TransformerNotFound"
+	// DisabledTransformerIssue means a disabled transformer is referenced in the plan
+	DisabledTransformerIssue PlanValidationIssueKind = "DisabledTransformer"
+	// PathNotFoundIssue means a path in an artifact does not exist
+	PathNotFoundIssue PlanValidationIssueKind = "PathNotFound"
+	// InvalidServiceNameIssue means a service name is not a valid DNS label
+	InvalidServiceNameIssue PlanValidationIssueKind = "InvalidServiceName"
+	// DuplicateServiceNameIssue means multiple services end up with the same name in the output
+	DuplicateServiceNameIssue PlanValidationIssueKind = "DuplicateServiceName"
+)
+
+// PlanValidationIssue is a single problem found while validating a plan
+type PlanValidationIssue struct {
+	Severity    transformertypes.ValidationSeverity `yaml:"severity" json:"severity"`
+	Kind        PlanValidationIssueKind             `yaml:"kind" json:"kind"`
+	Service     string                              `yaml:"service,omitempty" json:"service,omitempty"`
+	Transformer string                              `yaml:"transformer,omitempty" json:"transformer,omitempty"`
+	Message     string                              `yaml:"message" json:"message"`
+}
+
+// PlanValidationReport lists the problems found while validating a plan
+type PlanValidationReport struct {
+	Issues []PlanValidationIssue `yaml:"issues" json:"issues"`
+}
+
+// HasErrors returns true if any of the issues is an error
+func (r PlanValidationReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == transformertypes.ValidationError {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable report
+func (r PlanValidationReport) String() string {
+	if len(r.Issues) == 0 {
+		return "No problems found.\n"
+	}
+	sb := strings.Builder{}
+	for _, issue := range r.Issues {
+		sb.WriteString(fmt.Sprintf("%-7s %-20s %s\n", strings.ToUpper(string(issue.Severity)), issue.Kind, issue.Message))
+	}
+	return sb.String()
+}
+
+// ValidatePlan checks the plan against the source directory and the transformers that are currently available.
+// availableTransformers maps the names of the available transformers to the paths of their yaml files.
+func ValidatePlan(plan Plan, availableTransformers map[string]string) PlanValidationReport {
+	report := PlanValidationReport{}
+	addIssue := func(severity transformertypes.ValidationSeverity, kind PlanValidationIssueKind, serviceName, transformerName, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, PlanValidationIssue{
+			Severity:    severity,
+			Kind:        kind,
+			Service:     serviceName,
+			Transformer: transformerName,
+			Message:     fmt.Sprintf(format, args...),
+		})
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(&plan.Spec.TransformerSelector); err != nil {
+		addIssue(transformertypes.ValidationError, InvalidSelectorIssue, "", "", "the transformer selector is invalid. Error: %s", err)
+	}
+
+	if plan.Spec.SourceDir != "" {
+		if _, err := os.Stat(plan.Spec.SourceDir); err != nil {
+			addIssue(transformertypes.ValidationError, PathNotFoundIssue, "", "", "the source directory '%s' does not exist", plan.Spec.SourceDir)
+		}
+	}
+
+	// transformers
+	for _, name := range getSortedKeys(plan.Spec.Transformers) {
+		if _, ok := plan.Spec.DisabledTransformers[name]; ok {
+			addIssue(transformertypes.ValidationError, DisabledTransformerIssue, "", name, "the transformer '%s' is listed both as a transformer and as a disabled transformer", name)
+		}
+		transformerYamlPath := plan.Spec.Transformers[name]
+		availablePath, ok := availableTransformers[name]
+		if !ok {
+			addIssue(transformertypes.ValidationError, TransformerNotFoundIssue, "", name, "the transformer '%s' was not found in the built-in transformers or the customizations", name)
+			continue
+		}
+		if _, err := os.Stat(transformerYamlPath); err != nil {
+			addIssue(transformertypes.ValidationError, TransformerNotFoundIssue, "", name, "the yaml file '%s' of the transformer '%s' does not exist", transformerYamlPath, name)
+			continue
+		}
+		if filepath.Clean(transformerYamlPath) != filepath.Clean(availablePath) {
+			addIssue(transformertypes.ValidationWarning, TransformerNotFoundIssue, "", name, "the transformer '%s' is defined in '%s' but the plan refers to '%s'", name, availablePath, transformerYamlPath)
+		}
+	}
+	checkTransformerReference := func(serviceName, name, usage string) {
+		if _, ok := plan.Spec.DisabledTransformers[name]; ok {
+			addIssue(transformertypes.ValidationError, DisabledTransformerIssue, serviceName, name, "%s refers to the disabled transformer '%s'", usage, name)
+			return
+		}
+		if _, ok := plan.Spec.Transformers[name]; !ok {
+			addIssue(transformertypes.ValidationError, TransformerNotFoundIssue, serviceName, name, "%s refers to the transformer '%s' which is not in the list of transformers of the plan", usage, name)
+		}
+	}
+	for _, name := range plan.Spec.InvokedByDefaultTransformers {
+		checkTransformerReference("", name, "the list of transformers invoked by default")
+	}
+
+	// services
+	serviceNames := []string{}
+	for serviceName := range plan.Spec.Services {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+	compliantNames := map[string]string{}
+	for _, serviceName := range serviceNames {
+		compliantName := common.MakeStringK8sServiceNameCompliant(serviceName)
+		if errs := validation.IsDNS1035Label(serviceName); len(errs) > 0 {
+			addIssue(transformertypes.ValidationError, InvalidServiceNameIssue, serviceName, "", "the service name '%s' is invalid. Try '%s' instead. Error: %s", serviceName, compliantName, strings.Join(errs, " , "))
+		}
+		if otherName, ok := compliantNames[compliantName]; ok {
+			addIssue(transformertypes.ValidationError, DuplicateServiceNameIssue, serviceName, "", "the services '%s' and '%s' will both be named '%s'", otherName, serviceName, compliantName)
+		} else {
+			compliantNames[compliantName] = serviceName
+		}
+		for i, a := range plan.Spec.Services[serviceName] {
+			usage := fmt.Sprintf("the artifact %d of the service '%s'", i, serviceName)
+			if a.TransformerName == "" {
+				addIssue(transformertypes.ValidationError, TransformerNotFoundIssue, serviceName, "", "%s does not specify a transformer", usage)
+			} else {
+				checkTransformerReference(serviceName, a.TransformerName, usage)
+			}
+			for _, pathType := range getSortedPathTypes(a.Paths) {
+				for _, path := range a.Paths[pathType] {
+					if _, err := os.Stat(path); err != nil {
+						addIssue(transformertypes.ValidationError, PathNotFoundIssue, serviceName, a.TransformerName, "the path '%s' of type %s in %s does not exist", path, pathType, usage)
+					}
+				}
+			}
+		}
+	}
+	return report
+}
+
+func getSortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func getSortedPathTypes(paths map[transformertypes.PathType][]string) []transformertypes.PathType {
+	pathTypes := []transformertypes.PathType{}
+	for pathType := range paths {
+		pathTypes = append(pathTypes, pathType)
+	}
+	sort.Slice(pathTypes, func(i, j int) bool { return pathTypes[i] < pathTypes[j] })
+	return pathTypes
+}
@@ -0,0 +1,92 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plan_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/types/plan"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/konveyor/move2kube/types/transformer/artifacts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidatePlan(t *testing.T) {
+	sourceDir := t.TempDir()
+	serviceDir := filepath.Join(sourceDir, "api")
+	if err := os.MkdirAll(serviceDir, common.DefaultDirectoryPermission); err != nil {
+		t.Fatalf("failed to create the service directory. Error: %q", err)
+	}
+	transformerYamlPath := filepath.Join(t.TempDir(), "transformer.yaml")
+	if err := os.WriteFile(transformerYamlPath, []byte("kind: Transformer"), common.DefaultFilePermission); err != nil {
+		t.Fatalf("failed to write the transformer yaml. Error: %q", err)
+	}
+	availableTransformers := map[string]string{"Golang-Dockerfile": transformerYamlPath}
+	newPlan := func() plan.Plan {
+		p := plan.NewPlan()
+		p.Spec.SourceDir = sourceDir
+		p.Spec.Transformers = map[string]string{"Golang-Dockerfile": transformerYamlPath}
+		p.Spec.Services = map[string][]plan.PlanArtifact{
+			"api": {{TransformerName: "Golang-Dockerfile", Artifact: transformertypes.Artifact{
+				Paths: map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {serviceDir}},
+			}}},
+		}
+		return p
+	}
+
+	t.Run("valid plan", func(t *testing.T) {
+		report := plan.ValidatePlan(newPlan(), availableTransformers)
+		if len(report.Issues) != 0 {
+			t.Fatalf("expected no issues. Actual: %s", report)
+		}
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		p := newPlan()
+		p.Spec.TransformerSelector = metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: "Bad"}}}
+		p.Spec.Transformers["Missing"] = transformerYamlPath
+		p.Spec.DisabledTransformers = map[string]string{"Disabled": transformerYamlPath}
+		p.Spec.InvokedByDefaultTransformers = []string{"Disabled"}
+		p.Spec.Services["API"] = []plan.PlanArtifact{{TransformerName: "Unknown", Artifact: transformertypes.Artifact{
+			Paths: map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {filepath.Join(sourceDir, "missing")}},
+		}}}
+		report := plan.ValidatePlan(p, availableTransformers)
+		if !report.HasErrors() {
+			t.Fatalf("expected errors. Actual: %s", report)
+		}
+		expectedKinds := map[plan.PlanValidationIssueKind]int{
+			plan.InvalidSelectorIssue:      1,
+			plan.TransformerNotFoundIssue:  2,
+			plan.DisabledTransformerIssue:  1,
+			plan.InvalidServiceNameIssue:   1,
+			plan.DuplicateServiceNameIssue: 1,
+			plan.PathNotFoundIssue:         1,
+		}
+		actualKinds := map[plan.PlanValidationIssueKind]int{}
+		for _, issue := range report.Issues {
+			actualKinds[issue.Kind]++
+		}
+		for kind, count := range expectedKinds {
+			if actualKinds[kind] != count {
+				t.Errorf("expected %d issues of kind %s . Actual: %d\n%s", count, kind, actualKinds[kind], report)
+			}
+		}
+	})
+}
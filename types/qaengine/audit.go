@@ -0,0 +1,73 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"time"
+)
+
+// AnswerSource is where the answer to a problem came from
+type AnswerSource string
+
+const (
+	// CLIAnswerSource is used for answers given interactively on the command line
+	CLIAnswerSource AnswerSource = "cli"
+	// RESTAnswerSource is used for answers given using the QA HTTP REST API
+	RESTAnswerSource AnswerSource = "rest"
+	// ConfigFileAnswerSource is used for answers read from a config file
+	ConfigFileAnswerSource AnswerSource = "config"
+	// SetConfigAnswerSource is used for answers given using --set-config
+	SetConfigAnswerSource AnswerSource = "set-config"
+	// PresetAnswerSource is used for answers read from a preset
+	PresetAnswerSource AnswerSource = "preset"
+	// CacheAnswerSource is used for answers read from a QA cache file
+	CacheAnswerSource AnswerSource = "cache"
+	// SecretAnswerSource is used for answers read from an environment variable, a file or an encrypted secret
+	SecretAnswerSource AnswerSource = "secret"
+	// DefaultAnswerSource is used when the default answer was used
+	DefaultAnswerSource AnswerSource = "default"
+	// ConditionAnswerSource is used when the problem was skipped because its conditions were not satisfied
+	ConditionAnswerSource AnswerSource = "condition"
+)
+
+// AnswerMetadata records how and when a problem was answered
+type AnswerMetadata struct {
+	Source AnswerSource `yaml:"source" json:"source"`
+	// Origin is the config file, config string or cache file that contained the answer,
+	// or the condition that was not satisfied for skipped problems
+	Origin string `yaml:"origin,omitempty" json:"origin,omitempty"`
+	// Engine is the type of the QA engine that answered the problem
+	Engine string    `yaml:"engine,omitempty" json:"engine,omitempty"`
+	Time   time.Time `yaml:"time" json:"time"`
+}
+
+// AuditLogEntry is a line in the QA audit log. The answers to password type problems and secrets are never logged.
+type AuditLogEntry struct {
+	ID             string           `yaml:"id" json:"id"`
+	Type           SolutionFormType `yaml:"type,omitempty" json:"type,omitempty"`
+	Answer         interface{}      `yaml:"answer,omitempty" json:"answer,omitempty"`
+	AnswerMetadata `yaml:",inline"`
+}
+
+// NewAuditLogEntry creates an audit log entry for an answered problem
+func NewAuditLogEntry(p Problem, metadata AnswerMetadata) AuditLogEntry {
+	entry := AuditLogEntry{ID: p.ID, Type: p.Type, AnswerMetadata: metadata}
+	if p.Type != PasswordSolutionFormType && metadata.Source != SecretAnswerSource {
+		entry.Answer = p.Answer
+	}
+	return entry
+}
@@ -38,6 +38,7 @@ type Cache struct {
 type CacheSpec struct {
 	file             string `yaml:"-"`
 	persistPasswords bool   `yaml:"-"`
+	persistMetadata  bool   `yaml:"-"`
 	// Problems stores the list of problems with resolutions
 	Problems []Problem `yaml:"solutions"`
 }
@@ -56,6 +57,11 @@ func NewCache(file string, persistPasswords bool) (cache *Cache) {
 	}
 }
 
+// SetPersistMetadata sets whether the cache should store where and when each answer came from
+func (cache *Cache) SetPersistMetadata(persistMetadata bool) {
+	cache.Spec.persistMetadata = persistMetadata
+}
+
 // Load loads and merges cache
 func (cache *Cache) Load() error {
 	c := Cache{}
@@ -86,6 +92,9 @@ func (cache *Cache) AddSolution(problem Problem) error {
 	if err != nil {
 		return fmt.Errorf("failed to serialize the problem. Error: %w", err)
 	}
+	if !cache.Spec.persistMetadata {
+		p.Metadata = nil
+	}
 	added := false
 	for i, cp := range cache.Spec.Problems {
 		if cp.ID == p.ID {
@@ -122,6 +131,11 @@ func (cache *Cache) GetSolution(p Problem) (Problem, error) {
 	return p, fmt.Errorf("the problem %+v was not found in the cache", p)
 }
 
+// GetSolutionSource returns the cache file as the source of the solutions
+func (cache *Cache) GetSolutionSource(Problem) (AnswerSource, string) {
+	return CacheAnswerSource, cache.Spec.file
+}
+
 func (cache *Cache) merge(c Cache) {
 	for _, p := range c.Spec.Problems {
 		found := false
@@ -80,7 +80,7 @@ func ImageRegistryNamespace() string {
 
 // IngressHost returns Ingress host
 func IngressHost(defaulthost string, clusterQaLabel string) string {
-	key := common.JoinQASubKeys(common.ConfigTargetKey, `"`+clusterQaLabel+`"`, common.ConfigIngressHostKeySuffix)
+	key := fmt.Sprintf(common.ConfigTargetIngressHostKey, `"`+clusterQaLabel+`"`)
 	return qaengine.FetchStringAnswer(key, "Provide the ingress host domain", []string{"Ingress host domain is part of service URL"}, defaulthost, nil)
 }
 
@@ -107,7 +107,7 @@ func GetPortsForService(detectedPorts []int32, qaSubKey string) []int32 {
 			detectedPortsStr = append(detectedPortsStr, cast.ToString(detectedPort))
 		}
 		allDetectedPortsStr := append(detectedPortsStr, qatypes.OtherAnswer)
-		quesKey := common.JoinQASubKeys(common.ConfigServicesKey, qaSubKey, common.ConfigPortsForServiceKeySegment)
+		quesKey := fmt.Sprintf(common.ConfigServicesPortsKey, qaSubKey)
 		desc := fmt.Sprintf("Select ports to be exposed for the service '%s' :", qaSubKey)
 		hints := []string{"Select 'Other' if you want to add more ports"}
 		selectedPortsStr = qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, detectedPortsStr, allDetectedPortsStr, nil)
@@ -128,7 +128,7 @@ func GetPortsForService(detectedPorts []int32, qaSubKey string) []int32 {
 
 // GetPortForService returns the port to expose the service on.
 func GetPortForService(detectedPorts []int32, qaSubKey string) int32 {
-	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, qaSubKey, common.ConfigPortForServiceKeySegment)
+	quesKey := fmt.Sprintf(common.ConfigServicesPortKey, qaSubKey)
 	desc := fmt.Sprintf("Select the port to be exposed for the '%s' service :", qaSubKey)
 	hints := []string{"Select 'Other' if you want to expose the service using a different port."}
 	detectedPortStrs := []string{}
@@ -155,7 +155,7 @@ func GetPortForService(detectedPorts []int32, qaSubKey string) int32 {
 
 // GetDeploymentType returns the type of Deployment the service should generate
 func GetDeploymentType(serviceName string) ir.DeploymentType {
-	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, serviceName, common.ConfigDeploymentTypeKey)
+	quesKey := fmt.Sprintf(common.ConfigServicesDeploymentTypeKey, serviceName)
 	desc := fmt.Sprintf("For the service %s, which type of deployment is required?", serviceName)
 	def := string(ir.DeploymentTypeDeployment)
 	options := []string{string(ir.DeploymentTypeDeployment), string(ir.DeploymentTypeStatefulSet), string(ir.DeploymentTypeArgoRollout)}
@@ -0,0 +1,88 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProblemCondition makes a problem depend on the answer to another problem
+type ProblemCondition struct {
+	// ProblemID is the ID of the problem this problem depends on
+	ProblemID string `yaml:"problemID" json:"problemID"`
+	// Answers are the answers to the other problem for which this problem is asked.
+	// For multi-select problems it is enough for one of the selected options to be in the list.
+	// If empty, the problem is asked when the other problem was answered with anything other than false or an empty answer.
+	// The other problem must be asked first or be answered by the configs, caches or secrets.
+	Answers []interface{} `yaml:"answers,omitempty" json:"answers,omitempty"`
+}
+
+// IsSatisfiedBy returns true if the answer to the other problem satisfies the condition.
+// Answers from the secrets are always strings, so the strings true and false are treated as booleans.
+func (c ProblemCondition) IsSatisfiedBy(answer interface{}) bool {
+	if actual, ok := answer.(string); ok {
+		if strings.EqualFold(actual, "true") || strings.EqualFold(actual, "false") {
+			answer = strings.EqualFold(actual, "true")
+		}
+	}
+	answers := []string{}
+	switch actual := answer.(type) {
+	case nil:
+	case []string:
+		answers = actual
+	case []interface{}:
+		for _, a := range actual {
+			answers = append(answers, fmt.Sprint(a))
+		}
+	case bool:
+		if len(c.Answers) == 0 {
+			return actual
+		}
+		answers = append(answers, fmt.Sprint(actual))
+	default:
+		answers = append(answers, fmt.Sprint(actual))
+	}
+	if len(c.Answers) == 0 {
+		for _, a := range answers {
+			if a != "" {
+				return true
+			}
+		}
+		return false
+	}
+	for _, a := range answers {
+		for _, expected := range c.Answers {
+			if a == fmt.Sprint(expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String returns the condition in a human readable form
+func (c ProblemCondition) String() string {
+	if len(c.Answers) == 0 {
+		return c.ProblemID + " is answered"
+	}
+	expected := []string{}
+	for _, a := range c.Answers {
+		expected = append(expected, fmt.Sprint(a))
+	}
+	return fmt.Sprintf("%s is one of [%s]", c.ProblemID, strings.Join(expected, ", "))
+}
@@ -0,0 +1,51 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine_test
+
+import (
+	"testing"
+
+	"github.com/konveyor/move2kube/types/qaengine"
+)
+
+func TestProblemConditionIsSatisfiedBy(t *testing.T) {
+	testcases := []struct {
+		name      string
+		condition qaengine.ProblemCondition
+		answer    interface{}
+		want      bool
+	}{
+		{name: "confirmed", condition: qaengine.ProblemCondition{ProblemID: "a"}, answer: true, want: true},
+		{name: "not confirmed", condition: qaengine.ProblemCondition{ProblemID: "a"}, answer: false, want: false},
+		{name: "empty input", condition: qaengine.ProblemCondition{ProblemID: "a"}, answer: "", want: false},
+		{name: "matching bool", condition: qaengine.ProblemCondition{ProblemID: "a", Answers: []interface{}{false}}, answer: false, want: true},
+		{name: "matching select", condition: qaengine.ProblemCondition{ProblemID: "a", Answers: []interface{}{"b", "c"}}, answer: "c", want: true},
+		{name: "other select", condition: qaengine.ProblemCondition{ProblemID: "a", Answers: []interface{}{"b", "c"}}, answer: "d", want: false},
+		{name: "matching multiselect", condition: qaengine.ProblemCondition{ProblemID: "a", Answers: []interface{}{"c"}}, answer: []string{"b", "c"}, want: true},
+		{name: "confirmed secret", condition: qaengine.ProblemCondition{ProblemID: "a"}, answer: "true", want: true},
+		{name: "not confirmed secret", condition: qaengine.ProblemCondition{ProblemID: "a"}, answer: "false", want: false},
+		{name: "matching bool secret", condition: qaengine.ProblemCondition{ProblemID: "a", Answers: []interface{}{true}}, answer: "TRUE", want: true},
+		{name: "empty multiselect", condition: qaengine.ProblemCondition{ProblemID: "a"}, answer: []string{}, want: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.condition.IsSatisfiedBy(tc.answer); got != tc.want {
+				t.Fatalf("expected %t for the answer %#v. Actual: %t", tc.want, tc.answer, got)
+			}
+		})
+	}
+}
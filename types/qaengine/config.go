@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -43,10 +44,18 @@ type Config struct {
 	configStrings    []string
 	yamlMap          mapT
 	writeYamlMap     mapT
+	layers           []configLayer
 	OutputPath       string
 	persistPasswords bool
 }
 
+// configLayer is one of the config files or config strings that are merged to form the config
+type configLayer struct {
+	source  AnswerSource
+	origin  string
+	yamlMap mapT
+}
+
 var arrayIndexRegex = regexp.MustCompile(`^\[(\d+)\]$`)
 
 // Implement the Store interface
@@ -55,6 +64,16 @@ var arrayIndexRegex = regexp.MustCompile(`^\[(\d+)\]$`)
 func (c *Config) Load() (err error) {
 	logrus.Debugf("Config.Load")
 	yamlDatas := []string{}
+	c.layers = []configLayer{}
+	addLayer := func(source AnswerSource, origin, yamlData string) {
+		layer := configLayer{source: source, origin: origin}
+		if err := yaml.Unmarshal([]byte(yamlData), &layer.yamlMap); err != nil {
+			logrus.Debugf("failed to unmarshal the config from %s . Error: %q", origin, err)
+			return
+		}
+		c.layers = append(c.layers, layer)
+	}
+	presetsDir := filepath.Join(common.AssetsPath, "built-in", "presets")
 	// config files specified later override earlier config files
 	for _, configFile := range c.configFiles {
 		yamlData, err := os.ReadFile(configFile)
@@ -63,6 +82,11 @@ func (c *Config) Load() (err error) {
 			continue
 		}
 		yamlDatas = append(yamlDatas, string(yamlData))
+		if common.IsParent(configFile, presetsDir) {
+			addLayer(PresetAnswerSource, strings.TrimSuffix(filepath.Base(configFile), filepath.Ext(configFile)), string(yamlData))
+		} else {
+			addLayer(ConfigFileAnswerSource, configFile, string(yamlData))
+		}
 	}
 	// config strings override config files
 	// config strings specified later override earlier config strings
@@ -83,6 +107,7 @@ func (c *Config) Load() (err error) {
 		}
 		logrus.Debugf("after parsing the yamlData is:\n%s", yamlData)
 		yamlDatas = append(yamlDatas, yamlData)
+		addLayer(SetConfigAnswerSource, c.configStrings[i], yamlData)
 	}
 	c.yamlMap, err = MergeYAMLDatasIntoMap(yamlDatas)
 	c.writeYamlMap = mapT{}
@@ -94,11 +119,13 @@ func (c *Config) convertAnswer(p Problem, value interface{}) (Problem, error) {
 	return p, nil
 }
 
-func (c *Config) normalGetSolution(p Problem) (Problem, error) {
-	key := p.ID
-	value, ok := c.Get(key)
-	if ok {
-		return c.convertAnswer(p, value)
+// getCandidateKeys returns the keys that are looked up in order to find the answer for a problem
+func getCandidateKeys(key string) []string {
+	keys := []string{key}
+	if strings.Contains(key, common.Special) {
+		// for 'a.b.[].d' the answers are stored under the base key 'a.b'
+		idx := strings.LastIndex(key, common.Special)
+		return []string{key[:idx-len(common.Delim)]}
 	}
 	// starting from 2nd last subkey replace with match all selector *
 	// Example: Given a.b.c.d.e this matches a.b.c.*.e, then a.b.*.d.e, then a.*.c.d.e
@@ -106,10 +133,15 @@ func (c *Config) normalGetSolution(p Problem) (Problem, error) {
 	for idx := len(subKeys) - 2; idx > 0; idx-- {
 		baseKey := strings.Join(subKeys[:idx], common.Delim)
 		lastKeySegment := strings.Join(subKeys[idx+1:], common.Delim)
-		newKey := baseKey + common.Delim + common.MatchAll + common.Delim + lastKeySegment
-		v, ok := c.Get(newKey)
-		if ok {
-			return c.convertAnswer(p, v)
+		keys = append(keys, baseKey+common.Delim+common.MatchAll+common.Delim+lastKeySegment)
+	}
+	return keys
+}
+
+func (c *Config) normalGetSolution(p Problem) (Problem, error) {
+	for _, key := range getCandidateKeys(p.ID) {
+		if value, ok := c.Get(key); ok {
+			return c.convertAnswer(p, value)
 		}
 	}
 	return p, fmt.Errorf("no answer found in the config for the problem:%+v", p)
@@ -198,6 +230,23 @@ func (c *Config) GetSolution(p Problem) (Problem, error) {
 	return problem, nil
 }
 
+// GetSolutionSource returns the config file, preset or config string that has the solution for the problem.
+// Config strings override config files which override presets.
+// Returns an empty source if the solution was added to the config during this run.
+func (c *Config) GetSolutionSource(p Problem) (AnswerSource, string) {
+	for _, key := range getCandidateKeys(p.ID) {
+		for i := len(c.layers) - 1; i >= 0; i-- {
+			if _, ok := get(key, c.layers[i].yamlMap); ok {
+				return c.layers[i].source, c.layers[i].origin
+			}
+		}
+		if _, ok := c.Get(key); ok {
+			return "", ""
+		}
+	}
+	return "", ""
+}
+
 // Write writes the config to disk
 func (c *Config) Write() error {
 	logrus.Debugf("Config.Write write the file out")
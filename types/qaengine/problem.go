@@ -62,6 +62,11 @@ type Problem struct {
 	Answer     interface{}             `yaml:"answer,omitempty" json:"answer,omitempty"`
 	Validator  func(interface{}) error `yaml:"-" json:"-"`
 	Categories []string                `yaml:"categories,omitempty" json:"categories,omitempty"`
+	Metadata   *AnswerMetadata         `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	// Conditions must all be satisfied by the answers to the other problems for this problem to be asked.
+	// If any of them is not satisfied, the problem is skipped and gets the default answer.
+	// Asking a problem before the problems it depends on is an error.
+	Conditions []ProblemCondition `yaml:"conditions,omitempty" json:"conditions,omitempty"`
 }
 
 // NewProblem creates a new problem object from a GRPC problem
@@ -0,0 +1,129 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/konveyor/move2kube/common"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+)
+
+// QACatalog is the list of questions that can be asked by the active transformers
+type QACatalog struct {
+	Questions []QACatalogEntry `yaml:"questions" json:"questions"`
+}
+
+// QACatalogEntry describes a question that can be asked.
+// The dynamic parts of the problem ID, like the service name, are replaced with a *
+type QACatalogEntry struct {
+	ID           string           `yaml:"id" json:"id"`
+	Type         SolutionFormType `yaml:"type,omitempty" json:"type,omitempty"`
+	Description  string           `yaml:"description,omitempty" json:"description,omitempty"`
+	Default      interface{}      `yaml:"default,omitempty" json:"default,omitempty"`
+	Options      []string         `yaml:"options,omitempty" json:"options,omitempty"`
+	Categories   []string         `yaml:"categories,omitempty" json:"categories,omitempty"`
+	Transformers []string         `yaml:"transformers,omitempty" json:"transformers,omitempty"`
+}
+
+// AddCategories fills in the categories of each question using the QA category mappings.
+// Problem IDs in the mappings that don't match any of the questions are added to the catalog.
+func (c *QACatalog) AddCategories() {
+	for i, question := range c.Questions {
+		categories := common.UniqueStrings(GetProblemCategories(question.ID, nil))
+		sort.Strings(categories)
+		c.Questions[i].Categories = categories
+	}
+	extraQuestions := map[string][]string{}
+	for category, probIds := range common.QACategoryMap {
+		for _, probId := range probIds {
+			if c.matchesAnyQuestion(probId) {
+				continue
+			}
+			extraQuestions[probId] = append(extraQuestions[probId], category)
+		}
+	}
+	extraProbIds := []string{}
+	for probId := range extraQuestions {
+		extraProbIds = append(extraProbIds, probId)
+	}
+	sort.Strings(extraProbIds)
+	for _, probId := range extraProbIds {
+		categories := extraQuestions[probId]
+		sort.Strings(categories)
+		c.Questions = append(c.Questions, QACatalogEntry{ID: probId, Categories: categories})
+	}
+}
+
+func (c *QACatalog) matchesAnyQuestion(probId string) bool {
+	if !strings.Contains(probId, "*") {
+		for _, question := range c.Questions {
+			if question.ID == probId {
+				return true
+			}
+		}
+		return false
+	}
+	g, err := glob.Compile(probId)
+	if err != nil {
+		logrus.Errorf("invalid problem ID glob: %s\n", probId)
+		return false
+	}
+	for _, question := range c.Questions {
+		if g.Match(question.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Markdown returns the catalog as a markdown table
+func (c QACatalog) Markdown() string {
+	sb := strings.Builder{}
+	sb.WriteString("| ID | Type | Description | Default | Options | Categories | Transformers |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, question := range c.Questions {
+		def := ""
+		switch defs := question.Default.(type) {
+		case nil:
+		case []string:
+			def = strings.Join(defs, ", ")
+		case []interface{}:
+			def = strings.Join(cast.ToStringSlice(defs), ", ")
+		default:
+			def = fmt.Sprintf("%v", defs)
+		}
+		cells := []string{
+			"`" + question.ID + "`",
+			string(question.Type),
+			question.Description,
+			def,
+			strings.Join(question.Options, ", "),
+			strings.Join(question.Categories, ", "),
+			strings.Join(question.Transformers, ", "),
+		}
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(strings.ReplaceAll(cell, "|", `\|`), "\n", " ")
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return sb.String()
+}
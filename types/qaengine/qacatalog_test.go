@@ -0,0 +1,69 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/types/qaengine"
+)
+
+func TestQACatalogAddCategories(t *testing.T) {
+	oldQACategoryMap := common.QACategoryMap
+	defer func() { common.QACategoryMap = oldQACategoryMap }()
+	common.QACategoryMap = map[string][]string{
+		"ports":   {"move2kube.services.*.ports"},
+		"network": {"move2kube.services.*.ports", "move2kube.services.*.*.urlpath"},
+		"git":     {"move2kube.vcs.git.name"},
+	}
+	catalog := qaengine.QACatalog{Questions: []qaengine.QACatalogEntry{
+		{ID: "move2kube.services.*.ports", Type: qaengine.MultiSelectSolutionFormType},
+		{ID: "move2kube.minreplicas", Type: qaengine.InputSolutionFormType},
+	}}
+	catalog.AddCategories()
+	want := []qaengine.QACatalogEntry{
+		{ID: "move2kube.services.*.ports", Type: qaengine.MultiSelectSolutionFormType, Categories: []string{"network", "ports"}},
+		{ID: "move2kube.minreplicas", Type: qaengine.InputSolutionFormType, Categories: []string{"default"}},
+		{ID: "move2kube.services.*.*.urlpath", Categories: []string{"network"}},
+		{ID: "move2kube.vcs.git.name", Categories: []string{"git"}},
+	}
+	if !reflect.DeepEqual(catalog.Questions, want) {
+		t.Fatalf("failed to add the categories. Expected: %+v Actual: %+v", want, catalog.Questions)
+	}
+}
+
+func TestQACatalogMarkdown(t *testing.T) {
+	catalog := qaengine.QACatalog{Questions: []qaengine.QACatalogEntry{{
+		ID:          "move2kube.services.*.dockerfileType",
+		Type:        qaengine.SelectSolutionFormType,
+		Description: "Pick one | or the other",
+		Default:     "b",
+		Options:     []string{"a", "b"},
+		Categories:  []string{"sourceanalyzer"},
+	}}}
+	lines := strings.Split(strings.TrimSpace(catalog.Markdown()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header, a separator and a row. Actual: %q", lines)
+	}
+	want := "| `move2kube.services.*.dockerfileType` | Select | Pick one \\| or the other | b | a, b | sourceanalyzer |  |"
+	if lines[2] != want {
+		t.Fatalf("failed to format the row. Expected: %q Actual: %q", want, lines[2])
+	}
+}
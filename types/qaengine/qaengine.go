@@ -30,6 +30,8 @@ import (
 type Store interface {
 	Load() error
 	GetSolution(Problem) (Problem, error)
+	// GetSolutionSource returns where the solution returned by GetSolution came from, along with the file or config string that had it
+	GetSolutionSource(Problem) (AnswerSource, string)
 
 	Write() error
 	AddSolution(p Problem) error
@@ -0,0 +1,325 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/konveyor/move2kube/common"
+	"github.com/konveyor/move2kube/types"
+	"github.com/sirupsen/logrus"
+)
+
+// QASecretsKind defines kind of QA secrets file
+const QASecretsKind types.Kind = "QASecrets"
+
+const (
+	// secretKeySize is the size in bytes of the AES-256 key used to encrypt the answers
+	secretKeySize = 32
+	// envSecretOrigin is the prefix of the origin of answers read from environment variables
+	envSecretOrigin = "env:"
+	// fileSecretOrigin is the prefix of the origin of answers read from files
+	fileSecretOrigin = "file:"
+	// encryptedSecretOrigin is the prefix of the origin of answers decrypted from the secrets file
+	encryptedSecretOrigin = "encrypted:"
+)
+
+// QASecrets maps problem IDs to external secret sources
+type QASecrets struct {
+	types.TypeMeta   `yaml:",inline" json:",inline"`
+	types.ObjectMeta `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	Spec             QASecretsSpec `yaml:"spec" json:"spec"`
+}
+
+// QASecretsSpec stores the secret sources
+type QASecretsSpec struct {
+	// KeyFile is the file containing the key used to decrypt the encrypted answers.
+	// Relative paths are relative to the secrets file.
+	KeyFile string `yaml:"keyFile,omitempty" json:"keyFile,omitempty"`
+	// Secrets are tried in order. The first secret that matches the problem ID and has a value is used.
+	Secrets []QASecret `yaml:"secrets" json:"secrets"`
+}
+
+// QASecret is the source of the answers for the problems whose IDs match the pattern.
+// Exactly one of Env, File and Encrypted should be set.
+type QASecret struct {
+	// ProblemID is the ID of the problem. '*' can be used as a wildcard.
+	ProblemID string `yaml:"problemID" json:"problemID"`
+	// Env is the name of the environment variable containing the answer
+	Env string `yaml:"env,omitempty" json:"env,omitempty"`
+	// File is the path of the file containing the answer, for example a mounted secret.
+	// Relative paths are relative to the secrets file.
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+	// Encrypted is the base64 encoded answer encrypted with the key in the key file
+	Encrypted string `yaml:"encrypted,omitempty" json:"encrypted,omitempty"`
+}
+
+// SecretStore answers problems using environment variables, files and encrypted answers.
+// The answers are never written back.
+type SecretStore struct {
+	file    string
+	secrets QASecrets
+	globs   []glob.Glob
+	key     []byte
+	// origins contains the source of the latest answer to each problem
+	origins map[string]string
+}
+
+// NewSecretStore creates a new secret store from a secrets file
+func NewSecretStore(file string) *SecretStore {
+	return &SecretStore{file: file, origins: map[string]string{}}
+}
+
+// Load reads the secrets file and the key file
+func (s *SecretStore) Load() error {
+	secrets := QASecrets{}
+	if err := common.ReadMove2KubeYamlStrict(s.file, &secrets, string(QASecretsKind)); err != nil {
+		return fmt.Errorf("failed to load the QA secrets file at path '%s' . Error: %w", s.file, err)
+	}
+	globs := []glob.Glob{}
+	hasEncrypted := false
+	for _, secret := range secrets.Spec.Secrets {
+		numSources := 0
+		for _, source := range []string{secret.Env, secret.File, secret.Encrypted} {
+			if source != "" {
+				numSources++
+			}
+		}
+		if numSources != 1 {
+			return fmt.Errorf("the secret for the problem ID '%s' in the file '%s' should have exactly one of env, file and encrypted", secret.ProblemID, s.file)
+		}
+		g, err := glob.Compile(secret.ProblemID)
+		if err != nil {
+			return fmt.Errorf("the problem ID pattern '%s' in the file '%s' is invalid. Error: %w", secret.ProblemID, s.file, err)
+		}
+		globs = append(globs, g)
+		if secret.Encrypted != "" {
+			hasEncrypted = true
+		}
+	}
+	if hasEncrypted {
+		if secrets.Spec.KeyFile == "" {
+			return fmt.Errorf("the file '%s' has encrypted secrets but no key file", s.file)
+		}
+		key, err := ReadSecretKeyFile(s.resolvePath(secrets.Spec.KeyFile))
+		if err != nil {
+			return fmt.Errorf("failed to read the key file for the secrets file '%s' . Error: %w", s.file, err)
+		}
+		s.key = key
+	}
+	s.secrets = secrets
+	s.globs = globs
+	return nil
+}
+
+// resolvePath makes relative paths relative to the directory containing the secrets file
+func (s *SecretStore) resolvePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(s.file), path)
+}
+
+// getValue returns the value of the secret along with its origin
+func (s *SecretStore) getValue(secret QASecret) (value string, origin string, err error) {
+	switch {
+	case secret.Env != "":
+		value, ok := os.LookupEnv(secret.Env)
+		if !ok {
+			return "", "", fmt.Errorf("the environment variable '%s' is not set", secret.Env)
+		}
+		return value, envSecretOrigin + secret.Env, nil
+	case secret.File != "":
+		path := s.resolvePath(secret.File)
+		valueBytes, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read the secret file at path '%s' . Error: %w", path, err)
+		}
+		return strings.TrimRight(string(valueBytes), "\r\n"), fileSecretOrigin + path, nil
+	default:
+		value, err := DecryptSecret(s.key, secret.Encrypted)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decrypt the secret for the problem ID '%s' . Error: %w", secret.ProblemID, err)
+		}
+		return value, encryptedSecretOrigin + s.file, nil
+	}
+}
+
+// GetSolution reads the solution from the first matching secret that has a value
+func (s *SecretStore) GetSolution(p Problem) (Problem, error) {
+	if p.Answer != nil {
+		logrus.Warnf("Problem already solved.")
+		return p, nil
+	}
+	p, origin, err := s.lookupSolution(p)
+	if err != nil {
+		return p, err
+	}
+	s.origins[p.ID] = origin
+	return p, nil
+}
+
+// LookupSolution reads the solution like GetSolution but does not change the source returned by GetSolutionSource
+func (s *SecretStore) LookupSolution(p Problem) (Problem, error) {
+	if p.Answer != nil {
+		return p, nil
+	}
+	p, _, err := s.lookupSolution(p)
+	return p, err
+}
+
+// lookupSolution reads the solution from the first matching secret that has a value and returns its origin
+func (s *SecretStore) lookupSolution(p Problem) (Problem, string, error) {
+	for i, secret := range s.secrets.Spec.Secrets {
+		if !s.globs[i].Match(p.ID) {
+			continue
+		}
+		value, origin, err := s.getValue(secret)
+		if err != nil {
+			logrus.Debugf("skipping the secret for the problem ID '%s' . Error: %q", secret.ProblemID, err)
+			continue
+		}
+		answer, err := convertSecretValue(p.Type, value)
+		if err != nil {
+			return p, "", fmt.Errorf("the secret from '%s' is not a valid answer for the problem '%s' . Error: %w", origin, p.ID, err)
+		}
+		p.Answer = answer
+		return p, origin, nil
+	}
+	return p, "", fmt.Errorf("no secret found for the problem %+v", p)
+}
+
+// GetSolutionSource returns the environment variable, file or secrets file that had the solution
+func (s *SecretStore) GetSolutionSource(p Problem) (AnswerSource, string) {
+	return SecretAnswerSource, s.origins[p.ID]
+}
+
+// Write does nothing since the secrets are never written
+func (*SecretStore) Write() error {
+	return nil
+}
+
+// AddSolution does nothing since the secrets are never written
+func (*SecretStore) AddSolution(Problem) error {
+	return fmt.Errorf("solutions are not added to the secret store")
+}
+
+// convertSecretValue converts the value of a secret to an answer of the given problem type
+func convertSecretValue(problemType SolutionFormType, value string) (interface{}, error) {
+	switch problemType {
+	case ConfirmSolutionFormType:
+		answer, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			// the error from ParseBool contains the value, which must not be logged
+			return nil, fmt.Errorf("expected a boolean value like true or false")
+		}
+		return answer, nil
+	case MultiSelectSolutionFormType:
+		answers := []string{}
+		for _, answer := range strings.Split(value, ",") {
+			if answer = strings.TrimSpace(answer); answer != "" {
+				answers = append(answers, answer)
+			}
+		}
+		return answers, nil
+	default:
+		return value, nil
+	}
+}
+
+// GenerateSecretKeyFile creates a key file with a new random key that can be used to encrypt answers
+func GenerateSecretKeyFile(path string) ([]byte, error) {
+	key := make([]byte, secretKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate a random key. Error: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write the key file at path '%s' . Error: %w", path, err)
+	}
+	return key, nil
+}
+
+// ReadSecretKeyFile reads the base64 encoded key from a key file
+func ReadSecretKeyFile(path string) ([]byte, error) {
+	keyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key file at path '%s' . Error: %w", path, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(keyBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to base64 decode the key in the file at path '%s' . Error: %w", path, err)
+	}
+	if len(key) != secretKeySize {
+		return nil, fmt.Errorf("expected the key in the file at path '%s' to be %d bytes long. Actual length %d", path, secretKeySize, len(key))
+	}
+	return key, nil
+}
+
+// EncryptSecret encrypts the answer using AES-GCM and returns it base64 encoded
+func EncryptSecret(key []byte, plainText string) (string, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate a nonce. Error: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plainText), nil)), nil
+}
+
+// DecryptSecret decrypts a base64 encoded answer that was encrypted using EncryptSecret
+func DecryptSecret(key []byte, cipherText string) (string, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	cipherBytes, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", fmt.Errorf("failed to base64 decode the encrypted answer. Error: %w", err)
+	}
+	if len(cipherBytes) < gcm.NonceSize() {
+		return "", fmt.Errorf("the encrypted answer is too short")
+	}
+	nonce, sealed := cipherBytes[:gcm.NonceSize()], cipherBytes[gcm.NonceSize():]
+	plainBytes, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the encrypted answer. Error: %w", err)
+	}
+	return string(plainBytes), nil
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the AES cipher. Error: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the GCM cipher. Error: %w", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,155 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/move2kube/types/qaengine"
+)
+
+func TestSecretStore(t *testing.T) {
+	tempDir := t.TempDir()
+	key, err := qaengine.GenerateSecretKeyFile(filepath.Join(tempDir, "secrets.key"))
+	if err != nil {
+		t.Fatalf("failed to generate the key file. Error: %q", err)
+	}
+	encrypted, err := qaengine.EncryptSecret(key, "encrypted-password")
+	if err != nil {
+		t.Fatalf("failed to encrypt the secret. Error: %q", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "password"), []byte("file-password\n"), 0600); err != nil {
+		t.Fatalf("failed to write the secret file. Error: %q", err)
+	}
+	t.Setenv("M2K_TEST_ENV_PASSWORD", "env-password")
+	t.Setenv("M2K_TEST_ENABLED", "true")
+	t.Setenv("M2K_TEST_INVALID_BOOL", "hunter2")
+	secretsFile := filepath.Join(tempDir, "secrets.yaml")
+	secretsYaml := fmt.Sprintf(`apiVersion: move2kube.konveyor.io/v1alpha1
+kind: QASecrets
+spec:
+  keyFile: secrets.key
+  secrets:
+    - problemID: move2kube.env.password
+      env: M2K_TEST_ENV_PASSWORD
+    - problemID: move2kube.*.file.password
+      env: M2K_TEST_UNSET_PASSWORD
+    - problemID: move2kube.*.file.password
+      file: password
+    - problemID: move2kube.encrypted.password
+      encrypted: %s
+    - problemID: move2kube.enabled
+      env: M2K_TEST_ENABLED
+    - problemID: move2kube.invalid.enabled
+      env: M2K_TEST_INVALID_BOOL
+`, encrypted)
+	if err := os.WriteFile(secretsFile, []byte(secretsYaml), 0600); err != nil {
+		t.Fatalf("failed to write the secrets file. Error: %q", err)
+	}
+	store := qaengine.NewSecretStore(secretsFile)
+	if err := store.Load(); err != nil {
+		t.Fatalf("failed to load the secret store. Error: %q", err)
+	}
+
+	testcases := []struct {
+		id           string
+		solutionType qaengine.SolutionFormType
+		want         interface{}
+		wantOrigin   string
+	}{
+		{id: "move2kube.env.password", solutionType: qaengine.PasswordSolutionFormType, want: "env-password", wantOrigin: "env:M2K_TEST_ENV_PASSWORD"},
+		{id: "move2kube.svc1.file.password", solutionType: qaengine.PasswordSolutionFormType, want: "file-password", wantOrigin: "file:" + filepath.Join(tempDir, "password")},
+		{id: "move2kube.encrypted.password", solutionType: qaengine.PasswordSolutionFormType, want: "encrypted-password", wantOrigin: "encrypted:" + secretsFile},
+		{id: "move2kube.enabled", solutionType: qaengine.ConfirmSolutionFormType, want: true, wantOrigin: "env:M2K_TEST_ENABLED"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.id, func(t *testing.T) {
+			p, err := store.GetSolution(qaengine.Problem{ID: tc.id, Type: tc.solutionType})
+			if err != nil {
+				t.Fatalf("failed to get the solution. Error: %q", err)
+			}
+			if !reflect.DeepEqual(p.Answer, tc.want) {
+				t.Fatalf("expected the answer to be %#v. Actual: %#v", tc.want, p.Answer)
+			}
+			source, origin := store.GetSolutionSource(p)
+			if source != qaengine.SecretAnswerSource || origin != tc.wantOrigin {
+				t.Fatalf("expected the source to be %s %s. Actual: %s %s", qaengine.SecretAnswerSource, tc.wantOrigin, source, origin)
+			}
+		})
+	}
+
+	t.Run("invalid secret does not leak the value", func(t *testing.T) {
+		_, err := store.GetSolution(qaengine.Problem{ID: "move2kube.invalid.enabled", Type: qaengine.ConfirmSolutionFormType})
+		if err == nil {
+			t.Fatal("expected an error for a secret that is not a boolean")
+		}
+		if strings.Contains(err.Error(), "hunter2") {
+			t.Fatalf("the error contains the value of the secret: %q", err)
+		}
+	})
+
+	t.Run("lookup does not change the solution source", func(t *testing.T) {
+		p, err := store.LookupSolution(qaengine.Problem{ID: "move2kube.svc2.file.password", Type: qaengine.PasswordSolutionFormType})
+		if err != nil || p.Answer != "file-password" {
+			t.Fatalf("failed to look up the solution. Answer: %#v Error: %q", p.Answer, err)
+		}
+		if _, origin := store.GetSolutionSource(p); origin != "" {
+			t.Fatalf("expected the lookup to not record the origin. Actual: %s", origin)
+		}
+	})
+
+	t.Run("problem without a secret", func(t *testing.T) {
+		if _, err := store.GetSolution(qaengine.Problem{ID: "move2kube.other.password", Type: qaengine.PasswordSolutionFormType}); err == nil {
+			t.Fatal("expected an error for a problem without a secret")
+		}
+	})
+}
+
+func TestSecretStoreEncryptedWithoutKeyFile(t *testing.T) {
+	secretsFile := filepath.Join(t.TempDir(), "secrets.yaml")
+	secretsYaml := `apiVersion: move2kube.konveyor.io/v1alpha1
+kind: QASecrets
+spec:
+  secrets:
+    - problemID: move2kube.encrypted.password
+      encrypted: YWJjZA==
+`
+	if err := os.WriteFile(secretsFile, []byte(secretsYaml), 0600); err != nil {
+		t.Fatalf("failed to write the secrets file. Error: %q", err)
+	}
+	if err := qaengine.NewSecretStore(secretsFile).Load(); err == nil {
+		t.Fatal("expected an error when loading encrypted secrets without a key file")
+	}
+}
+
+func TestDecryptSecretWithWrongKey(t *testing.T) {
+	key := make([]byte, 32)
+	encrypted, err := qaengine.EncryptSecret(key, "password")
+	if err != nil {
+		t.Fatalf("failed to encrypt the secret. Error: %q", err)
+	}
+	wrongKey := make([]byte, 32)
+	wrongKey[0] = 1
+	if _, err := qaengine.DecryptSecret(wrongKey, encrypted); err == nil {
+		t.Fatal("expected an error when decrypting with the wrong key")
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package artifacts
+
+import (
+	"github.com/konveyor/move2kube/types/ir"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+// configSchema is the Go type that the configs of a config type are decoded into, the version of the type
+// and the artifact type whose configs it applies to
+type configSchema struct {
+	artifactType transformertypes.ArtifactType
+	version      string
+	obj          interface{}
+}
+
+// configSchemas has the schemas of the config types. Bump the version when changing a type, the versions of the
+// generated schemas are recorded in testdata/schemaversions.yaml and TestConfigSchemaVersions fails until it is bumped.
+// The configs that are shared between artifact types are registered for any artifact type.
+var configSchemas = map[transformertypes.ConfigType]configSchema{
+	ServiceConfigType:                 {version: "1.0.0", obj: ServiceConfig{}},
+	ImageNameConfigType:               {version: "1.0.0", obj: ImageName{}},
+	OriginalNameConfigType:            {version: "1.0.0", obj: OriginalNameConfig{}},
+	CloudFoundryConfigType:            {version: "1.0.0", obj: CloudFoundryConfig{}},
+	ContainerizationOptionsConfigType: {version: "1.0.0", obj: ContainerizationOptionsConfig{}},
+	DotNetConfigType:                  {version: "1.0.0", obj: DotNetConfig{}},
+	GradleConfigType:                  {version: "1.0.0", obj: GradleConfig{}},
+	MavenConfigType:                   {version: "1.0.0", obj: MavenConfig{}},
+	SpringBootConfigType:              {version: "1.0.0", obj: SpringBootConfig{}},
+	JarConfigType:                     {version: "1.0.0", obj: JarArtifactConfig{}},
+	WarConfigType:                     {version: "1.0.0", obj: WarArtifactConfig{}},
+	EarConfigType:                     {version: "1.0.0", obj: EarArtifactConfig{}},
+	InvokeDetectConfigType:            {version: "1.0.0", obj: InvokeDetectConfig{}},
+	NewImagesConfigType:               {artifactType: NewImagesArtifactType, version: "1.0.0", obj: NewImages{}},
+	ir.IRConfigType:                   {version: "1.0.0", obj: ir.IR{}},
+}
+
+func init() {
+	for configType, cs := range configSchemas {
+		if err := transformertypes.RegisterConfigSchema(cs.artifactType, configType, cs.version, cs.obj); err != nil {
+			logrus.Errorf("failed to register the schema for the config type '%s' . Error: %q", configType, err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/move2kube/types/ir"
+	transformertypes "github.com/konveyor/move2kube/types/transformer"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateConfigs(t *testing.T) {
+	t.Run("configs with the registered types are valid", func(t *testing.T) {
+		a := transformertypes.Artifact{Name: "a", Type: ServiceArtifactType, Configs: map[transformertypes.ConfigType]interface{}{
+			ServiceConfigType:   ServiceConfig{ServiceName: "svc"},
+			ImageNameConfigType: &ImageName{ImageName: "img"},
+			ir.IRConfigType:     ir.NewIR(),
+		}}
+		if err := a.ValidateConfigs(); err != nil {
+			t.Fatalf("expected the configs to be valid. Error: %q", err)
+		}
+	})
+	t.Run("generic configs that match the schema are valid", func(t *testing.T) {
+		a := transformertypes.Artifact{Name: "a", Type: JarArtifactType, Configs: map[transformertypes.ConfigType]interface{}{
+			ServiceConfigType:        map[string]interface{}{"serviceName": "svc"},
+			JarConfigType:            map[string]interface{}{"port": 8080, "envVariables": map[string]interface{}{"FOO": "bar"}, "javaVersion": nil},
+			"UnregisteredConfigType": []interface{}{1, "two"},
+		}}
+		if err := a.ValidateConfigs(); err != nil {
+			t.Fatalf("expected the configs to be valid. Error: %q", err)
+		}
+	})
+	t.Run("generic configs with the wrong types are invalid", func(t *testing.T) {
+		a := transformertypes.Artifact{Name: "a", Type: JarArtifactType, Configs: map[transformertypes.ConfigType]interface{}{
+			ServiceConfigType: map[string]interface{}{"serviceName": []interface{}{"svc"}},
+			JarConfigType:     map[string]interface{}{"port": "8080"},
+		}}
+		err := a.ValidateConfigs()
+		if err == nil {
+			t.Fatalf("expected the configs to be invalid")
+		}
+		for _, expected := range []string{"config 'Service'", "serviceName", "config 'Jar'", "port"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Fatalf("expected the error to mention '%s' . Actual: %q", expected, err)
+			}
+		}
+	})
+	t.Run("generic configs with unknown keys are invalid", func(t *testing.T) {
+		a := transformertypes.Artifact{Name: "a", Type: JarArtifactType, Configs: map[transformertypes.ConfigType]interface{}{
+			ServiceConfigType: map[string]interface{}{"serviceName": "svc", "unknownKey": 1},
+		}}
+		err := a.ValidateConfigs()
+		if err == nil {
+			t.Fatalf("expected the configs with an unknown key to be invalid")
+		}
+		if !strings.Contains(err.Error(), "unknownKey") {
+			t.Fatalf("expected the error to mention the unknown key. Actual: %q", err)
+		}
+	})
+	t.Run("configs that can not be checked against the schema are invalid", func(t *testing.T) {
+		a := transformertypes.Artifact{Name: "a", Type: JarArtifactType, Configs: map[transformertypes.ConfigType]interface{}{
+			JarConfigType: map[string]interface{}{"port": math.NaN()},
+		}}
+		if err := a.ValidateConfigs(); err == nil {
+			t.Fatalf("expected an error for a config with non string keys")
+		}
+	})
+	t.Run("configs are checked against the schema of the artifact type", func(t *testing.T) {
+		configs := map[transformertypes.ConfigType]interface{}{NewImagesConfigType: map[string]interface{}{"imageNames": "img"}}
+		a := transformertypes.Artifact{Name: "a", Type: NewImagesArtifactType, Configs: configs}
+		if err := a.ValidateConfigs(); err == nil {
+			t.Fatalf("expected the configs to be invalid for the artifact type '%s'", a.Type)
+		}
+		a.Type = ServiceArtifactType
+		if err := a.ValidateConfigs(); err != nil {
+			t.Fatalf("expected the configs to not be checked for the artifact type '%s' . Error: %q", a.Type, err)
+		}
+	})
+}
+
+func TestConfigSchemaVersions(t *testing.T) {
+	type schemaVersion struct {
+		Version string `yaml:"version"`
+		SHA256  string `yaml:"sha256"`
+	}
+	recordedPath := filepath.Join("testdata", "schemaversions.yaml")
+	recordedBytes, err := os.ReadFile(recordedPath)
+	if err != nil {
+		t.Fatalf("failed to read the file '%s' . Error: %q", recordedPath, err)
+	}
+	recorded := map[transformertypes.ConfigType]schemaVersion{}
+	if err := yaml.Unmarshal(recordedBytes, &recorded); err != nil {
+		t.Fatalf("failed to parse the file '%s' as yaml. Error: %q", recordedPath, err)
+	}
+	for configType, cs := range configSchemas {
+		schema, ok := transformertypes.GetConfigSchema(cs.artifactType, configType)
+		if !ok {
+			t.Fatalf("the schema for the config type '%s' is not registered", configType)
+		}
+		schemaBytes, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatalf("failed to encode the schema for the config type '%s' as json. Error: %q", configType, err)
+		}
+		actual := schemaVersion{Version: cs.version, SHA256: fmt.Sprintf("%x", sha256.Sum256(schemaBytes))}
+		expected, ok := recorded[configType]
+		if !ok {
+			t.Errorf("the schema for the config type '%s' is not recorded in the file '%s' . Add:\n%s:\n  version: %s\n  sha256: %s", configType, recordedPath, configType, actual.Version, actual.SHA256)
+			continue
+		}
+		if expected.Version == actual.Version && expected.SHA256 != actual.SHA256 {
+			t.Errorf("the schema for the config type '%s' changed without bumping its version '%s' . Bump the version in schemas.go and record the new version and the sha256 '%s' in the file '%s'", configType, actual.Version, actual.SHA256, recordedPath)
+			continue
+		}
+		if expected != actual {
+			t.Errorf("the schema version of the config type '%s' changed. Record the version '%s' and the sha256 '%s' in the file '%s'", configType, actual.Version, actual.SHA256, recordedPath)
+		}
+	}
+}
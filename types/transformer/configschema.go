@@ -0,0 +1,175 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/konveyor/move2kube/common"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// AnyArtifactType is used to register a config schema that applies to the configs of every artifact type
+const AnyArtifactType ArtifactType = ""
+
+// configSchema is the schema for the configs of a config type
+type configSchema struct {
+	version  string
+	goType   reflect.Type
+	schema   map[string]interface{}
+	compiled *gojsonschema.Schema
+}
+
+// configSchemaKey identifies a schema by the artifact type and the config type it applies to
+type configSchemaKey struct {
+	artifactType ArtifactType
+	configType   ConfigType
+}
+
+var (
+	configSchemas      = map[configSchemaKey]configSchema{}
+	configSchemasMutex = sync.RWMutex{}
+)
+
+// RegisterConfigSchema registers the Go type that the configs of the config type are decoded into, for artifacts of the artifact type.
+// Use AnyArtifactType to register the type for the configs of every artifact type. A registration for a specific artifact type takes precedence.
+// The JSON schema used to validate the configs is generated from the type.
+// The version is a semantic version that should be bumped whenever the type changes, transformers can require a range of versions.
+// All the registrations of a config type must have the same version.
+func RegisterConfigSchema(artifactType ArtifactType, configType ConfigType, version string, obj interface{}) error {
+	if _, err := semver.NewVersion(version); err != nil {
+		return fmt.Errorf("the version '%s' of the schema for the config type '%s' is not a valid semantic version. Error: %w", version, configType, err)
+	}
+	goType := reflect.TypeOf(obj)
+	if goType == nil {
+		return fmt.Errorf("the object for the config type '%s' is nil", configType)
+	}
+	for goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+	schema := common.GetJSONSchemaForType(goType)
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+	if err != nil {
+		return fmt.Errorf("failed to compile the schema generated from the type %s for the config type '%s' . Error: %w", goType, configType, err)
+	}
+	configSchemasMutex.Lock()
+	defer configSchemasMutex.Unlock()
+	for key, cs := range configSchemas {
+		if key.configType == configType && key.artifactType != artifactType && cs.version != version {
+			return fmt.Errorf("the version '%s' of the schema for the config type '%s' does not match the version '%s' it was registered with for the artifact type '%s'", version, configType, cs.version, key.artifactType)
+		}
+	}
+	configSchemas[configSchemaKey{artifactType: artifactType, configType: configType}] = configSchema{version: version, goType: goType, schema: schema, compiled: compiled}
+	return nil
+}
+
+// getConfigSchema returns the schema for the configs of the config type in artifacts of the artifact type
+func getConfigSchema(artifactType ArtifactType, configType ConfigType) (configSchema, bool) {
+	configSchemasMutex.RLock()
+	defer configSchemasMutex.RUnlock()
+	if cs, ok := configSchemas[configSchemaKey{artifactType: artifactType, configType: configType}]; ok {
+		return cs, true
+	}
+	cs, ok := configSchemas[configSchemaKey{artifactType: AnyArtifactType, configType: configType}]
+	return cs, ok
+}
+
+// GetConfigSchema returns the JSON schema for the configs of the config type in artifacts of the artifact type
+func GetConfigSchema(artifactType ArtifactType, configType ConfigType) (map[string]interface{}, bool) {
+	cs, ok := getConfigSchema(artifactType, configType)
+	return cs.schema, ok
+}
+
+// GetConfigSchemaVersion returns the version of the schema for the config type
+func GetConfigSchemaVersion(configType ConfigType) (string, bool) {
+	configSchemasMutex.RLock()
+	defer configSchemasMutex.RUnlock()
+	for key, cs := range configSchemas {
+		if key.configType == configType {
+			return cs.version, true
+		}
+	}
+	return "", false
+}
+
+// GetConfigTypesWithSchema returns the sorted list of config types that have a schema for any artifact type
+func GetConfigTypesWithSchema() []ConfigType {
+	configSchemasMutex.RLock()
+	defer configSchemasMutex.RUnlock()
+	configTypes := []ConfigType{}
+	for key := range configSchemas {
+		configTypes = common.AppendIfNotPresent(configTypes, key.configType)
+	}
+	sort.Strings(configTypes)
+	return configTypes
+}
+
+// ValidateConfigs checks that the configs of the artifact can be decoded into the types registered for their config types.
+// The schema registered for the artifact type is used if there is one, otherwise the one registered for any artifact type.
+// Configs that already have the registered type and configs without a registered schema are not checked.
+func (a *Artifact) ValidateConfigs() error {
+	configTypes := []ConfigType{}
+	for configType := range a.Configs {
+		configTypes = append(configTypes, configType)
+	}
+	sort.Strings(configTypes)
+	problems := []string{}
+	for _, configType := range configTypes {
+		if err := validateConfig(a.Type, configType, a.Configs[configType]); err != nil {
+			problems = append(problems, fmt.Sprintf("config '%s': %s", configType, err))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("the artifact '%s' of type '%s' has invalid configs:\n%s", a.Name, a.Type, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+func validateConfig(artifactType ArtifactType, configType ConfigType, config interface{}) error {
+	cs, ok := getConfigSchema(artifactType, configType)
+	if !ok || config == nil {
+		return nil
+	}
+	configValueType := reflect.TypeOf(config)
+	for configValueType.Kind() == reflect.Ptr {
+		configValueType = configValueType.Elem()
+	}
+	if configValueType == cs.goType {
+		return nil
+	}
+	doc, err := common.GetMapInterfaceFromObj(config)
+	if err != nil {
+		return fmt.Errorf("failed to convert the config to a generic object. Error: %w", err)
+	}
+	result, err := cs.compiled.Validate(gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return fmt.Errorf("failed to validate the config against the schema of the type %s . Error: %w", cs.goType, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	descs := []string{}
+	for _, resultErr := range result.Errors() {
+		descs = append(descs, resultErr.String())
+	}
+	return fmt.Errorf("does not match the schema of the type %s : %s", cs.goType, strings.Join(descs, "; "))
+}
@@ -0,0 +1,69 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PreviousOutputGroupName is the group used in the dry run summary for files of a previous run that would be removed
+const PreviousOutputGroupName = "(previous output)"
+
+// UnattributedOutputGroupName is the group used in the dry run summary for files that were written to the output directory without a path mapping
+const UnattributedOutputGroupName = "(unattributed)"
+
+// DryRunChanges lists the changes that a single transformer would make to the output directory
+type DryRunChanges struct {
+	Created      []string `yaml:"created,omitempty" json:"created,omitempty"`
+	Overwritten  []string `yaml:"overwritten,omitempty" json:"overwritten,omitempty"`
+	Deleted      []string `yaml:"deleted,omitempty" json:"deleted,omitempty"`
+	PathMappings string   `yaml:"pathMappings,omitempty" json:"pathMappings,omitempty"`
+}
+
+// DryRunSummary lists the changes that a transformation would make to the output directory, grouped by transformer name
+type DryRunSummary map[string]*DryRunChanges
+
+// String returns a human readable summary
+func (s DryRunSummary) String() string {
+	names := []string{}
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sb := strings.Builder{}
+	for _, name := range names {
+		changes := s[name]
+		sb.WriteString(fmt.Sprintf("%s:\n", name))
+		for _, c := range []struct {
+			action string
+			paths  []string
+		}{{"create", changes.Created}, {"overwrite", changes.Overwritten}, {"delete", changes.Deleted}} {
+			for _, path := range c.paths {
+				sb.WriteString(fmt.Sprintf("  %-9s %s\n", c.action, path))
+			}
+		}
+		if changes.PathMappings != "" {
+			sb.WriteString("  path mappings:\n")
+			for _, line := range strings.Split(changes.PathMappings, "\n") {
+				sb.WriteString(fmt.Sprintf("    %s\n", line))
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,37 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+// TransformerSummary is a short description of an initialized transformer
+type TransformerSummary struct {
+	Name           string            `yaml:"name" json:"name"`
+	Class          string            `yaml:"class" json:"class"`
+	Labels         map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	SortOrder      int               `yaml:"sortOrder" json:"sortOrder"`
+	Isolated       bool              `yaml:"isolated" json:"isolated"`
+	ContainerBased bool              `yaml:"containerBased" json:"containerBased"`
+}
+
+// TransformerDescription is the resolved configuration of an initialized transformer
+type TransformerDescription struct {
+	Transformer    `yaml:",inline"`
+	YamlPath       string   `yaml:"yamlPath" json:"yamlPath"`
+	TemplatesPath  string   `yaml:"templatesPath,omitempty" json:"templatesPath,omitempty"`
+	SortOrder      int      `yaml:"sortOrder" json:"sortOrder"`
+	ContainerBased bool     `yaml:"containerBased" json:"containerBased"`
+	Overrides      []string `yaml:"overrides,omitempty" json:"overrides,omitempty"`
+}
@@ -42,4 +42,6 @@ type PathMapping struct {
 	SrcPath        string          `yaml:"sourcePath" json:"sourcePath" m2kpath:"normal"`
 	DestPath       string          `yaml:"destinationPath" json:"destinationPath" m2kpath:"normal"` // Relative to output directory
 	TemplateConfig interface{}     `yaml:"templateConfig" json:"templateConfig"`
+	// Provenance is filled by move2kube after the transformer returns the path mapping
+	Provenance *PathMappingProvenance `yaml:"provenance,omitempty" json:"provenance,omitempty"`
 }
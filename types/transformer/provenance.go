@@ -0,0 +1,46 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+// ProvenanceFileName is the name of the file where the provenance of the output files is written
+const ProvenanceFileName = "m2k-provenance.json"
+
+// ArtifactReference identifies an artifact
+type ArtifactReference struct {
+	Name string       `yaml:"name" json:"name"`
+	Type ArtifactType `yaml:"type" json:"type"`
+}
+
+// PathMappingProvenance records the transformer and the artifacts that produced a path mapping
+type PathMappingProvenance struct {
+	TransformerName string              `yaml:"transformerName" json:"transformerName"`
+	Artifacts       []ArtifactReference `yaml:"artifacts,omitempty" json:"artifacts,omitempty"`
+}
+
+// FileProvenance records how a file in the output directory was produced
+type FileProvenance struct {
+	TransformerName string              `json:"transformerName"`
+	Artifacts       []ArtifactReference `json:"artifacts,omitempty"`
+	SourcePath      string              `json:"sourcePath,omitempty"`
+	PathMappingType PathMappingType     `json:"pathMappingType"`
+}
+
+// ProvenanceManifest maps each file in the output directory to the path mappings that wrote it.
+// When multiple path mappings write the same file, they are listed in the order they were applied.
+type ProvenanceManifest struct {
+	Files map[string][]FileProvenance `json:"files"`
+}
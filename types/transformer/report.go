@@ -0,0 +1,125 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ReportFileName is the name of the file where the transform report is written in json format
+	ReportFileName = "m2k-report.json"
+	// ReportTextFileName is the name of the file where the transform report is written in a human readable format
+	ReportTextFileName = "m2k-report.txt"
+)
+
+// TransformerInvocation records a single run of a transformer
+type TransformerInvocation struct {
+	TransformerName      string              `yaml:"transformerName" json:"transformerName"`
+	TransformerClass     string              `yaml:"transformerClass" json:"transformerClass"`
+	Iteration            int                 `yaml:"iteration" json:"iteration"`
+	StartTime            time.Time           `yaml:"startTime" json:"startTime"`
+	DurationSeconds      float64             `yaml:"durationSeconds" json:"durationSeconds"`
+	InputArtifacts       []ArtifactReference `yaml:"inputArtifacts,omitempty" json:"inputArtifacts,omitempty"`
+	ProducedArtifacts    []ArtifactReference `yaml:"producedArtifacts,omitempty" json:"producedArtifacts,omitempty"`
+	ProducedPathMappings int                 `yaml:"producedPathMappings" json:"producedPathMappings"`
+	Error                string              `yaml:"error,omitempty" json:"error,omitempty"`
+	TimedOut             bool                `yaml:"timedOut,omitempty" json:"timedOut,omitempty"`
+}
+
+// TransformReport lists every transformer invocation of a transformation in the order they finished
+type TransformReport struct {
+	ServiceFilter *ServiceFilter          `yaml:"serviceFilter,omitempty" json:"serviceFilter,omitempty"`
+	Invocations   []TransformerInvocation `yaml:"invocations" json:"invocations"`
+}
+
+// ServiceFilter records the glob patterns used to select the services of the plan that were transformed.
+// The output of a transformation with a service filter only contains the selected services.
+type ServiceFilter struct {
+	Include          []string `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude          []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	SelectedServices []string `yaml:"selectedServices" json:"selectedServices"`
+	SkippedServices  []string `yaml:"skippedServices" json:"skippedServices"`
+}
+
+// Failed returns the invocations that returned an error
+func (r TransformReport) Failed() []TransformerInvocation {
+	failed := []TransformerInvocation{}
+	for _, invocation := range r.Invocations {
+		if invocation.Error != "" {
+			failed = append(failed, invocation)
+		}
+	}
+	return failed
+}
+
+// String returns a human readable report
+func (r TransformReport) String() string {
+	sb := strings.Builder{}
+	if r.ServiceFilter != nil {
+		sb.WriteString("PARTIAL TRANSFORMATION: only some of the services in the plan were transformed\n")
+		if len(r.ServiceFilter.Include) > 0 {
+			sb.WriteString(fmt.Sprintf("  include patterns:  %s\n", strings.Join(r.ServiceFilter.Include, ", ")))
+		}
+		if len(r.ServiceFilter.Exclude) > 0 {
+			sb.WriteString(fmt.Sprintf("  exclude patterns:  %s\n", strings.Join(r.ServiceFilter.Exclude, ", ")))
+		}
+		sb.WriteString(fmt.Sprintf("  selected services: %s\n", formatServiceNames(r.ServiceFilter.SelectedServices)))
+		sb.WriteString(fmt.Sprintf("  skipped services:  %s\n\n", formatServiceNames(r.ServiceFilter.SkippedServices)))
+	}
+	sb.WriteString(fmt.Sprintf("Transformer invocations: %d (%d failed)\n", len(r.Invocations), len(r.Failed())))
+	for _, invocation := range r.Invocations {
+		status := "OK"
+		if invocation.TimedOut {
+			status = "TIMED OUT"
+		} else if invocation.Error != "" {
+			status = "FAILED"
+		}
+		sb.WriteString(fmt.Sprintf(
+			"\niteration %d: %s (%s) %s in %s\n",
+			invocation.Iteration, invocation.TransformerName, invocation.TransformerClass, status,
+			time.Duration(invocation.DurationSeconds*float64(time.Second)).Round(time.Millisecond),
+		))
+		sb.WriteString(fmt.Sprintf("  inputs:   %s\n", formatArtifactReferences(invocation.InputArtifacts)))
+		sb.WriteString(fmt.Sprintf("  produced: %s\n", formatArtifactReferences(invocation.ProducedArtifacts)))
+		sb.WriteString(fmt.Sprintf("  path mappings: %d\n", invocation.ProducedPathMappings))
+		if invocation.Error != "" {
+			sb.WriteString(fmt.Sprintf("  error:    %s\n", invocation.Error))
+		}
+	}
+	return sb.String()
+}
+
+func formatServiceNames(serviceNames []string) string {
+	if len(serviceNames) == 0 {
+		return "none"
+	}
+	return strings.Join(serviceNames, ", ")
+}
+
+func formatArtifactReferences(artifacts []ArtifactReference) string {
+	if len(artifacts) == 0 {
+		return "none"
+	}
+	refs := []string{}
+	for _, artifact := range artifacts {
+		refs = append(refs, fmt.Sprintf("%s [%s]", artifact.Name, artifact.Type))
+	}
+	return strings.Join(refs, ", ")
+}
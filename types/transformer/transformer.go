@@ -17,6 +17,8 @@
 package transformer
 
 import (
+	"time"
+
 	"github.com/konveyor/move2kube/types"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -47,6 +49,16 @@ type TransformerSpec struct {
 	TemplatesDir        string                                 `yaml:"templates" json:"templates"` // Relative to yaml directory or working directory in image
 	Config              interface{}                            `yaml:"config" json:"config"`
 	InvokedByDefault    InvokedByDefault                       `yaml:"invokedByDefault" json:"invokedByDefault"`
+	Timeout             string                                 `yaml:"timeout,omitempty" json:"timeout,omitempty"` // Go duration string like "10m"
+	TimeoutDuration     time.Duration                          `yaml:"-" json:"-"`
+	Requires            TransformerRequirements                `yaml:"requires,omitempty" json:"requires,omitempty"`
+}
+
+// TransformerRequirements stores the versions that the transformer is compatible with.
+// The versions are semver ranges like ">= 0.3.0, < 0.4.0" . Transformers whose requirements are not satisfied are disabled.
+type TransformerRequirements struct {
+	Move2Kube       string                `yaml:"move2kube,omitempty" json:"move2kube,omitempty"`
+	ArtifactSchemas map[ConfigType]string `yaml:"artifactSchemas,omitempty" json:"artifactSchemas,omitempty"` // [config type]version range
 }
 
 // InvokedByDefault stores config to toggle transformers invoke by default
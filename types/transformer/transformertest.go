@@ -0,0 +1,91 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// TransformerTestInputArtifactsFileName is the file in the test case directory that contains the artifacts given to the transformer
+	TransformerTestInputArtifactsFileName = "artifacts.yaml"
+	// TransformerTestSourceDirName is the directory in the test case directory that is used as the source directory
+	TransformerTestSourceDirName = "source"
+	// TransformerTestGoldenDirName is the directory in the test case directory that contains the expected output of the transformer
+	TransformerTestGoldenDirName = "golden"
+	// TransformerTestGoldenPathMappingsFileName is the golden file that contains the expected path mappings
+	TransformerTestGoldenPathMappingsFileName = "pathmappings.yaml"
+	// TransformerTestGoldenArtifactsFileName is the golden file that contains the expected created artifacts
+	TransformerTestGoldenArtifactsFileName = "artifacts.yaml"
+)
+
+// TransformerTestStatus is the outcome of running a transformer against a test case
+type TransformerTestStatus string
+
+const (
+	// TransformerTestPassed means the output of the transformer matched the golden files
+	TransformerTestPassed TransformerTestStatus = "passed"
+	// TransformerTestFailed means the output of the transformer did not match the golden files
+	TransformerTestFailed TransformerTestStatus = "failed"
+	// TransformerTestUpdated means the golden files were overwritten with the output of the transformer
+	TransformerTestUpdated TransformerTestStatus = "updated"
+	// TransformerTestError means the transformer or the test case could not be run
+	TransformerTestError TransformerTestStatus = "error"
+)
+
+// TransformerTestResult is the result of running a transformer against a single test case
+type TransformerTestResult struct {
+	TestCase string                `yaml:"testCase" json:"testCase"`
+	Status   TransformerTestStatus `yaml:"status" json:"status"`
+	Diff     string                `yaml:"diff,omitempty" json:"diff,omitempty"`
+	Error    string                `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// TransformerTestReport contains the results of running a transformer against its test cases
+type TransformerTestReport struct {
+	Transformer string                  `yaml:"transformer" json:"transformer"`
+	Results     []TransformerTestResult `yaml:"results" json:"results"`
+}
+
+// Failed returns true if any of the test cases failed or could not be run
+func (r TransformerTestReport) Failed() bool {
+	for _, result := range r.Results {
+		if result.Status == TransformerTestFailed || result.Status == TransformerTestError {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable report
+func (r TransformerTestReport) String() string {
+	sb := strings.Builder{}
+	for _, result := range r.Results {
+		sb.WriteString(fmt.Sprintf("%-7s %s\n", strings.ToUpper(string(result.Status)), result.TestCase))
+		if result.Error != "" {
+			sb.WriteString(fmt.Sprintf("        %s\n", result.Error))
+		}
+		if result.Diff != "" {
+			sb.WriteString(result.Diff)
+			if !strings.HasSuffix(result.Diff, "\n") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String()
+}
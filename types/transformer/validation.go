@@ -0,0 +1,90 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationSeverity is the severity of a problem found while validating the transformers
+type ValidationSeverity string
+
+const (
+	// ValidationError is a problem that will cause the transformation to fail or behave incorrectly
+	ValidationError ValidationSeverity = "error"
+	// ValidationWarning is a problem that might cause the transformation to behave unexpectedly
+	ValidationWarning ValidationSeverity = "warning"
+	// ValidationInfo is an observation that is usually harmless
+	ValidationInfo ValidationSeverity = "info"
+)
+
+// ValidationIssueKind is the kind of problem found while validating the transformers
+type ValidationIssueKind string
+
+const (
+	// InitFailedIssue means the transformer could not be initialized
+	InitFailedIssue ValidationIssueKind = "InitFailed"
+	// IncompatibleVersionIssue means the version requirements of the transformer are not satisfied
+	IncompatibleVersionIssue ValidationIssueKind = "IncompatibleVersion"
+	// UnreachableTransformerIssue means no artifact would ever be given to the transformer
+	UnreachableTransformerIssue ValidationIssueKind = "UnreachableTransformer"
+	// UnproducedArtifactTypeIssue means the transformer consumes an artifact type that no transformer produces
+	UnproducedArtifactTypeIssue ValidationIssueKind = "UnproducedArtifactType"
+	// UnconsumedArtifactTypeIssue means the transformer produces an artifact type that no transformer consumes
+	UnconsumedArtifactTypeIssue ValidationIssueKind = "UnconsumedArtifactType"
+	// CycleIssue means the transformers produce and consume artifacts in a cycle
+	CycleIssue ValidationIssueKind = "Cycle"
+	// SelectorConflictIssue means a dependency or override selector does not select the expected transformers
+	SelectorConflictIssue ValidationIssueKind = "SelectorConflict"
+)
+
+// ValidationIssue is a single problem found while validating the transformers
+type ValidationIssue struct {
+	Severity     ValidationSeverity  `yaml:"severity" json:"severity"`
+	Kind         ValidationIssueKind `yaml:"kind" json:"kind"`
+	Transformers []string            `yaml:"transformers,omitempty" json:"transformers,omitempty"`
+	ArtifactType ArtifactType        `yaml:"artifactType,omitempty" json:"artifactType,omitempty"`
+	Message      string              `yaml:"message" json:"message"`
+}
+
+// ValidationReport lists the problems found while validating the transformers
+type ValidationReport struct {
+	Issues []ValidationIssue `yaml:"issues" json:"issues"`
+}
+
+// HasErrors returns true if any of the issues is an error
+func (r ValidationReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == ValidationError {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable report
+func (r ValidationReport) String() string {
+	if len(r.Issues) == 0 {
+		return "No problems found.\n"
+	}
+	sb := strings.Builder{}
+	for _, issue := range r.Issues {
+		sb.WriteString(fmt.Sprintf("%-7s %-22s %s\n", strings.ToUpper(string(issue.Severity)), issue.Kind, issue.Message))
+	}
+	return sb.String()
+}